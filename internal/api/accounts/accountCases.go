@@ -0,0 +1,257 @@
+package accounts
+
+import (
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/currencies"
+	"github.com/Renan-Parise/finances/internal/cache"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+const (
+	defaultHistoryDays = 30
+	maxHistoryDays     = 366
+)
+
+type AccountUseCase interface {
+	CreateAccount(userID int64, name, accountType, currency string, openingBalance money.Money) (*Account, error)
+	GetAccounts(userID int64) ([]*Account, error)
+	UpdateAccount(account *Account) error
+	DeleteAccount(userID int64, id int64) error
+	CreateTransfer(userID, fromAccountID, toAccountID int64, amount money.Money, description string) (*Transfer, error)
+	DeleteTransfer(userID int64, id int64) error
+	GetBalances(userID int64) ([]*AccountBalance, error)
+	GetBalanceHistory(userID int64, accountID int64, from, to time.Time) ([]*BalancePoint, error)
+}
+
+type accountUseCase struct {
+	accountRepo  AccountRepository
+	currencyRepo currencies.CurrencyRepository
+	cache        cache.Invalidator
+}
+
+func NewAccountUseCase(ar AccountRepository, cr currencies.CurrencyRepository, ci cache.Invalidator) AccountUseCase {
+	return &accountUseCase{accountRepo: ar, currencyRepo: cr, cache: ci}
+}
+
+func (uc *accountUseCase) CreateAccount(userID int64, name, accountType, currency string, openingBalance money.Money) (*Account, error) {
+	if !validTypes[accountType] {
+		return nil, errors.NewValidationError("type", "invalid account type: "+accountType)
+	}
+
+	if currency == "" {
+		baseCurrency, err := uc.currencyRepo.GetBaseCurrency(userID)
+		if err != nil {
+			return nil, err
+		}
+		currency = baseCurrency
+	}
+
+	currency, ok := currencies.NormalizeCurrency(currency)
+	if !ok {
+		return nil, errors.NewValidationError("currency", "invalid currency code")
+	}
+
+	account := NewAccount(userID, name, accountType, currency, openingBalance)
+	if err := uc.accountRepo.Create(account); err != nil {
+		return nil, err
+	}
+	cache.InvalidateUserOrLog(uc.cache, userID)
+	return account, nil
+}
+
+func (uc *accountUseCase) GetAccounts(userID int64) ([]*Account, error) {
+	return uc.accountRepo.GetAll(userID)
+}
+
+func (uc *accountUseCase) UpdateAccount(account *Account) error {
+	if !validTypes[account.Type] {
+		return errors.NewValidationError("type", "invalid account type: "+account.Type)
+	}
+
+	existing, err := uc.getAccount(account.UserID, account.ID)
+	if err != nil {
+		return err
+	}
+
+	account.Currency = existing.Currency
+	account.OpeningBalance.Currency = existing.Currency
+	account.UpdatedAt = time.Now()
+	if err := uc.accountRepo.Update(account); err != nil {
+		return err
+	}
+	cache.InvalidateUserOrLog(uc.cache, account.UserID)
+	return nil
+}
+
+func (uc *accountUseCase) DeleteAccount(userID int64, id int64) error {
+	if _, err := uc.getAccount(userID, id); err != nil {
+		return err
+	}
+
+	hasTransactions, err := uc.accountRepo.HasTransactions(userID, id)
+	if err != nil {
+		return errors.NewServiceError("error checking account transactions: " + err.Error())
+	}
+
+	if hasTransactions {
+		return errors.NewValidationError("id", "account still has transactions and cannot be deleted")
+	}
+
+	if err := uc.accountRepo.Delete(userID, id); err != nil {
+		return err
+	}
+	cache.InvalidateUserOrLog(uc.cache, userID)
+	return nil
+}
+
+func (uc *accountUseCase) CreateTransfer(userID, fromAccountID, toAccountID int64, amount money.Money, description string) (*Transfer, error) {
+	if fromAccountID == toAccountID {
+		return nil, errors.NewValidationError("toAccountId", "cannot transfer to the same account")
+	}
+
+	if !amount.IsPositive() {
+		return nil, errors.NewValidationError("amount", "transfer amount must be positive")
+	}
+
+	from, err := uc.getAccount(userID, fromAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := uc.getAccount(userID, toAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if from.Currency != to.Currency {
+		return nil, errors.NewValidationError("toAccountId", "transfers between accounts in different currencies are not supported")
+	}
+
+	amount.Currency = from.Currency
+
+	transfer := NewTransfer(userID, fromAccountID, toAccountID, amount, description)
+	if err := uc.accountRepo.CreateTransfer(transfer); err != nil {
+		return nil, err
+	}
+	cache.InvalidateUserOrLog(uc.cache, userID)
+	return transfer, nil
+}
+
+func (uc *accountUseCase) DeleteTransfer(userID int64, id int64) error {
+	locked, err := uc.accountRepo.IsTransferLocked(userID, id)
+	if err != nil {
+		return err
+	}
+
+	if locked {
+		return errors.NewValidationError("id", "transfer has a reconciled and locked transaction; unlock it first")
+	}
+
+	if err := uc.accountRepo.DeleteTransfer(userID, id); err != nil {
+		return err
+	}
+	cache.InvalidateUserOrLog(uc.cache, userID)
+	return nil
+}
+
+func (uc *accountUseCase) GetBalances(userID int64) ([]*AccountBalance, error) {
+	return uc.accountRepo.GetBalances(userID)
+}
+
+func (uc *accountUseCase) GetBalanceHistory(userID int64, accountID int64, from, to time.Time) ([]*BalancePoint, error) {
+	account, err := uc.getAccount(userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if to.IsZero() {
+		to = time.Now()
+	}
+	to = truncateDay(to)
+
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -defaultHistoryDays)
+	}
+	from = truncateDay(from)
+
+	if from.After(to) {
+		return nil, errors.NewValidationError("from", "from must be before to")
+	}
+
+	if to.Sub(from) > maxHistoryDays*24*time.Hour {
+		return nil, errors.NewValidationError("from", "history range cannot exceed one year")
+	}
+
+	balance, err := uc.accountRepo.GetBalanceBefore(userID, accountID, from)
+	if err != nil {
+		return nil, err
+	}
+	balance.Currency = account.Currency
+
+	changes, err := uc.accountRepo.GetDailyChanges(userID, accountID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	var history []*BalancePoint
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		if change, ok := changes[date]; ok {
+			change.Currency = account.Currency
+			balance = balance.Add(change)
+		}
+		history = append(history, &BalancePoint{Date: date, Balance: balance})
+	}
+	return history, nil
+}
+
+func (uc *accountUseCase) getAccount(userID int64, id int64) (*Account, error) {
+	account, err := uc.accountRepo.GetByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if account == nil {
+		return nil, errors.NewValidationError("accountId", "account not found")
+	}
+	return account, nil
+}
+
+// ResolveCurrency returns the currency of an amount posted to accountID, or to
+// no account.
+func ResolveCurrency(ar AccountRepository, cr currencies.CurrencyRepository, userID, accountID int64, currency string) (string, error) {
+	if currency != "" {
+		normalized, ok := currencies.NormalizeCurrency(currency)
+		if !ok {
+			return "", errors.NewValidationError("currency", "invalid currency code")
+		}
+		currency = normalized
+	}
+
+	if accountID != 0 {
+		account, err := ar.GetByID(userID, accountID)
+		if err != nil {
+			return "", err
+		}
+
+		if account == nil {
+			return "", errors.NewValidationError("accountId", "account not found")
+		}
+
+		if currency != "" && currency != account.Currency {
+			return "", errors.NewValidationError("currency", "currency must match the account currency "+account.Currency)
+		}
+		return account.Currency, nil
+	}
+
+	if currency == "" {
+		return cr.GetBaseCurrency(userID)
+	}
+	return currency, nil
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
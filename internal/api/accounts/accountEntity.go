@@ -0,0 +1,57 @@
+package accounts
+
+import (
+	"time"
+
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+const (
+	TypeChecking   = "checking"
+	TypeSavings    = "savings"
+	TypeCreditCard = "credit_card"
+	TypeCash       = "cash"
+	TypeInvestment = "investment"
+)
+
+var validTypes = map[string]bool{
+	TypeChecking:   true,
+	TypeSavings:    true,
+	TypeCreditCard: true,
+	TypeCash:       true,
+	TypeInvestment: true,
+}
+
+type Account struct {
+	ID             int64       `json:"id"`
+	UserID         int64       `json:"userId"`
+	Name           string      `json:"name"`
+	Type           string      `json:"type"`
+	Currency       string      `json:"currency"`
+	OpeningBalance money.Money `json:"openingBalance"`
+	CreatedAt      time.Time   `json:"createdAt"`
+	UpdatedAt      time.Time   `json:"updatedAt"`
+}
+
+type AccountBalance struct {
+	AccountID int64       `json:"accountId"`
+	Name      string      `json:"name"`
+	Type      string      `json:"type"`
+	Currency  string      `json:"currency"`
+	Balance   money.Money `json:"balance"`
+}
+
+type BalancePoint struct {
+	Date    string      `json:"date"`
+	Balance money.Money `json:"balance"`
+}
+
+type Transfer struct {
+	ID            int64       `json:"id"`
+	UserID        int64       `json:"userId"`
+	FromAccountID int64       `json:"fromAccountId"`
+	ToAccountID   int64       `json:"toAccountId"`
+	Amount        money.Money `json:"amount"`
+	Description   string      `json:"description"`
+	CreatedAt     time.Time   `json:"createdAt"`
+}
@@ -0,0 +1,32 @@
+package accounts
+
+import (
+	"time"
+
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+func NewAccount(userID int64, name, accountType, currency string, openingBalance money.Money) *Account {
+	now := time.Now()
+	openingBalance.Currency = currency
+	return &Account{
+		UserID:         userID,
+		Name:           name,
+		Type:           accountType,
+		Currency:       currency,
+		OpeningBalance: openingBalance,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+func NewTransfer(userID, fromAccountID, toAccountID int64, amount money.Money, description string) *Transfer {
+	return &Transfer{
+		UserID:        userID,
+		FromAccountID: fromAccountID,
+		ToAccountID:   toAccountID,
+		Amount:        amount,
+		Description:   description,
+		CreatedAt:     time.Now(),
+	}
+}
@@ -0,0 +1,271 @@
+package accounts
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/middlewares"
+	"github.com/Renan-Parise/finances/pkg/money"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccountHandler struct {
+	accountUseCase AccountUseCase
+}
+
+func NewAccountHandler(router *gin.RouterGroup, au AccountUseCase) {
+	handler := &AccountHandler{
+		accountUseCase: au,
+	}
+
+	accounts := router.Group("/accounts")
+	accounts.Use(middlewares.JWTAuthMiddleware())
+	{
+		accounts.POST("/", handler.CreateAccount)
+		accounts.GET("/", handler.GetAccounts)
+		accounts.GET("/balances", handler.GetBalances)
+		accounts.PUT("/:id", handler.UpdateAccount)
+		accounts.DELETE("/:id", handler.DeleteAccount)
+		accounts.GET("/:id/history", handler.GetBalanceHistory)
+		accounts.POST("/transfers", handler.CreateTransfer)
+		accounts.DELETE("/transfers/:id", handler.DeleteTransfer)
+	}
+}
+
+func (h *AccountHandler) CreateAccount(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	var input struct {
+		Name           string      `json:"name" binding:"required"`
+		Type           string      `json:"type" binding:"required"`
+		Currency       string      `json:"currency"`
+		OpeningBalance money.Money `json:"openingBalance"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	account, err := h.accountUseCase.CreateAccount(userID.(int64), input.Name, input.Type, input.Currency, input.OpeningBalance)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, account)
+}
+
+func (h *AccountHandler) GetAccounts(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	accounts, err := h.accountUseCase.GetAccounts(userID.(int64))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, accounts)
+}
+
+func (h *AccountHandler) UpdateAccount(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	var input struct {
+		Name           string      `json:"name" binding:"required"`
+		Type           string      `json:"type" binding:"required"`
+		OpeningBalance money.Money `json:"openingBalance"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	account := &Account{
+		ID:             id,
+		UserID:         userID.(int64),
+		Name:           input.Name,
+		Type:           input.Type,
+		OpeningBalance: input.OpeningBalance,
+	}
+
+	err = h.accountUseCase.UpdateAccount(account)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Account updated successfully"})
+}
+
+func (h *AccountHandler) DeleteAccount(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	err = h.accountUseCase.DeleteAccount(userID.(int64), id)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Account deleted successfully"})
+}
+
+func (h *AccountHandler) GetBalances(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	balances, err := h.accountUseCase.GetBalances(userID.(int64))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, balances)
+}
+
+func (h *AccountHandler) GetBalanceHistory(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	var from, to time.Time
+	if value := c.Query("from"); value != "" {
+		if from, err = time.Parse("2006-01-02", value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date, expected YYYY-MM-DD"})
+			return
+		}
+	}
+
+	if value := c.Query("to"); value != "" {
+		if to, err = time.Parse("2006-01-02", value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date, expected YYYY-MM-DD"})
+			return
+		}
+	}
+
+	history, err := h.accountUseCase.GetBalanceHistory(userID.(int64), id, from, to)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+func (h *AccountHandler) CreateTransfer(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	var input struct {
+		FromAccountID int64       `json:"fromAccountId" binding:"required"`
+		ToAccountID   int64       `json:"toAccountId" binding:"required"`
+		Amount        money.Money `json:"amount"`
+		Description   string      `json:"description"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transfer, err := h.accountUseCase.CreateTransfer(userID.(int64), input.FromAccountID, input.ToAccountID,
+		input.Amount, input.Description)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, transfer)
+}
+
+func (h *AccountHandler) DeleteTransfer(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transfer ID"})
+		return
+	}
+
+	err = h.accountUseCase.DeleteTransfer(userID.(int64), id)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Transfer deleted successfully"})
+}
@@ -0,0 +1,281 @@
+package accounts
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/ledger"
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+type AccountRepository interface {
+	Create(account *Account) error
+	GetAll(userID int64) ([]*Account, error)
+	GetByID(userID int64, id int64) (*Account, error)
+	Update(account *Account) error
+	Delete(userID int64, id int64) error
+	HasTransactions(userID int64, id int64) (bool, error)
+	CreateTransfer(transfer *Transfer) error
+	DeleteTransfer(userID int64, id int64) error
+	IsTransferLocked(userID int64, id int64) (bool, error)
+	GetBalances(userID int64) ([]*AccountBalance, error)
+	GetBalanceBefore(userID int64, accountID int64, before time.Time) (money.Money, error)
+	GetDailyChanges(userID int64, accountID int64, from, to time.Time) (map[string]money.Money, error)
+}
+
+type accountRepository struct {
+	db *sql.DB
+}
+
+func NewAccountRepository(db *sql.DB) AccountRepository {
+	return &accountRepository{db: db}
+}
+
+func (r *accountRepository) Create(account *Account) error {
+	query := `INSERT INTO accounts (userId, name, type, currency, openingBalance, createdAt, updatedAt) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return errors.NewQueryError("error preparing query: " + err.Error())
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(account.UserID, account.Name, account.Type, account.Currency, account.OpeningBalance,
+		account.CreatedAt, account.UpdatedAt)
+	if err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return errors.NewQueryError("error getting last insert ID: " + err.Error())
+	}
+
+	account.ID = id
+	return nil
+}
+
+func (r *accountRepository) GetAll(userID int64) ([]*Account, error) {
+	query := `SELECT id, userId, name, type, currency, openingBalance, createdAt, updatedAt FROM accounts WHERE userId = ? ORDER BY name`
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	var accounts []*Account
+	for rows.Next() {
+		account, err := scanAccount(rows)
+		if err != nil {
+			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+func (r *accountRepository) GetByID(userID int64, id int64) (*Account, error) {
+	query := `SELECT id, userId, name, type, currency, openingBalance, createdAt, updatedAt FROM accounts WHERE id = ? AND userId = ?`
+	account, err := scanAccount(r.db.QueryRow(query, id, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.NewQueryError("error scanning row: " + err.Error())
+	}
+	return account, nil
+}
+
+func (r *accountRepository) Update(account *Account) error {
+	query := `UPDATE accounts SET name = ?, type = ?, openingBalance = ?, updatedAt = ? WHERE id = ? AND userId = ?`
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return errors.NewQueryError("error preparing query: " + err.Error())
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(account.Name, account.Type, account.OpeningBalance, account.UpdatedAt, account.ID, account.UserID)
+	return err
+}
+
+func (r *accountRepository) Delete(userID int64, id int64) error {
+	query := `DELETE FROM accounts WHERE id = ? AND userId = ?`
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return errors.NewQueryError("error preparing query: " + err.Error())
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(id, userID)
+	return err
+}
+
+func (r *accountRepository) HasTransactions(userID int64, id int64) (bool, error) {
+	query := `SELECT COUNT(*) FROM transactions WHERE userId = ? AND accountId = ?`
+	var count int
+	err := r.db.QueryRow(query, userID, id).Scan(&count)
+	if err != nil {
+		return false, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	return count > 0, nil
+}
+
+func (r *accountRepository) CreateTransfer(transfer *Transfer) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return errors.NewDatabaseError("error starting transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`INSERT INTO transfers (userId, fromAccountId, toAccountId, amount, description, createdAt)
+              VALUES (?, ?, ?, ?, ?, ?)`,
+		transfer.UserID, transfer.FromAccountID, transfer.ToAccountID, transfer.Amount,
+		transfer.Description, transfer.CreatedAt)
+	if err != nil {
+		return errors.NewQueryError("error inserting transfer: " + err.Error())
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return errors.NewQueryError("error getting last insert ID: " + err.Error())
+	}
+
+	legs := []struct {
+		accountID int64
+		amount    money.Money
+	}{
+		{transfer.FromAccountID, transfer.Amount.Neg()},
+		{transfer.ToAccountID, transfer.Amount},
+	}
+	for _, leg := range legs {
+		_, err := ledger.Insert(tx, &ledger.Entry{
+			UserID:      transfer.UserID,
+			AccountID:   leg.accountID,
+			TransferID:  id,
+			OccurredAt:  transfer.CreatedAt,
+			CreatedAt:   transfer.CreatedAt,
+			UpdatedAt:   transfer.CreatedAt,
+			Description: transfer.Description,
+			Amount:      leg.amount,
+			Currency:    leg.amount.Currency,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+
+	transfer.ID = id
+	return nil
+}
+
+func (r *accountRepository) DeleteTransfer(userID int64, id int64) error {
+	query := `DELETE FROM transfers WHERE id = ? AND userId = ?`
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return errors.NewQueryError("error preparing query: " + err.Error())
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(id, userID)
+	return err
+}
+
+func (r *accountRepository) IsTransferLocked(userID int64, id int64) (bool, error) {
+	query := `SELECT COUNT(*) FROM transactions WHERE userId = ? AND transferId = ? AND locked = TRUE`
+	var count int
+	err := r.db.QueryRow(query, userID, id).Scan(&count)
+	if err != nil {
+		return false, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	return count > 0, nil
+}
+
+func (r *accountRepository) GetBalances(userID int64) ([]*AccountBalance, error) {
+	query := `
+		SELECT a.id, a.name, a.type, a.currency, a.openingBalance + COALESCE(SUM(t.amount), 0) AS balance
+		FROM accounts a
+		LEFT JOIN transactions t ON t.accountId = a.id AND t.userId = a.userId
+		WHERE a.userId = ?
+		GROUP BY a.id, a.name, a.type, a.currency, a.openingBalance
+		ORDER BY a.name
+	`
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, errors.NewQueryError("Failed to get account balances: " + err.Error())
+	}
+	defer rows.Close()
+
+	var balances []*AccountBalance
+	for rows.Next() {
+		var balance AccountBalance
+		err := rows.Scan(&balance.AccountID, &balance.Name, &balance.Type, &balance.Currency, &balance.Balance)
+		if err != nil {
+			return nil, errors.NewQueryError("Failed to scan account balance: " + err.Error())
+		}
+		balance.Balance.Currency = balance.Currency
+		balances = append(balances, &balance)
+	}
+	return balances, nil
+}
+
+func (r *accountRepository) GetBalanceBefore(userID int64, accountID int64, before time.Time) (money.Money, error) {
+	query := `
+		SELECT a.openingBalance + COALESCE((
+			SELECT SUM(t.amount) FROM transactions t
+			WHERE t.userId = a.userId AND t.accountId = a.id AND t.occurredAt < ?
+		), 0)
+		FROM accounts a
+		WHERE a.id = ? AND a.userId = ?
+	`
+	var balance money.Money
+	err := r.db.QueryRow(query, before, accountID, userID).Scan(&balance)
+	if err != nil {
+		return balance, errors.NewQueryError("Failed to get account balance: " + err.Error())
+	}
+	return balance, nil
+}
+
+func (r *accountRepository) GetDailyChanges(userID int64, accountID int64, from, to time.Time) (map[string]money.Money, error) {
+	query := `
+		SELECT DATE(occurredAt) AS day, SUM(amount) AS total
+		FROM transactions
+		WHERE userId = ? AND accountId = ? AND occurredAt >= ? AND occurredAt < ?
+		GROUP BY DATE(occurredAt)
+	`
+	rows, err := r.db.Query(query, userID, accountID, from, to)
+	if err != nil {
+		return nil, errors.NewQueryError("Failed to get account history: " + err.Error())
+	}
+	defer rows.Close()
+
+	changes := make(map[string]money.Money)
+	for rows.Next() {
+		var day time.Time
+		var total money.Money
+		err := rows.Scan(&day, &total)
+		if err != nil {
+			return nil, errors.NewQueryError("Failed to scan account history: " + err.Error())
+		}
+		changes[day.Format("2006-01-02")] = total
+	}
+	return changes, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAccount(row rowScanner) (*Account, error) {
+	var account Account
+	err := row.Scan(&account.ID, &account.UserID, &account.Name, &account.Type, &account.Currency,
+		&account.OpeningBalance, &account.CreatedAt, &account.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	account.OpeningBalance.Currency = account.Currency
+	return &account, nil
+}
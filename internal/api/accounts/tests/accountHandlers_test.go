@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/accounts"
+	"github.com/Renan-Parise/finances/pkg/money"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAccountUseCase struct {
+	mock.Mock
+}
+
+func TestNewAccountHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.Default()
+	group := router.Group("/api")
+
+	mockUseCase := new(MockAccountUseCase)
+	accounts.NewAccountHandler(group, mockUseCase)
+
+	routes := router.Routes()
+
+	expectedRoutes := []struct {
+		method string
+		path   string
+	}{
+		{"POST", "/api/accounts/"},
+		{"GET", "/api/accounts/"},
+		{"GET", "/api/accounts/balances"},
+		{"PUT", "/api/accounts/:id"},
+		{"DELETE", "/api/accounts/:id"},
+		{"GET", "/api/accounts/:id/history"},
+		{"POST", "/api/accounts/transfers"},
+		{"DELETE", "/api/accounts/transfers/:id"},
+	}
+
+	for _, expected := range expectedRoutes {
+		found := false
+		for _, route := range routes {
+			if route.Method == expected.method && route.Path == expected.path {
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, "Route %s %s not registered", expected.method, expected.path)
+	}
+}
+
+func (m *MockAccountUseCase) CreateAccount(userID int64, name, accountType, currency string, openingBalance money.Money) (*accounts.Account, error) {
+	args := m.Called(userID, name, accountType, currency, openingBalance)
+	return args.Get(0).(*accounts.Account), args.Error(1)
+}
+
+func (m *MockAccountUseCase) GetAccounts(userID int64) ([]*accounts.Account, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]*accounts.Account), args.Error(1)
+}
+
+func (m *MockAccountUseCase) UpdateAccount(account *accounts.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *MockAccountUseCase) DeleteAccount(userID int64, id int64) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
+
+func (m *MockAccountUseCase) CreateTransfer(userID, fromAccountID, toAccountID int64, amount money.Money, description string) (*accounts.Transfer, error) {
+	args := m.Called(userID, fromAccountID, toAccountID, amount, description)
+	return args.Get(0).(*accounts.Transfer), args.Error(1)
+}
+
+func (m *MockAccountUseCase) DeleteTransfer(userID int64, id int64) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
+
+func (m *MockAccountUseCase) GetBalances(userID int64) ([]*accounts.AccountBalance, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]*accounts.AccountBalance), args.Error(1)
+}
+
+func (m *MockAccountUseCase) GetBalanceHistory(userID int64, accountID int64, from, to time.Time) ([]*accounts.BalancePoint, error) {
+	args := m.Called(userID, accountID, from, to)
+	return args.Get(0).([]*accounts.BalancePoint), args.Error(1)
+}
@@ -1,6 +1,7 @@
 package categories
 
 import (
+	"github.com/Renan-Parise/finances/internal/cache"
 	"github.com/Renan-Parise/finances/internal/errors"
 )
 
@@ -13,10 +14,11 @@ type CategoryUseCase interface {
 
 type categoryUseCase struct {
 	categoryRepo CategoryRepository
+	cache        cache.Invalidator
 }
 
-func NewCategoryUseCase(cr CategoryRepository) CategoryUseCase {
-	return &categoryUseCase{categoryRepo: cr}
+func NewCategoryUseCase(cr CategoryRepository, ci cache.Invalidator) CategoryUseCase {
+	return &categoryUseCase{categoryRepo: cr, cache: ci}
 }
 
 func (uc *categoryUseCase) CreateCategory(userID int64, name string) error {
@@ -30,7 +32,11 @@ func (uc *categoryUseCase) CreateCategory(userID int64, name string) error {
 	}
 
 	category := NewCategory(userID, name)
-	return uc.categoryRepo.Create(category)
+	if err := uc.categoryRepo.Create(category); err != nil {
+		return err
+	}
+	cache.InvalidateUserOrLog(uc.cache, userID)
+	return nil
 }
 
 func (uc *categoryUseCase) CreateDefaultCategories(userID int64) error {
@@ -42,6 +48,7 @@ func (uc *categoryUseCase) CreateDefaultCategories(userID int64) error {
 			return errors.NewServiceError("error creating category " + categoryName + ": " + err.Error())
 		}
 	}
+	cache.InvalidateUserOrLog(uc.cache, userID)
 	return nil
 }
 
@@ -50,5 +57,9 @@ func (uc *categoryUseCase) GetCategories(userID int64) ([]*Category, error) {
 }
 
 func (uc *categoryUseCase) DeleteCategory(userID int64, id int) error {
-	return uc.categoryRepo.Delete(userID, id)
+	if err := uc.categoryRepo.Delete(userID, id); err != nil {
+		return err
+	}
+	cache.InvalidateUserOrLog(uc.cache, userID)
+	return nil
 }
@@ -0,0 +1,80 @@
+package currencies
+
+import (
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/cache"
+	"github.com/Renan-Parise/finances/internal/errors"
+)
+
+type CurrencyUseCase interface {
+	ImportRates(r io.Reader) (*ImportResult, error)
+	GetRate(from, to string, date time.Time) (*ExchangeRate, error)
+	GetBaseCurrency(userID int64) (string, error)
+	SetBaseCurrency(userID int64, currency string) error
+}
+
+type currencyUseCase struct {
+	currencyRepo CurrencyRepository
+	provider     ExchangeRateProvider
+	cache        cache.Invalidator
+}
+
+func NewCurrencyUseCase(cr CurrencyRepository, p ExchangeRateProvider, ci cache.Invalidator) CurrencyUseCase {
+	return &currencyUseCase{currencyRepo: cr, provider: p, cache: ci}
+}
+
+func (uc *currencyUseCase) ImportRates(r io.Reader) (*ImportResult, error) {
+	rates, err := ParseRatesCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rates) == 0 {
+		return nil, errors.NewValidationError("file", "no exchange rates found")
+	}
+
+	if err := uc.currencyRepo.SaveRates(rates); err != nil {
+		return nil, err
+	}
+
+	cache.InvalidateAllOrLog(uc.cache)
+	return &ImportResult{Imported: len(rates)}, nil
+}
+
+func (uc *currencyUseCase) GetRate(from, to string, date time.Time) (*ExchangeRate, error) {
+	from, ok := NormalizeCurrency(from)
+	if !ok {
+		return nil, errors.NewValidationError("from", "invalid currency code")
+	}
+
+	to, ok = NormalizeCurrency(to)
+	if !ok {
+		return nil, errors.NewValidationError("to", "invalid currency code")
+	}
+
+	rate, err := uc.provider.Rate(from, to, date)
+	if err != nil {
+		return nil, err
+	}
+	return NewExchangeRate(from, to, date, new(big.Rat).Set(rate)), nil
+}
+
+func (uc *currencyUseCase) GetBaseCurrency(userID int64) (string, error) {
+	return uc.currencyRepo.GetBaseCurrency(userID)
+}
+
+func (uc *currencyUseCase) SetBaseCurrency(userID int64, currency string) error {
+	currency, ok := NormalizeCurrency(currency)
+	if !ok {
+		return errors.NewValidationError("currency", "invalid currency code")
+	}
+	if err := uc.currencyRepo.SetBaseCurrency(userID, currency); err != nil {
+		return err
+	}
+
+	cache.InvalidateUserOrLog(uc.cache, userID)
+	return nil
+}
@@ -0,0 +1,32 @@
+package currencies
+
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+)
+
+type ExchangeRate struct {
+	Base  string    `json:"base"`
+	Quote string    `json:"quote"`
+	Date  time.Time `json:"date"`
+	Rate  *big.Rat  `json:"-"`
+}
+
+type ImportResult struct {
+	Imported int `json:"imported"`
+}
+
+func (e *ExchangeRate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Base  string `json:"base"`
+		Quote string `json:"quote"`
+		Date  string `json:"date"`
+		Rate  string `json:"rate"`
+	}{
+		Base:  e.Base,
+		Quote: e.Quote,
+		Date:  e.Date.Format("2006-01-02"),
+		Rate:  e.Rate.FloatString(8),
+	})
+}
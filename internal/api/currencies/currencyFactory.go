@@ -0,0 +1,87 @@
+package currencies
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+)
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// NormalizeCurrency upper-cases an ISO 4217 code and reports whether it is
+// well formed.
+func NormalizeCurrency(code string) (string, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	return code, currencyCode.MatchString(code)
+}
+
+func NewExchangeRate(base, quote string, date time.Time, rate *big.Rat) *ExchangeRate {
+	return &ExchangeRate{
+		Base:  base,
+		Quote: quote,
+		Date:  time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+		Rate:  rate,
+	}
+}
+
+// ParseRatesCSV reads historical rates from a CSV with the header
+// date,base,quote,rate where one unit of base buys rate units of quote.
+func ParseRatesCSV(r io.Reader) ([]*ExchangeRate, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.NewValidationError("file", "could not read CSV header: "+err.Error())
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"date", "base", "quote", "rate"} {
+		if _, ok := columns[name]; !ok {
+			return nil, errors.NewValidationError("file", "missing column "+name)
+		}
+	}
+
+	var rates []*ExchangeRate
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.NewValidationError("file", fmt.Sprintf("line %d: %s", line, err.Error()))
+		}
+
+		date, err := time.Parse("2006-01-02", strings.TrimSpace(record[columns["date"]]))
+		if err != nil {
+			return nil, errors.NewValidationError("date", fmt.Sprintf("line %d: expected YYYY-MM-DD", line))
+		}
+
+		base, ok := NormalizeCurrency(record[columns["base"]])
+		if !ok {
+			return nil, errors.NewValidationError("base", fmt.Sprintf("line %d: invalid currency code", line))
+		}
+
+		quote, ok := NormalizeCurrency(record[columns["quote"]])
+		if !ok {
+			return nil, errors.NewValidationError("quote", fmt.Sprintf("line %d: invalid currency code", line))
+		}
+
+		rate, ok := new(big.Rat).SetString(strings.TrimSpace(record[columns["rate"]]))
+		if !ok || rate.Sign() <= 0 {
+			return nil, errors.NewValidationError("rate", fmt.Sprintf("line %d: rate must be a positive number", line))
+		}
+
+		rates = append(rates, NewExchangeRate(base, quote, date, rate))
+	}
+	return rates, nil
+}
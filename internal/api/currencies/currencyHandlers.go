@@ -0,0 +1,136 @@
+package currencies
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CurrencyHandler struct {
+	currencyUseCase CurrencyUseCase
+}
+
+func NewCurrencyHandler(router *gin.RouterGroup, cu CurrencyUseCase) {
+	handler := &CurrencyHandler{
+		currencyUseCase: cu,
+	}
+
+	currencies := router.Group("/currencies")
+	currencies.Use(middlewares.JWTAuthMiddleware())
+	{
+		currencies.GET("/base", handler.GetBaseCurrency)
+		currencies.PUT("/base", handler.SetBaseCurrency)
+		currencies.GET("/rates", handler.GetRate)
+	}
+
+	rates := router.Group("/currencies/rates")
+	rates.Use(middlewares.JWTAuthMiddleware(), middlewares.ServiceOnlyMiddleware())
+	{
+		rates.POST("/import", handler.ImportRates)
+	}
+}
+
+func (h *CurrencyHandler) GetBaseCurrency(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	currency, err := h.currencyUseCase.GetBaseCurrency(userID.(int64))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"currency": currency})
+}
+
+func (h *CurrencyHandler) SetBaseCurrency(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	var input struct {
+		Currency string `json:"currency" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.currencyUseCase.SetBaseCurrency(userID.(int64), input.Currency)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Base currency updated successfully"})
+}
+
+func (h *CurrencyHandler) GetRate(c *gin.Context) {
+	if _, exists := c.Get("userID"); !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	date := time.Now()
+	if value := c.Query("date"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date, expected YYYY-MM-DD"})
+			return
+		}
+		date = parsed
+	}
+
+	rate, err := h.currencyUseCase.GetRate(c.Query("from"), c.Query("to"), date)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rate)
+}
+
+func (h *CurrencyHandler) ImportRates(c *gin.Context) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A CSV file is required in the 'file' field"})
+		return
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer reader.Close()
+
+	result, err := h.currencyUseCase.ImportRates(reader)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
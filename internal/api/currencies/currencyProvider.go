@@ -0,0 +1,79 @@
+package currencies
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+// ExchangeRateProvider returns how many units of to one unit of from buys on
+// the given date.
+type ExchangeRateProvider interface {
+	Rate(from, to string, date time.Time) (*big.Rat, error)
+}
+
+type tableRateProvider struct {
+	currencyRepo CurrencyRepository
+}
+
+// NewTableRateProvider serves rates from the exchange_rates table, falling
+// back to the inverse pair when only the opposite direction was loaded.
+func NewTableRateProvider(cr CurrencyRepository) ExchangeRateProvider {
+	return &tableRateProvider{currencyRepo: cr}
+}
+
+func (p *tableRateProvider) Rate(from, to string, date time.Time) (*big.Rat, error) {
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+
+	rate, err := p.currencyRepo.FindRate(from, to, date)
+	if err != nil {
+		return nil, err
+	}
+	if rate != nil {
+		return rate.Rate, nil
+	}
+
+	inverse, err := p.currencyRepo.FindRate(to, from, date)
+	if err != nil {
+		return nil, err
+	}
+	if inverse != nil {
+		return new(big.Rat).Inv(inverse.Rate), nil
+	}
+
+	return nil, errors.NewValidationError("currency",
+		"no exchange rate from "+from+" to "+to+" on or before "+date.Format("2006-01-02"))
+}
+
+// Converter memoizes provider lookups for the lifetime of one computation,
+// since statistics convert many amounts that share a currency and date.
+type Converter struct {
+	provider ExchangeRateProvider
+	rates    map[string]*big.Rat
+}
+
+func NewConverter(p ExchangeRateProvider) *Converter {
+	return &Converter{provider: p, rates: make(map[string]*big.Rat)}
+}
+
+func (c *Converter) Convert(amount money.Money, to string, date time.Time) (money.Money, error) {
+	if amount.Currency == to || amount.Currency == "" {
+		return money.New(amount.Amount, to), nil
+	}
+
+	key := amount.Currency + to + date.Format("2006-01-02")
+	rate, ok := c.rates[key]
+	if !ok {
+		var err error
+		rate, err = c.provider.Rate(amount.Currency, to, date)
+		if err != nil {
+			return money.Money{}, err
+		}
+		c.rates[key] = rate
+	}
+	return amount.Convert(rate, to)
+}
@@ -0,0 +1,96 @@
+package currencies
+
+import (
+	"database/sql"
+	"math/big"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+)
+
+type CurrencyRepository interface {
+	SaveRates(rates []*ExchangeRate) error
+	FindRate(base, quote string, date time.Time) (*ExchangeRate, error)
+	GetBaseCurrency(userID int64) (string, error)
+	SetBaseCurrency(userID int64, currency string) error
+}
+
+type currencyRepository struct {
+	db *sql.DB
+}
+
+func NewCurrencyRepository(db *sql.DB) CurrencyRepository {
+	return &currencyRepository{db: db}
+}
+
+func (r *currencyRepository) SaveRates(rates []*ExchangeRate) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return errors.NewDatabaseError("error starting transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT INTO exchange_rates (base, quote, date, rate) VALUES (?, ?, ?, ?)
+              ON DUPLICATE KEY UPDATE rate = VALUES(rate)`)
+	if err != nil {
+		return errors.NewQueryError("error preparing query: " + err.Error())
+	}
+	defer stmt.Close()
+
+	for _, rate := range rates {
+		_, err := stmt.Exec(rate.Base, rate.Quote, rate.Date.Format("2006-01-02"), rate.Rate.FloatString(8))
+		if err != nil {
+			return errors.NewQueryError("error saving exchange rate: " + err.Error())
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+	return nil
+}
+
+func (r *currencyRepository) FindRate(base, quote string, date time.Time) (*ExchangeRate, error) {
+	query := `SELECT base, quote, date, rate FROM exchange_rates
+              WHERE base = ? AND quote = ? AND date <= ?
+              ORDER BY date DESC
+              LIMIT 1`
+	var rate ExchangeRate
+	var value string
+	err := r.db.QueryRow(query, base, quote, date.Format("2006-01-02")).Scan(&rate.Base, &rate.Quote, &rate.Date, &value)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.NewQueryError("error scanning row: " + err.Error())
+	}
+
+	parsed, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return nil, errors.NewQueryError("invalid exchange rate value: " + value)
+	}
+	rate.Rate = parsed
+	return &rate, nil
+}
+
+func (r *currencyRepository) GetBaseCurrency(userID int64) (string, error) {
+	query := `SELECT baseCurrency FROM users WHERE id = ?`
+	var currency string
+	err := r.db.QueryRow(query, userID).Scan(&currency)
+	if err != nil {
+		return "", errors.NewQueryError("error getting base currency: " + err.Error())
+	}
+	return currency, nil
+}
+
+func (r *currencyRepository) SetBaseCurrency(userID int64, currency string) error {
+	query := `UPDATE users SET baseCurrency = ? WHERE id = ?`
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return errors.NewQueryError("error preparing query: " + err.Error())
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(currency, userID)
+	return err
+}
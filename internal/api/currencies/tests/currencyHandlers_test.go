@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/currencies"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCurrencyUseCase struct {
+	mock.Mock
+}
+
+func TestNewCurrencyHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.Default()
+	group := router.Group("/api")
+
+	mockUseCase := new(MockCurrencyUseCase)
+	currencies.NewCurrencyHandler(group, mockUseCase)
+
+	routes := router.Routes()
+
+	expectedRoutes := []struct {
+		method string
+		path   string
+	}{
+		{"GET", "/api/currencies/base"},
+		{"PUT", "/api/currencies/base"},
+		{"GET", "/api/currencies/rates"},
+		{"POST", "/api/currencies/rates/import"},
+	}
+
+	for _, expected := range expectedRoutes {
+		found := false
+		for _, route := range routes {
+			if route.Method == expected.method && route.Path == expected.path {
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, "Route %s %s not registered", expected.method, expected.path)
+	}
+}
+
+func (m *MockCurrencyUseCase) ImportRates(r io.Reader) (*currencies.ImportResult, error) {
+	args := m.Called(r)
+	return args.Get(0).(*currencies.ImportResult), args.Error(1)
+}
+
+func (m *MockCurrencyUseCase) GetRate(from, to string, date time.Time) (*currencies.ExchangeRate, error) {
+	args := m.Called(from, to, date)
+	return args.Get(0).(*currencies.ExchangeRate), args.Error(1)
+}
+
+func (m *MockCurrencyUseCase) GetBaseCurrency(userID int64) (string, error) {
+	args := m.Called(userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockCurrencyUseCase) SetBaseCurrency(userID int64, currency string) error {
+	args := m.Called(userID, currency)
+	return args.Error(0)
+}
@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/currencies"
+	"github.com/Renan-Parise/finances/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryCurrencyRepository struct {
+	rates   []*currencies.ExchangeRate
+	lookups int
+}
+
+func (r *memoryCurrencyRepository) SaveRates(rates []*currencies.ExchangeRate) error {
+	r.rates = append(r.rates, rates...)
+	return nil
+}
+
+func (r *memoryCurrencyRepository) FindRate(base, quote string, date time.Time) (*currencies.ExchangeRate, error) {
+	r.lookups++
+	var found *currencies.ExchangeRate
+	for _, rate := range r.rates {
+		if rate.Base != base || rate.Quote != quote || rate.Date.After(date) {
+			continue
+		}
+		if found == nil || rate.Date.After(found.Date) {
+			found = rate
+		}
+	}
+	return found, nil
+}
+
+func (r *memoryCurrencyRepository) GetBaseCurrency(userID int64) (string, error) {
+	return "BRL", nil
+}
+
+func (r *memoryCurrencyRepository) SetBaseCurrency(userID int64, currency string) error {
+	return nil
+}
+
+func TestParseRatesCSV(t *testing.T) {
+	rates, err := currencies.ParseRatesCSV(strings.NewReader("date,base,quote,rate\n2026-01-02,usd,BRL,5.4321\n"))
+	assert.NoError(t, err)
+	assert.Len(t, rates, 1)
+	assert.Equal(t, "USD", rates[0].Base)
+	assert.Equal(t, "BRL", rates[0].Quote)
+	assert.Equal(t, big.NewRat(54321, 10000), rates[0].Rate)
+
+	_, err = currencies.ParseRatesCSV(strings.NewReader("date,base,quote,rate\n2026-01-02,USD,BRL,-1\n"))
+	assert.Error(t, err)
+
+	_, err = currencies.ParseRatesCSV(strings.NewReader("date,base,rate\n"))
+	assert.Error(t, err)
+}
+
+func TestTableRateProviderUsesLatestRateAndInverse(t *testing.T) {
+	repo := &memoryCurrencyRepository{}
+	rates, _ := currencies.ParseRatesCSV(strings.NewReader(
+		"date,base,quote,rate\n2026-01-01,USD,BRL,5\n2026-01-05,USD,BRL,6\n"))
+	assert.NoError(t, repo.SaveRates(rates))
+
+	provider := currencies.NewTableRateProvider(repo)
+
+	rate, err := provider.Rate("USD", "BRL", time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewRat(5, 1), rate)
+
+	rate, err = provider.Rate("BRL", "USD", time.Date(2026, 1, 6, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewRat(1, 6), rate)
+
+	_, err = provider.Rate("USD", "BRL", time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC))
+	assert.Error(t, err)
+}
+
+func TestConverterMemoizesRates(t *testing.T) {
+	repo := &memoryCurrencyRepository{}
+	rates, _ := currencies.ParseRatesCSV(strings.NewReader("date,base,quote,rate\n2026-01-01,USD,BRL,5.5\n"))
+	assert.NoError(t, repo.SaveRates(rates))
+
+	converter := currencies.NewConverter(currencies.NewTableRateProvider(repo))
+	date := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	converted, err := converter.Convert(money.MustParse("10.00", "USD"), "BRL", date)
+	assert.NoError(t, err)
+	assert.Equal(t, "55.00", converted.String())
+	assert.Equal(t, "BRL", converted.Currency)
+
+	_, err = converter.Convert(money.MustParse("1.00", "USD"), "BRL", date)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, repo.lookups)
+
+	same, err := converter.Convert(money.MustParse("3.00", "BRL"), "BRL", date)
+	assert.NoError(t, err)
+	assert.Equal(t, "3.00", same.String())
+}
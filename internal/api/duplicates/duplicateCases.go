@@ -0,0 +1,156 @@
+package duplicates
+
+import (
+	"fmt"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/cache"
+	"github.com/Renan-Parise/finances/internal/errors"
+)
+
+const maxWindowDays = 31
+
+type DuplicateUseCase interface {
+	FindDuplicates(userID int64, options *Options) ([]*Group, error)
+	Merge(userID int64, request *MergeRequest) error
+	Dismiss(userID int64, ids []int64) error
+	GetHistory(userID int64) ([]*Resolution, error)
+}
+
+type duplicateUseCase struct {
+	duplicateRepo   DuplicateRepository
+	transactionRepo transactions.TransactionRepositories
+	learner         transactions.Learner
+	cache           cache.Invalidator
+}
+
+func NewDuplicateUseCase(dr DuplicateRepository, tr transactions.TransactionRepositories, ln transactions.Learner,
+	ci cache.Invalidator) DuplicateUseCase {
+	return &duplicateUseCase{
+		duplicateRepo:   dr,
+		transactionRepo: tr,
+		learner:         ln,
+		cache:           ci,
+	}
+}
+
+func (uc *duplicateUseCase) FindDuplicates(userID int64, options *Options) ([]*Group, error) {
+	if options.WindowDays == 0 {
+		options.WindowDays = DefaultWindowDays
+	}
+	if options.MinScore == 0 {
+		options.MinScore = DefaultMinScore
+	}
+
+	if options.WindowDays < 0 || options.WindowDays > maxWindowDays {
+		return nil, errors.NewValidationError("windowDays", fmt.Sprintf("windowDays must be between 1 and %d", maxWindowDays))
+	}
+
+	if options.MinScore < 0 || options.MinScore > 1 {
+		return nil, errors.NewValidationError("minScore", "minScore must be between 0 and 1")
+	}
+
+	history, err := uc.transactionRepo.Filter(userID, &transactions.Filter{})
+	if err != nil {
+		return nil, err
+	}
+
+	dismissed, err := uc.duplicateRepo.GetDismissedPairs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := FindGroups(history, dismissed, options)
+	if groups == nil {
+		groups = []*Group{}
+	}
+	return groups, nil
+}
+
+func (uc *duplicateUseCase) Merge(userID int64, request *MergeRequest) error {
+	if len(request.RemoveIDs) == 0 {
+		return errors.NewValidationError("removeIds", "at least one transaction to remove is required")
+	}
+
+	keep, err := uc.getCandidate(userID, request.KeepID)
+	if err != nil {
+		return err
+	}
+
+	seen := map[int64]bool{keep.ID: true}
+	var removed []*transactions.Transaction
+	for _, id := range request.RemoveIDs {
+		if seen[id] {
+			return errors.NewValidationError("removeIds", fmt.Sprintf("transaction %d is listed more than once", id))
+		}
+		seen[id] = true
+
+		transaction, err := uc.getCandidate(userID, id)
+		if err != nil {
+			return err
+		}
+
+		if transaction.Locked {
+			return errors.NewValidationError("removeIds", fmt.Sprintf("transaction %d is reconciled and locked; unlock it first", id))
+		}
+
+		if transaction.Currency != keep.Currency || transaction.Amount.Amount != keep.Amount.Amount {
+			return errors.NewValidationError("removeIds", fmt.Sprintf("transaction %d does not have the same amount as the kept one", id))
+		}
+		removed = append(removed, transaction)
+	}
+
+	if err := uc.duplicateRepo.Merge(userID, keep, removed); err != nil {
+		return err
+	}
+	cache.InvalidateUserOrLog(uc.cache, userID)
+
+	transactions.Relearn(uc.learner, userID, removed, nil)
+	return nil
+}
+
+func (uc *duplicateUseCase) Dismiss(userID int64, ids []int64) error {
+	if len(ids) < 2 {
+		return errors.NewValidationError("transactionIds", "at least two transactions are required")
+	}
+
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return errors.NewValidationError("transactionIds", fmt.Sprintf("transaction %d is listed more than once", id))
+		}
+		seen[id] = true
+
+		if _, err := uc.getCandidate(userID, id); err != nil {
+			return err
+		}
+	}
+
+	var pairs []Pair
+	for i := range ids {
+		for j := i + 1; j < len(ids); j++ {
+			pairs = append(pairs, NewPair(ids[i], ids[j]))
+		}
+	}
+	return uc.duplicateRepo.Dismiss(userID, pairs)
+}
+
+func (uc *duplicateUseCase) GetHistory(userID int64) ([]*Resolution, error) {
+	return uc.duplicateRepo.GetResolutions(userID)
+}
+
+func (uc *duplicateUseCase) getCandidate(userID int64, id int64) (*transactions.Transaction, error) {
+	transaction, err := uc.transactionRepo.GetByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction == nil {
+		return nil, errors.NewValidationError("id", fmt.Sprintf("transaction %d not found", id))
+	}
+
+	if transaction.TransferID != 0 {
+		return nil, errors.NewValidationError("id", fmt.Sprintf("transaction %d belongs to a transfer", id))
+	}
+	return transaction, nil
+}
@@ -0,0 +1,58 @@
+package duplicates
+
+import (
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+)
+
+const (
+	ActionMerge   = "merge"
+	ActionDismiss = "dismiss"
+)
+
+const (
+	DefaultWindowDays = 3
+	DefaultMinScore   = 0.6
+)
+
+// Options tune the detector.
+type Options struct {
+	WindowDays int     `json:"windowDays"`
+	MinScore   float64 `json:"minScore"`
+}
+
+// Group is a set of transactions that are likely the same purchase.
+type Group struct {
+	Score        float64                     `json:"score"`
+	Transactions []*transactions.Transaction `json:"transactions"`
+}
+
+// Pair identifies two transactions regardless of order.
+type Pair struct {
+	Low  int64
+	High int64
+}
+
+func NewPair(a, b int64) Pair {
+	if a > b {
+		a, b = b, a
+	}
+	return Pair{Low: a, High: b}
+}
+
+// Resolution is one entry of the audit trail.
+type Resolution struct {
+	ID            int64                     `json:"id"`
+	UserID        int64                     `json:"userId"`
+	Action        string                    `json:"action"`
+	TransactionID int64                     `json:"transactionId"`
+	OtherID       int64                     `json:"otherId"`
+	Snapshot      *transactions.Transaction `json:"snapshot,omitempty"`
+	CreatedAt     time.Time                 `json:"createdAt"`
+}
+
+type MergeRequest struct {
+	KeepID    int64   `json:"keepId" binding:"required"`
+	RemoveIDs []int64 `json:"removeIds" binding:"required"`
+}
@@ -0,0 +1,173 @@
+package duplicates
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/utils"
+)
+
+// Similarity compares two descriptions with the Dice coefficient of their
+// letter bigrams, after dropping accents, case, digits and punctuation.
+func Similarity(a, b string) float64 {
+	a, b = normalize(a), normalize(b)
+	if a == b {
+		return 1
+	}
+
+	bigramsA, bigramsB := bigrams(a), bigrams(b)
+	if len(bigramsA) == 0 || len(bigramsB) == 0 {
+		return 0
+	}
+
+	counts := make(map[string]int, len(bigramsA))
+	for _, bigram := range bigramsA {
+		counts[bigram]++
+	}
+
+	shared := 0
+	for _, bigram := range bigramsB {
+		if counts[bigram] > 0 {
+			counts[bigram]--
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(bigramsA)+len(bigramsB))
+}
+
+// Score rates how likely two transactions with the same amount are the same
+// purchase.
+func Score(a, b *transactions.Transaction, windowDays int) float64 {
+	days := daysApart(a.OccurredAt, b.OccurredAt)
+	closeness := 1 - float64(days)/float64(windowDays+1)
+
+	score := 0.7*Similarity(a.Description, b.Description) + 0.3*closeness
+	if a.AccountID != 0 && b.AccountID != 0 && a.AccountID != b.AccountID {
+		score /= 2
+	}
+	return math.Round(score*100) / 100
+}
+
+// FindGroups links transactions whose pair score reaches options.MinScore into
+// groups, strongest first.
+func FindGroups(batch []*transactions.Transaction, dismissed map[Pair]bool, options *Options) []*Group {
+	var candidates []*transactions.Transaction
+	for _, transaction := range batch {
+		if transaction.TransferID == 0 {
+			candidates = append(candidates, transaction)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Currency != b.Currency {
+			return a.Currency < b.Currency
+		}
+		if a.Amount.Amount != b.Amount.Amount {
+			return a.Amount.Amount < b.Amount.Amount
+		}
+		return a.OccurredAt.Before(b.OccurredAt)
+	})
+
+	parent := make([]int, len(candidates))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	type link struct {
+		a, b  int
+		score float64
+	}
+	var links []link
+	for i, a := range candidates {
+		for j := i + 1; j < len(candidates); j++ {
+			b := candidates[j]
+			if b.Currency != a.Currency || b.Amount.Amount != a.Amount.Amount ||
+				daysApart(a.OccurredAt, b.OccurredAt) > options.WindowDays {
+				break
+			}
+
+			if dismissed[NewPair(a.ID, b.ID)] {
+				continue
+			}
+
+			if score := Score(a, b, options.WindowDays); score >= options.MinScore {
+				links = append(links, link{a: i, b: j, score: score})
+				parent[find(i)] = find(j)
+			}
+		}
+	}
+
+	byRoot := make(map[int]*Group)
+	var groups []*Group
+	for _, l := range links {
+		root := find(l.a)
+		group, ok := byRoot[root]
+		if !ok {
+			group = &Group{Score: l.score}
+			byRoot[root] = group
+			groups = append(groups, group)
+		}
+		group.Score = math.Min(group.Score, l.score)
+	}
+
+	for i, transaction := range candidates {
+		if group, ok := byRoot[find(i)]; ok {
+			group.Transactions = append(group.Transactions, transaction)
+		}
+	}
+
+	for _, group := range groups {
+		sort.SliceStable(group.Transactions, func(i, j int) bool {
+			return group.Transactions[i].OccurredAt.Before(group.Transactions[j].OccurredAt)
+		})
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Score > groups[j].Score
+	})
+	return groups
+}
+
+func daysApart(a, b time.Time) int {
+	dayA := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	dayB := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(dayB.Sub(dayA).Hours() / 24)
+	if days < 0 {
+		return -days
+	}
+	return days
+}
+
+func normalize(value string) string {
+	words := strings.FieldsFunc(strings.ToLower(utils.RemoveAccents(value)), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	return strings.Join(words, " ")
+}
+
+func bigrams(value string) []string {
+	runes := []rune(value)
+	if len(runes) < 2 {
+		if len(runes) == 1 {
+			return []string{value}
+		}
+		return nil
+	}
+
+	result := make([]string, 0, len(runes)-1)
+	for i := 0; i < len(runes)-1; i++ {
+		result = append(result, string(runes[i:i+2]))
+	}
+	return result
+}
@@ -0,0 +1,137 @@
+package duplicates
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DuplicateHandler struct {
+	duplicateUseCase DuplicateUseCase
+}
+
+func NewDuplicateHandler(router *gin.RouterGroup, du DuplicateUseCase) {
+	handler := &DuplicateHandler{
+		duplicateUseCase: du,
+	}
+
+	duplicates := router.Group("/duplicates")
+	duplicates.Use(middlewares.JWTAuthMiddleware())
+	{
+		duplicates.GET("/", handler.FindDuplicates)
+		duplicates.GET("/history", handler.GetHistory)
+		duplicates.POST("/merge", handler.Merge)
+		duplicates.POST("/dismiss", handler.Dismiss)
+	}
+}
+
+func (h *DuplicateHandler) FindDuplicates(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	options := &Options{}
+	if value := c.Query("windowDays"); value != "" {
+		windowDays, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid windowDays"})
+			return
+		}
+		options.WindowDays = windowDays
+	}
+
+	if value := c.Query("minScore"); value != "" {
+		minScore, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid minScore"})
+			return
+		}
+		options.MinScore = minScore
+	}
+
+	groups, err := h.duplicateUseCase.FindDuplicates(userID.(int64), options)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, groups)
+}
+
+func (h *DuplicateHandler) Merge(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	var input MergeRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.duplicateUseCase.Merge(userID.(int64), &input); err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Transactions merged successfully"})
+}
+
+func (h *DuplicateHandler) Dismiss(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	var input struct {
+		TransactionIDs []int64 `json:"transactionIds" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.duplicateUseCase.Dismiss(userID.(int64), input.TransactionIDs); err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Duplicate suggestion dismissed successfully"})
+}
+
+func (h *DuplicateHandler) GetHistory(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	resolutions, err := h.duplicateUseCase.GetHistory(userID.(int64))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resolutions)
+}
@@ -0,0 +1,140 @@
+package duplicates
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/errors"
+)
+
+type DuplicateRepository interface {
+	GetDismissedPairs(userID int64) (map[Pair]bool, error)
+	Dismiss(userID int64, pairs []Pair) error
+	Merge(userID int64, keep *transactions.Transaction, removed []*transactions.Transaction) error
+	GetResolutions(userID int64) ([]*Resolution, error)
+}
+
+var linkedTables = []string{"import_fitids", "journal_sync", "recurring_occurrences", "installments"}
+
+type duplicateRepository struct {
+	db *sql.DB
+}
+
+func NewDuplicateRepository(db *sql.DB) DuplicateRepository {
+	return &duplicateRepository{db: db}
+}
+
+func (r *duplicateRepository) GetDismissedPairs(userID int64) (map[Pair]bool, error) {
+	query := `SELECT transactionId, otherId FROM duplicate_resolutions WHERE userId = ? AND action = ?`
+	rows, err := r.db.Query(query, userID, ActionDismiss)
+	if err != nil {
+		return nil, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	dismissed := make(map[Pair]bool)
+	for rows.Next() {
+		var a, b int64
+		if err := rows.Scan(&a, &b); err != nil {
+			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		dismissed[NewPair(a, b)] = true
+	}
+	return dismissed, nil
+}
+
+func (r *duplicateRepository) Dismiss(userID int64, pairs []Pair) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return errors.NewDatabaseError("error starting transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for _, pair := range pairs {
+		_, err := tx.Exec(`INSERT INTO duplicate_resolutions (userId, action, transactionId, otherId, createdAt) VALUES (?, ?, ?, ?, ?)`,
+			userID, ActionDismiss, pair.Low, pair.High, now)
+		if err != nil {
+			return errors.NewQueryError("error executing query: " + err.Error())
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+	return nil
+}
+
+func (r *duplicateRepository) Merge(userID int64, keep *transactions.Transaction, removed []*transactions.Transaction) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return errors.NewDatabaseError("error starting transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for _, transaction := range removed {
+		for _, table := range linkedTables {
+			query := `UPDATE ` + table + ` SET transactionId = ? WHERE transactionId = ?`
+			if _, err := tx.Exec(query, keep.ID, transaction.ID); err != nil {
+				return errors.NewQueryError("error moving " + table + " links: " + err.Error())
+			}
+		}
+
+		snapshot, err := json.Marshal(transaction)
+		if err != nil {
+			return errors.NewDatabaseError("error encoding snapshot: " + err.Error())
+		}
+
+		_, err = tx.Exec(`INSERT INTO duplicate_resolutions (userId, action, transactionId, otherId, snapshot, createdAt)
+              VALUES (?, ?, ?, ?, ?, ?)`, userID, ActionMerge, keep.ID, transaction.ID, string(snapshot), now)
+		if err != nil {
+			return errors.NewQueryError("error executing query: " + err.Error())
+		}
+
+		if _, err := tx.Exec(`DELETE FROM transactions WHERE id = ? AND userId = ?`, transaction.ID, userID); err != nil {
+			return errors.NewQueryError("error deleting transaction: " + err.Error())
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+	return nil
+}
+
+func (r *duplicateRepository) GetResolutions(userID int64) ([]*Resolution, error) {
+	query := `SELECT id, userId, action, transactionId, otherId, COALESCE(snapshot, ''), createdAt
+              FROM duplicate_resolutions WHERE userId = ? ORDER BY createdAt DESC, id DESC`
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	var resolutions []*Resolution
+	for rows.Next() {
+		var resolution Resolution
+		var snapshot string
+		err := rows.Scan(&resolution.ID, &resolution.UserID, &resolution.Action, &resolution.TransactionID,
+			&resolution.OtherID, &snapshot, &resolution.CreatedAt)
+		if err != nil {
+			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+		}
+
+		if snapshot != "" {
+			resolution.Snapshot = &transactions.Transaction{}
+			if err := json.Unmarshal([]byte(snapshot), resolution.Snapshot); err != nil {
+				return nil, errors.NewQueryError("error decoding snapshot: " + err.Error())
+			}
+			resolution.Snapshot.Amount.Currency = resolution.Snapshot.Currency
+			for _, split := range resolution.Snapshot.Splits {
+				split.Amount.Currency = resolution.Snapshot.Currency
+			}
+		}
+		resolutions = append(resolutions, &resolution)
+	}
+	return resolutions, nil
+}
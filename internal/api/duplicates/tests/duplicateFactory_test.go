@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/duplicates"
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var day = time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func transaction(id int64, description, amount string, daysLater int) *transactions.Transaction {
+	t := transactions.NewTransaction(1, 1, description, 1, money.MustParse(amount, "BRL"))
+	t.ID = id
+	t.Currency = "BRL"
+	t.OccurredAt = day.AddDate(0, 0, daysLater)
+	return t
+}
+
+func options() *duplicates.Options {
+	return &duplicates.Options{WindowDays: duplicates.DefaultWindowDays, MinScore: duplicates.DefaultMinScore}
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, duplicates.Similarity("Padaria São João", "PADARIA SAO JOAO 1234"))
+	assert.Greater(t, duplicates.Similarity("NETFLIX.COM", "Netflix com assinatura"), 0.6)
+	assert.Less(t, duplicates.Similarity("Uber trip", "Supermercado Extra"), 0.2)
+	assert.Equal(t, 0.0, duplicates.Similarity("1234", "Uber"))
+}
+
+func TestScorePenalizesDifferentAccounts(t *testing.T) {
+	a := transaction(1, "Padaria", "-12.50", 0)
+	b := transaction(2, "Padaria", "-12.50", 1)
+
+	same := duplicates.Score(a, b, duplicates.DefaultWindowDays)
+	b.AccountID = 2
+	other := duplicates.Score(a, b, duplicates.DefaultWindowDays)
+
+	assert.Greater(t, same, duplicates.DefaultMinScore)
+	assert.Less(t, other, duplicates.DefaultMinScore)
+}
+
+func TestFindGroups(t *testing.T) {
+	batch := []*transactions.Transaction{
+		transaction(1, "Padaria São João", "-12.50", 0),
+		transaction(2, "PADARIA SAO JOAO", "-12.50", 1),
+		transaction(3, "Padaria Sao Joao 0042", "-12.50", 2),
+		transaction(4, "Padaria São João", "-12.51", 0),
+		transaction(5, "Uber trip", "-30", 0),
+		transaction(6, "Supermercado", "-30", 0),
+	}
+
+	groups := duplicates.FindGroups(batch, nil, options())
+	require.Len(t, groups, 1)
+
+	var ids []int64
+	for _, transaction := range groups[0].Transactions {
+		ids = append(ids, transaction.ID)
+	}
+	assert.Equal(t, []int64{1, 2, 3}, ids)
+	assert.GreaterOrEqual(t, groups[0].Score, duplicates.DefaultMinScore)
+}
+
+func TestFindGroupsRespectsWindow(t *testing.T) {
+	batch := []*transactions.Transaction{
+		transaction(1, "Spotify", "-21.90", 0),
+		transaction(2, "Spotify", "-21.90", 30),
+	}
+
+	assert.Empty(t, duplicates.FindGroups(batch, nil, options()))
+}
+
+func TestFindGroupsSkipsDismissedPairsAndTransfers(t *testing.T) {
+	a := transaction(1, "Padaria", "-12.50", 0)
+	b := transaction(2, "Padaria", "-12.50", 0)
+	batch := []*transactions.Transaction{a, b}
+
+	dismissed := map[duplicates.Pair]bool{duplicates.NewPair(2, 1): true}
+	assert.Empty(t, duplicates.FindGroups(batch, dismissed, options()))
+
+	b.TransferID = 7
+	assert.Empty(t, duplicates.FindGroups(batch, nil, options()))
+}
@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Renan-Parise/finances/internal/api/duplicates"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDuplicateUseCase struct {
+	mock.Mock
+}
+
+func TestNewDuplicateHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.Default()
+	group := router.Group("/api")
+
+	mockUseCase := new(MockDuplicateUseCase)
+	duplicates.NewDuplicateHandler(group, mockUseCase)
+
+	routes := router.Routes()
+
+	expectedRoutes := []struct {
+		method string
+		path   string
+	}{
+		{"GET", "/api/duplicates/"},
+		{"GET", "/api/duplicates/history"},
+		{"POST", "/api/duplicates/merge"},
+		{"POST", "/api/duplicates/dismiss"},
+	}
+
+	for _, expected := range expectedRoutes {
+		found := false
+		for _, route := range routes {
+			if route.Method == expected.method && route.Path == expected.path {
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, "Route %s %s not registered", expected.method, expected.path)
+	}
+}
+
+func (m *MockDuplicateUseCase) FindDuplicates(userID int64, options *duplicates.Options) ([]*duplicates.Group, error) {
+	args := m.Called(userID, options)
+	return args.Get(0).([]*duplicates.Group), args.Error(1)
+}
+
+func (m *MockDuplicateUseCase) Merge(userID int64, request *duplicates.MergeRequest) error {
+	args := m.Called(userID, request)
+	return args.Error(0)
+}
+
+func (m *MockDuplicateUseCase) Dismiss(userID int64, ids []int64) error {
+	args := m.Called(userID, ids)
+	return args.Error(0)
+}
+
+func (m *MockDuplicateUseCase) GetHistory(userID int64) ([]*duplicates.Resolution, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]*duplicates.Resolution), args.Error(1)
+}
@@ -0,0 +1,179 @@
+package exports
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/accounts"
+	"github.com/Renan-Parise/finances/internal/api/categories"
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/utils"
+)
+
+type ExportUseCase interface {
+	PrepareExport(userID int64, request *Request) (*Export, error)
+}
+
+// Export is a validated export ready to be streamed.
+type Export struct {
+	ContentType string
+	Filename    string
+	stream      func(w io.Writer) error
+}
+
+func (e *Export) Stream(w io.Writer) error {
+	return e.stream(w)
+}
+
+type exportUseCase struct {
+	transactionRepo transactions.TransactionRepositories
+	categoryRepo    categories.CategoryRepository
+	accountRepo     accounts.AccountRepository
+}
+
+func NewExportUseCase(tr transactions.TransactionRepositories, cr categories.CategoryRepository, ar accounts.AccountRepository) ExportUseCase {
+	return &exportUseCase{
+		transactionRepo: tr,
+		categoryRepo:    cr,
+		accountRepo:     ar,
+	}
+}
+
+func (uc *exportUseCase) PrepareExport(userID int64, request *Request) (*Export, error) {
+	exportFormat, ok := formats[strings.ToLower(request.Format)]
+	if !ok {
+		return nil, errors.NewValidationError("format", "format must be csv, ndjson, ofx or xlsx")
+	}
+	request.Format = strings.ToLower(request.Format)
+
+	options, err := resolveOptions(request)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := request.Filter
+	if filter.Field == "" {
+		filter.Field, filter.Order = "occurredAt", "ASC"
+	}
+	if !transactions.SortableFields[filter.Field] {
+		return nil, errors.NewValidationError("field", "invalid field for sorting: "+filter.Field)
+	}
+
+	if request.Format == FormatOFX {
+		if err := uc.prepareStatement(userID, &filter, options); err != nil {
+			return nil, err
+		}
+	}
+
+	categoryList, err := uc.categoryRepo.GetAll(userID)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int]string, len(categoryList))
+	for _, category := range categoryList {
+		names[category.ID] = category.Name
+	}
+
+	export := &Export{
+		ContentType: exportFormat.contentType,
+		Filename:    "transactions-" + time.Now().Format("20060102") + "." + exportFormat.extension,
+	}
+	export.stream = func(w io.Writer) error {
+		writer, err := NewRowWriter(request.Format, w, options)
+		if err != nil {
+			return err
+		}
+
+		err = uc.transactionRepo.Stream(userID, &filter, func(transaction *transactions.Transaction) error {
+			rows := []*Row{NewRow(transaction, names)}
+			if exportFormat.splitLines {
+				rows = SplitRows(transaction, names)
+			}
+
+			for _, row := range rows {
+				if err := writer.WriteRow(row); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return writer.Close()
+	}
+	return export, nil
+}
+
+func (uc *exportUseCase) prepareStatement(userID int64, filter *transactions.Filter, options *Options) error {
+	if filter.AccountID == 0 {
+		return errors.NewValidationError("accountId", "an OFX export covers a single account; set accountId")
+	}
+
+	account, err := uc.accountRepo.GetByID(userID, filter.AccountID)
+	if err != nil {
+		return err
+	}
+
+	if account == nil {
+		return errors.NewValidationError("accountId", "account not found")
+	}
+
+	options.Account = account
+	options.From = account.CreatedAt
+	options.To = time.Now()
+	if filter.From != "" {
+		if options.From, err = time.Parse("2006-01-02", filter.From[:min(len(filter.From), 10)]); err != nil {
+			return errors.NewValidationError("from", "expected YYYY-MM-DD")
+		}
+	}
+	if filter.To != "" {
+		if options.To, err = time.Parse("2006-01-02", filter.To[:min(len(filter.To), 10)]); err != nil {
+			return errors.NewValidationError("to", "expected YYYY-MM-DD")
+		}
+	}
+
+	options.LedgerBalance, err = uc.accountRepo.GetBalanceBefore(userID, account.ID, options.To.AddDate(0, 0, 1))
+	if err != nil {
+		return err
+	}
+	options.LedgerBalance.Currency = account.Currency
+	return nil
+}
+
+func resolveOptions(request *Request) (*Options, error) {
+	name := request.Locale
+	if name == "" {
+		name = defaultLocale
+	}
+
+	locale, ok := locales[name]
+	if !ok {
+		return nil, errors.NewValidationError("locale", "unsupported locale "+name)
+	}
+
+	if request.DecimalSeparator != "" {
+		if request.DecimalSeparator != "." && request.DecimalSeparator != "," {
+			return nil, errors.NewValidationError("decimalSeparator", "decimal separator must be '.' or ','")
+		}
+		locale.DecimalSeparator = request.DecimalSeparator
+		if locale.ThousandsSeparator == request.DecimalSeparator {
+			locale.ThousandsSeparator = ""
+		}
+		if request.DecimalSeparator == "," {
+			locale.Delimiter = ';'
+		}
+	}
+
+	if request.DateFormat != "" {
+		locale.DateFormat = request.DateFormat
+	}
+
+	layout, err := utils.DateLayout(locale.DateFormat)
+	if err != nil {
+		return nil, err
+	}
+	return &Options{Locale: locale, DateLayout: layout}, nil
+}
@@ -0,0 +1,67 @@
+package exports
+
+import (
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/accounts"
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+	FormatOFX    = "ofx"
+	FormatXLSX   = "xlsx"
+)
+
+type format struct {
+	contentType string
+	extension   string
+	splitLines  bool
+}
+
+var formats = map[string]format{
+	FormatCSV:    {"text/csv; charset=utf-8", "csv", true},
+	FormatNDJSON: {"application/x-ndjson", "ndjson", true},
+	FormatOFX:    {"application/x-ofx", "ofx", false},
+	FormatXLSX:   {"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "xlsx", true},
+}
+
+// Request takes the same criteria as the transactions filter plus the output
+// format.
+type Request struct {
+	transactions.Filter
+	Format           string `json:"format"`
+	Locale           string `json:"locale"`
+	DecimalSeparator string `json:"decimalSeparator"`
+	DateFormat       string `json:"dateFormat"`
+}
+
+type Locale struct {
+	DecimalSeparator   string
+	ThousandsSeparator string
+	DateFormat         string
+	Delimiter          rune
+}
+
+// Options controls how rows are rendered.
+type Options struct {
+	Locale
+	DateLayout    string
+	Account       *accounts.Account
+	From          time.Time
+	To            time.Time
+	LedgerBalance money.Money
+}
+
+type Row struct {
+	ID          int64
+	Date        time.Time
+	Description string
+	CategoryID  int
+	Category    string
+	AccountID   int64
+	Amount      money.Money
+	Currency    string
+}
@@ -0,0 +1,150 @@
+package exports
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/errors"
+)
+
+const defaultLocale = "iso"
+
+var locales = map[string]Locale{
+	defaultLocale: {DecimalSeparator: ".", DateFormat: "YYYY-MM-DD", Delimiter: ','},
+	"en-US":       {DecimalSeparator: ".", ThousandsSeparator: ",", DateFormat: "MM/DD/YYYY", Delimiter: ','},
+	"pt-BR":       {DecimalSeparator: ",", ThousandsSeparator: ".", DateFormat: "DD/MM/YYYY", Delimiter: ';'},
+}
+
+// NewRow is the row of a whole transaction, under its own category.
+func NewRow(transaction *transactions.Transaction, categoryNames map[int]string) *Row {
+	return &Row{
+		ID:          transaction.ID,
+		Date:        transaction.OccurredAt,
+		Description: transaction.Description,
+		CategoryID:  transaction.Category,
+		Category:    categoryNames[transaction.Category],
+		AccountID:   transaction.AccountID,
+		Amount:      transaction.Amount,
+		Currency:    transaction.Currency,
+	}
+}
+
+// SplitRows returns one row per split line of a transaction.
+func SplitRows(transaction *transactions.Transaction, categoryNames map[int]string) []*Row {
+	if len(transaction.Splits) == 0 {
+		return []*Row{NewRow(transaction, categoryNames)}
+	}
+
+	rows := make([]*Row, len(transaction.Splits))
+	for i, split := range transaction.Splits {
+		row := NewRow(transaction, categoryNames)
+		row.CategoryID = split.Category
+		row.Category = categoryNames[split.Category]
+		row.Amount = split.Amount
+		rows[i] = row
+	}
+	return rows
+}
+
+// RowWriter renders rows one at a time; Close writes whatever the format needs
+// after the last row.
+type RowWriter interface {
+	WriteRow(row *Row) error
+	Close() error
+}
+
+func NewRowWriter(exportFormat string, w io.Writer, options *Options) (RowWriter, error) {
+	switch exportFormat {
+	case FormatCSV:
+		return newCSVWriter(w, options)
+	case FormatNDJSON:
+		return &ndjsonWriter{encoder: json.NewEncoder(w)}, nil
+	case FormatOFX:
+		return newOFXWriter(w, options)
+	case FormatXLSX:
+		return newXLSXWriter(w, options)
+	default:
+		return nil, errors.NewValidationError("format", "format must be csv, ndjson, ofx or xlsx")
+	}
+}
+
+const csvFlushEvery = 500
+
+type csvWriter struct {
+	writer  *csv.Writer
+	options *Options
+	rows    int
+}
+
+func newCSVWriter(w io.Writer, options *Options) (*csvWriter, error) {
+	writer := csv.NewWriter(w)
+	writer.Comma = options.Delimiter
+	err := writer.Write([]string{"id", "date", "description", "category", "account", "amount", "currency"})
+	return &csvWriter{writer: writer, options: options}, err
+}
+
+func (cw *csvWriter) WriteRow(row *Row) error {
+	account := ""
+	if row.AccountID != 0 {
+		account = strconv.FormatInt(row.AccountID, 10)
+	}
+
+	err := cw.writer.Write([]string{
+		strconv.FormatInt(row.ID, 10),
+		row.Date.Format(cw.options.DateLayout),
+		row.Description,
+		row.Category,
+		account,
+		row.Amount.Format(cw.options.DecimalSeparator, cw.options.ThousandsSeparator),
+		row.Currency,
+	})
+	if err != nil {
+		return err
+	}
+
+	if cw.rows++; cw.rows%csvFlushEvery == 0 {
+		cw.writer.Flush()
+		return cw.writer.Error()
+	}
+	return nil
+}
+
+func (cw *csvWriter) Close() error {
+	cw.writer.Flush()
+	return cw.writer.Error()
+}
+
+type ndjsonRow struct {
+	ID          int64  `json:"id"`
+	Date        string `json:"date"`
+	Description string `json:"description"`
+	CategoryID  int    `json:"categoryId,omitempty"`
+	Category    string `json:"category,omitempty"`
+	AccountID   int64  `json:"accountId,omitempty"`
+	Amount      string `json:"amount"`
+	Currency    string `json:"currency"`
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func (nw *ndjsonWriter) WriteRow(row *Row) error {
+	return nw.encoder.Encode(&ndjsonRow{
+		ID:          row.ID,
+		Date:        row.Date.Format("2006-01-02T15:04:05Z07:00"),
+		Description: row.Description,
+		CategoryID:  row.CategoryID,
+		Category:    row.Category,
+		AccountID:   row.AccountID,
+		Amount:      row.Amount.String(),
+		Currency:    row.Currency,
+	})
+}
+
+func (nw *ndjsonWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,59 @@
+package exports
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExportHandler struct {
+	exportUseCase ExportUseCase
+}
+
+func NewExportHandler(router *gin.RouterGroup, eu ExportUseCase) {
+	handler := &ExportHandler{
+		exportUseCase: eu,
+	}
+
+	exports := router.Group("/exports")
+	exports.Use(middlewares.JWTAuthMiddleware())
+	{
+		exports.POST("/transactions", handler.ExportTransactions)
+	}
+}
+
+func (h *ExportHandler) ExportTransactions(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	var input Request
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	export, err := h.exportUseCase.PrepareExport(userID.(int64), &input)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", export.ContentType)
+	c.Header("Content-Disposition", `attachment; filename="`+export.Filename+`"`)
+	c.Status(http.StatusOK)
+
+	if err := export.Stream(c.Writer); err != nil {
+		log.Printf("Failed to stream export for user %d: %v", userID.(int64), err)
+	}
+}
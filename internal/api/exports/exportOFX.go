@@ -0,0 +1,91 @@
+package exports
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/accounts"
+)
+
+const ofxNameLength = 32
+
+type ofxWriter struct {
+	writer   *bufio.Writer
+	options  *Options
+	messages string
+	response string
+	rs       string
+}
+
+func newOFXWriter(w io.Writer, options *Options) (*ofxWriter, error) {
+	ow := &ofxWriter{
+		writer:   bufio.NewWriter(w),
+		options:  options,
+		messages: "BANKMSGSRSV1",
+		response: "STMTTRNRS",
+		rs:       "STMTRS",
+	}
+
+	accountFrom := "<BANKACCTFROM><BANKID>0</BANKID><ACCTID>%d</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>"
+	switch options.Account.Type {
+	case accounts.TypeSavings:
+		accountFrom = "<BANKACCTFROM><BANKID>0</BANKID><ACCTID>%d</ACCTID><ACCTTYPE>SAVINGS</ACCTTYPE></BANKACCTFROM>"
+	case accounts.TypeCreditCard:
+		ow.messages, ow.response, ow.rs = "CREDITCARDMSGSRSV1", "CCSTMTTRNRS", "CCSTMTRS"
+		accountFrom = "<CCACCTFROM><ACCTID>%d</ACCTID></CCACCTFROM>"
+	}
+
+	_, err := fmt.Fprintf(ow.writer, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS><DTSERVER>%s</DTSERVER><LANGUAGE>POR</LANGUAGE></SONRS></SIGNONMSGSRSV1>
+<%s><%s><TRNUID>0</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+<%s><CURDEF>%s</CURDEF>`+accountFrom+`
+<BANKTRANLIST><DTSTART>%s</DTSTART><DTEND>%s</DTEND>
+`, ofxDateTime(time.Now()), ow.messages, ow.response, ow.rs, options.Account.Currency, options.Account.ID,
+		ofxDate(options.From), ofxDate(options.To))
+	return ow, err
+}
+
+func (ow *ofxWriter) WriteRow(row *Row) error {
+	transactionType := "CREDIT"
+	if row.Amount.IsNegative() {
+		transactionType = "DEBIT"
+	}
+
+	name := []rune(row.Description)
+	if len(name) > ofxNameLength {
+		name = name[:ofxNameLength]
+	}
+
+	_, err := fmt.Fprintf(ow.writer, "<STMTTRN><TRNTYPE>%s</TRNTYPE><DTPOSTED>%s</DTPOSTED><TRNAMT>%s</TRNAMT><FITID>%d</FITID><NAME>%s</NAME><MEMO>%s</MEMO></STMTTRN>\n",
+		transactionType, ofxDate(row.Date), row.Amount.String(), row.ID, escapeXML(string(name)), escapeXML(row.Description))
+	return err
+}
+
+func (ow *ofxWriter) Close() error {
+	_, err := fmt.Fprintf(ow.writer, "</BANKTRANLIST>\n<LEDGERBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></LEDGERBAL>\n</%s></%s></%s>\n</OFX>\n",
+		ow.options.LedgerBalance.String(), ofxDate(ow.options.To), ow.rs, ow.response, ow.messages)
+	if err != nil {
+		return err
+	}
+	return ow.writer.Flush()
+}
+
+func ofxDate(t time.Time) string {
+	return t.Format("20060102")
+}
+
+func ofxDateTime(t time.Time) string {
+	return t.UTC().Format("20060102150405") + "[0:GMT]"
+}
+
+func escapeXML(value string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/accounts"
+	"github.com/Renan-Parise/finances/internal/api/exports"
+	"github.com/Renan-Parise/finances/internal/api/imports"
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sampleRows = []*exports.Row{
+	{ID: 7, Date: time.Date(2026, 3, 4, 15, 0, 0, 0, time.UTC), Description: "Padaria São João", CategoryID: 1,
+		Category: "Food", AccountID: 2, Amount: money.MustParse("-1234.50", "BRL"), Currency: "BRL"},
+	{ID: 8, Date: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), Description: "Salary & bonus", CategoryID: 2,
+		Category: "Income", AccountID: 2, Amount: money.MustParse("5000", "BRL"), Currency: "BRL"},
+}
+
+func writeAll(t *testing.T, format string, options *exports.Options) []byte {
+	var out bytes.Buffer
+	writer, err := exports.NewRowWriter(format, &out, options)
+	require.NoError(t, err)
+	for _, row := range sampleRows {
+		require.NoError(t, writer.WriteRow(row))
+	}
+	require.NoError(t, writer.Close())
+	return out.Bytes()
+}
+
+func TestCSVExportUsesLocale(t *testing.T) {
+	options := &exports.Options{
+		Locale:     exports.Locale{DecimalSeparator: ",", ThousandsSeparator: ".", DateFormat: "DD/MM/YYYY", Delimiter: ';'},
+		DateLayout: "02/01/2006",
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(writeAll(t, exports.FormatCSV, options))), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "id;date;description;category;account;amount;currency", lines[0])
+	assert.Equal(t, "7;04/03/2026;Padaria São João;Food;2;-1.234,50;BRL", lines[1])
+}
+
+func TestNDJSONExport(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(string(writeAll(t, exports.FormatNDJSON, &exports.Options{}))), "\n")
+	require.Len(t, lines, 2)
+	assert.JSONEq(t, `{"id":7,"date":"2026-03-04T15:00:00Z","description":"Padaria São João","categoryId":1,
+		"category":"Food","accountId":2,"amount":"-1234.50","currency":"BRL"}`, lines[0])
+}
+
+func TestOFXExportRoundTrips(t *testing.T) {
+	options := &exports.Options{
+		Account:       &accounts.Account{ID: 2, Type: accounts.TypeChecking, Currency: "BRL"},
+		From:          time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:            time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+		LedgerBalance: money.MustParse("3765.50", "BRL"),
+	}
+
+	statement, err := imports.ParseOFX(bytes.NewReader(writeAll(t, exports.FormatOFX, options)))
+	require.NoError(t, err)
+	assert.Equal(t, "BRL", statement.Currency)
+	assert.Equal(t, "2", statement.AccountNumber)
+	require.Len(t, statement.Entries, 2)
+	assert.Equal(t, "7", statement.Entries[0].FITID)
+	assert.Equal(t, "Salary & bonus", statement.Entries[1].Description)
+	assert.Equal(t, money.MustParse("-1234.50", "BRL"), statement.Entries[0].Amount)
+	assert.Equal(t, money.MustParse("3765.50", "BRL"), *statement.LedgerBalance)
+}
+
+func TestXLSXExportIsAValidWorkbook(t *testing.T) {
+	options := &exports.Options{Locale: exports.Locale{DateFormat: "DD/MM/YYYY"}}
+	data := writeAll(t, exports.FormatXLSX, options)
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	parts := make(map[string]string)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		parts[file.Name] = string(content)
+	}
+
+	require.Contains(t, parts, "[Content_Types].xml")
+	assert.Contains(t, parts["xl/styles.xml"], `formatCode="dd/mm/yyyy"`)
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<c r="B2" s="1"><v>46085</v></c>`)
+	assert.Contains(t, sheet, `<c r="E2" s="2"><v>-1234.50</v></c>`)
+	assert.Contains(t, sheet, "Salary &amp; bonus")
+	assert.Contains(t, sheet, `<col min="6" max="6"`)
+}
+
+func TestSplitRowsWritesOneRowPerSplitLine(t *testing.T) {
+	names := map[int]string{1: "Food", 3: "Home", 4: "Pharmacy"}
+	transaction := &transactions.Transaction{ID: 9, Description: "Supermarket", Category: 1,
+		Amount: money.MustParse("-150", "BRL"), Currency: "BRL"}
+
+	rows := exports.SplitRows(transaction, names)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "Food", rows[0].Category)
+
+	transaction.Splits = []*transactions.Split{
+		{Category: 3, Amount: money.MustParse("-100", "BRL")},
+		{Category: 4, Amount: money.MustParse("-50", "BRL")},
+	}
+	rows = exports.SplitRows(transaction, names)
+	require.Len(t, rows, 2)
+	for i, expected := range []struct {
+		category string
+		amount   string
+	}{{"Home", "-100.00"}, {"Pharmacy", "-50.00"}} {
+		assert.Equal(t, int64(9), rows[i].ID)
+		assert.Equal(t, "Supermarket", rows[i].Description)
+		assert.Equal(t, expected.category, rows[i].Category)
+		assert.Equal(t, expected.amount, rows[i].Amount.String())
+	}
+
+	whole := exports.NewRow(transaction, names)
+	assert.Equal(t, "Food", whole.Category)
+	assert.Equal(t, "-150.00", whole.Amount.String())
+}
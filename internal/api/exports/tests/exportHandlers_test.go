@@ -0,0 +1,49 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Renan-Parise/finances/internal/api/exports"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockExportUseCase struct {
+	mock.Mock
+}
+
+func TestNewExportHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.Default()
+	group := router.Group("/api")
+
+	mockUseCase := new(MockExportUseCase)
+	exports.NewExportHandler(group, mockUseCase)
+
+	routes := router.Routes()
+
+	expectedRoutes := []struct {
+		method string
+		path   string
+	}{
+		{"POST", "/api/exports/transactions"},
+	}
+
+	for _, expected := range expectedRoutes {
+		found := false
+		for _, route := range routes {
+			if route.Method == expected.method && route.Path == expected.path {
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, "Route %s %s not registered", expected.method, expected.path)
+	}
+}
+
+func (m *MockExportUseCase) PrepareExport(userID int64, request *exports.Request) (*exports.Export, error) {
+	args := m.Called(userID, request)
+	return args.Get(0).(*exports.Export), args.Error(1)
+}
@@ -0,0 +1,405 @@
+package imports
+
+import (
+	"io"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/accounts"
+	"github.com/Renan-Parise/finances/internal/api/currencies"
+	"github.com/Renan-Parise/finances/internal/api/suggestions"
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/cache"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/utils"
+)
+
+type ImportUseCase interface {
+	CreateProfile(profile *Profile) error
+	GetProfiles(userID int64) ([]*Profile, error)
+	UpdateProfile(profile *Profile) error
+	DeleteProfile(userID int64, id int64) error
+	ImportCSV(userID int64, options *CSVImport, file io.Reader) (*Report, error)
+	ImportStatement(userID int64, options *StatementImport, file io.Reader) (*Report, error)
+}
+
+type importUseCase struct {
+	importRepo      ImportRepository
+	transactionRepo transactions.TransactionRepositories
+	accountRepo     accounts.AccountRepository
+	currencyRepo    currencies.CurrencyRepository
+	enricher        transactions.Enricher
+	suggestions     suggestions.SuggestionUseCase
+	cache           cache.Invalidator
+}
+
+func NewImportUseCase(ir ImportRepository, tr transactions.TransactionRepositories, ar accounts.AccountRepository,
+	cr currencies.CurrencyRepository, en transactions.Enricher, su suggestions.SuggestionUseCase, ci cache.Invalidator) ImportUseCase {
+	return &importUseCase{
+		importRepo:      ir,
+		transactionRepo: tr,
+		accountRepo:     ar,
+		currencyRepo:    cr,
+		enricher:        en,
+		suggestions:     su,
+		cache:           ci,
+	}
+}
+
+func (uc *importUseCase) CreateProfile(profile *Profile) error {
+	if err := uc.validateProfile(profile); err != nil {
+		return err
+	}
+	return uc.importRepo.CreateProfile(profile)
+}
+
+func (uc *importUseCase) GetProfiles(userID int64) ([]*Profile, error) {
+	return uc.importRepo.GetProfiles(userID)
+}
+
+func (uc *importUseCase) UpdateProfile(profile *Profile) error {
+	existing, err := uc.getProfile(profile.UserID, profile.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.validateProfile(profile); err != nil {
+		return err
+	}
+
+	profile.CreatedAt = existing.CreatedAt
+	profile.UpdatedAt = time.Now()
+	return uc.importRepo.UpdateProfile(profile)
+}
+
+func (uc *importUseCase) DeleteProfile(userID int64, id int64) error {
+	if _, err := uc.getProfile(userID, id); err != nil {
+		return err
+	}
+	return uc.importRepo.DeleteProfile(userID, id)
+}
+
+func (uc *importUseCase) ImportCSV(userID int64, options *CSVImport, file io.Reader) (*Report, error) {
+	if err := validateThreshold(options.SuggestThreshold); err != nil {
+		return nil, err
+	}
+
+	profile, err := uc.getProfile(userID, options.ProfileID)
+	if err != nil {
+		return nil, err
+	}
+
+	currency, err := accounts.ResolveCurrency(uc.accountRepo, uc.currencyRepo, userID, profile.AccountID, profile.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ParseCSV(file, profile, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{DryRun: options.DryRun, Total: len(rows), Rows: rows}
+	var batch []*transactions.Transaction
+	var batchRows []*Row
+	for _, row := range rows {
+		if row.Error != "" {
+			report.Failed++
+			continue
+		}
+
+		transaction := transactions.NewTransaction(userID, profile.AccountID, row.Description, 0, row.Amount)
+		transaction.OccurredAt = row.occurredAt
+		transaction.Currency = currency
+		batch = append(batch, transaction)
+		batchRows = append(batchRows, row)
+	}
+
+	if err := uc.categorize(userID, batch, batchRows, profile.Category, options.SuggestThreshold); err != nil {
+		return nil, err
+	}
+
+	if options.DryRun || len(batch) == 0 {
+		return report, nil
+	}
+
+	if err := uc.transactionRepo.CreateBatch(batch); err != nil {
+		return nil, err
+	}
+	report.Imported = len(batch)
+	cache.InvalidateUserOrLog(uc.cache, userID)
+	uc.learn(userID, batch)
+	return report, nil
+}
+
+func (uc *importUseCase) ImportStatement(userID int64, options *StatementImport, file io.Reader) (*Report, error) {
+	if options.AccountID == 0 {
+		return nil, errors.NewValidationError("accountId", "an account is required to import a statement")
+	}
+
+	if options.Category == 0 {
+		return nil, errors.NewValidationError("category", "a default category is required")
+	}
+
+	if err := validateThreshold(options.SuggestThreshold); err != nil {
+		return nil, err
+	}
+
+	account, err := uc.accountRepo.GetByID(userID, options.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if account == nil {
+		return nil, errors.NewValidationError("accountId", "account not found")
+	}
+
+	var statement *Statement
+	switch options.Format {
+	case FormatOFX:
+		statement, err = ParseOFX(file)
+	case FormatQIF:
+		statement, err = ParseQIF(file, options.DateFormat)
+	default:
+		return nil, errors.NewValidationError("format", "format must be ofx or qif")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if statement.Currency != "" && statement.Currency != account.Currency {
+		return nil, errors.NewValidationError("file", "statement currency "+statement.Currency+
+			" does not match the account currency "+account.Currency)
+	}
+
+	fitids := make([]string, len(statement.Entries))
+	for i, entry := range statement.Entries {
+		fitids[i] = entry.FITID
+	}
+
+	known, err := uc.importRepo.GetKnownFITIDs(account.ID, fitids)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{DryRun: options.DryRun, Total: len(statement.Entries)}
+	var batch []*transactions.Transaction
+	var batchFITIDs []string
+	var batchRows []*Row
+	for _, entry := range statement.Entries {
+		entry.Amount.Currency = account.Currency
+		row := &Row{
+			Line:        entry.Line,
+			FITID:       entry.FITID,
+			Date:        entry.Date.Format("2006-01-02"),
+			Description: entry.Description,
+			Amount:      entry.Amount,
+			Duplicate:   known[entry.FITID],
+			occurredAt:  entry.Date,
+		}
+		report.Rows = append(report.Rows, row)
+
+		switch {
+		case row.Duplicate:
+			report.Duplicates++
+			continue
+		case entry.Description == "":
+			row.Error = "description is empty"
+		case entry.Amount.IsZero():
+			row.Error = "amount is zero"
+		}
+		if row.Error != "" {
+			report.Failed++
+			continue
+		}
+
+		transaction := transactions.NewTransaction(userID, account.ID, entry.Description, 0, entry.Amount)
+		transaction.OccurredAt = entry.Date
+		transaction.Currency = account.Currency
+		batch = append(batch, transaction)
+		batchFITIDs = append(batchFITIDs, entry.FITID)
+		batchRows = append(batchRows, row)
+	}
+
+	if err := uc.categorize(userID, batch, batchRows, options.Category, options.SuggestThreshold); err != nil {
+		return nil, err
+	}
+
+	if !options.DryRun && len(batch) > 0 {
+		if report.Imported, err = uc.importRepo.ImportEntries(account.ID, batch, batchFITIDs); err != nil {
+			return nil, err
+		}
+
+		for i, transaction := range batch {
+			if transaction.ID == 0 {
+				batchRows[i].Duplicate = true
+				report.Duplicates++
+			}
+		}
+		cache.InvalidateUserOrLog(uc.cache, userID)
+		uc.learn(userID, batch)
+	}
+
+	if statement.LedgerBalance != nil {
+		if report.Balance, err = uc.checkBalance(account, statement, batch, options.DryRun); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+func (uc *importUseCase) checkBalance(account *accounts.Account, statement *Statement, batch []*transactions.Transaction, dryRun bool) (*BalanceCheck, error) {
+	date := statement.LedgerDate
+	if date.IsZero() {
+		date = time.Now()
+	}
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	computed, err := uc.accountRepo.GetBalanceBefore(account.UserID, account.ID, day.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	computed.Currency = account.Currency
+
+	if dryRun {
+		for _, transaction := range batch {
+			if transaction.OccurredAt.Before(day.AddDate(0, 0, 1)) {
+				computed = computed.Add(transaction.Amount)
+			}
+		}
+	}
+
+	ledger := *statement.LedgerBalance
+	ledger.Currency = account.Currency
+	return &BalanceCheck{
+		Date:       day.Format("2006-01-02"),
+		Ledger:     ledger,
+		Computed:   computed,
+		Difference: ledger.Sub(computed),
+	}, nil
+}
+
+func (uc *importUseCase) categorize(userID int64, batch []*transactions.Transaction, rows []*Row, category int, threshold float64) error {
+	if err := uc.enricher.Enrich(userID, batch); err != nil {
+		return err
+	}
+
+	if threshold > 0 {
+		var pending []int
+		var descriptions []string
+		for i, transaction := range batch {
+			if transaction.Category == 0 {
+				pending = append(pending, i)
+				descriptions = append(descriptions, transaction.Description)
+			}
+		}
+
+		if len(pending) > 0 {
+			suggested, err := uc.suggestions.SuggestBest(userID, descriptions)
+			if err != nil {
+				return err
+			}
+
+			for j, i := range pending {
+				if suggested[j] != nil && suggested[j].Confidence >= threshold {
+					batch[i].Category = suggested[j].Category
+					rows[i].Confidence = suggested[j].Confidence
+				}
+			}
+		}
+	}
+
+	for i, transaction := range batch {
+		if transaction.Category == 0 {
+			transaction.Category = category
+		}
+		rows[i].Category = transaction.Category
+	}
+	return nil
+}
+
+func (uc *importUseCase) learn(userID int64, batch []*transactions.Transaction) {
+	var imported []*transactions.Transaction
+	for _, transaction := range batch {
+		if transaction.ID != 0 {
+			imported = append(imported, transaction)
+		}
+	}
+
+	transactions.Relearn(uc.suggestions, userID, nil, imported)
+}
+
+func validateThreshold(threshold float64) error {
+	if threshold < 0 || threshold > 1 {
+		return errors.NewValidationError("suggestThreshold", "suggestThreshold must be between 0 and 1")
+	}
+	return nil
+}
+
+func (uc *importUseCase) getProfile(userID int64, id int64) (*Profile, error) {
+	profile, err := uc.importRepo.GetProfile(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile == nil {
+		return nil, errors.NewValidationError("profileId", "import profile not found")
+	}
+	return profile, nil
+}
+
+func (uc *importUseCase) validateProfile(profile *Profile) error {
+	if profile.Name == "" {
+		return errors.NewValidationError("name", "name is required")
+	}
+
+	if profile.Category == 0 {
+		return errors.NewValidationError("category", "a default category is required")
+	}
+
+	if len([]rune(profile.Delimiter)) != 1 {
+		return errors.NewValidationError("delimiter", "delimiter must be a single character")
+	}
+
+	if profile.DecimalSeparator != "." && profile.DecimalSeparator != "," {
+		return errors.NewValidationError("decimalSeparator", "decimal separator must be '.' or ','")
+	}
+
+	if profile.Delimiter == profile.DecimalSeparator {
+		return errors.NewValidationError("delimiter", "delimiter and decimal separator must differ")
+	}
+
+	if !validEncodings[profile.Encoding] {
+		return errors.NewValidationError("encoding", "unsupported encoding "+profile.Encoding)
+	}
+
+	if profile.SkipRows < 0 {
+		return errors.NewValidationError("skipRows", "skipRows cannot be negative")
+	}
+
+	if profile.DateColumn == "" || profile.DescriptionColumn == "" {
+		return errors.NewValidationError("dateColumn", "date and description columns are required")
+	}
+
+	if _, err := utils.DateLayout(profile.DateFormat); err != nil {
+		return err
+	}
+
+	if profile.AmountColumn == "" && profile.DebitColumn == "" && profile.CreditColumn == "" {
+		return errors.NewValidationError("amountColumn", "an amount column or debit/credit columns are required")
+	}
+
+	if profile.AmountColumn != "" && (profile.DebitColumn != "" || profile.CreditColumn != "") {
+		return errors.NewValidationError("amountColumn", "use either an amount column or debit/credit columns")
+	}
+
+	if profile.Currency != "" || profile.AccountID != 0 {
+		currency, err := accounts.ResolveCurrency(uc.accountRepo, uc.currencyRepo, profile.UserID, profile.AccountID, profile.Currency)
+		if err != nil {
+			return err
+		}
+		if profile.Currency != "" {
+			profile.Currency = currency
+		}
+	}
+	return nil
+}
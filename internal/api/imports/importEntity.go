@@ -0,0 +1,115 @@
+package imports
+
+import (
+	"time"
+
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+const (
+	EncodingUTF8        = "utf-8"
+	EncodingLatin1      = "latin-1"
+	EncodingWindows1252 = "windows-1252"
+)
+
+var validEncodings = map[string]bool{
+	EncodingUTF8:        true,
+	EncodingLatin1:      true,
+	EncodingWindows1252: true,
+}
+
+// Profile describes how to read one bank's CSV statement.
+type Profile struct {
+	ID                int64     `json:"id"`
+	UserID            int64     `json:"userId"`
+	Name              string    `json:"name"`
+	AccountID         int64     `json:"accountId,omitempty"`
+	Category          int       `json:"category"`
+	Currency          string    `json:"currency,omitempty"`
+	Delimiter         string    `json:"delimiter"`
+	Encoding          string    `json:"encoding"`
+	HasHeader         bool      `json:"hasHeader"`
+	SkipRows          int       `json:"skipRows"`
+	DateColumn        string    `json:"dateColumn"`
+	DateFormat        string    `json:"dateFormat"`
+	DescriptionColumn string    `json:"descriptionColumn"`
+	AmountColumn      string    `json:"amountColumn,omitempty"`
+	DebitColumn       string    `json:"debitColumn,omitempty"`
+	CreditColumn      string    `json:"creditColumn,omitempty"`
+	DecimalSeparator  string    `json:"decimalSeparator"`
+	CreatedAt         time.Time `json:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+const (
+	FormatOFX = "ofx"
+	FormatQIF = "qif"
+)
+
+// Row is one parsed statement line.
+type Row struct {
+	Line        int         `json:"line"`
+	FITID       string      `json:"fitid,omitempty"`
+	Date        string      `json:"date,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Amount      money.Money `json:"amount"`
+	Category    int         `json:"category,omitempty"`
+	Confidence  float64     `json:"confidence,omitempty"`
+	Duplicate   bool        `json:"duplicate,omitempty"`
+	Error       string      `json:"error,omitempty"`
+
+	occurredAt time.Time
+}
+
+type Report struct {
+	DryRun     bool          `json:"dryRun"`
+	Total      int           `json:"total"`
+	Imported   int           `json:"imported"`
+	Duplicates int           `json:"duplicates"`
+	Failed     int           `json:"failed"`
+	Balance    *BalanceCheck `json:"balance,omitempty"`
+	Rows       []*Row        `json:"rows"`
+}
+
+// BalanceCheck compares the ledger balance reported by the bank with the
+// balance the account has in the API on the same date.
+type BalanceCheck struct {
+	Date       string      `json:"date"`
+	Ledger     money.Money `json:"ledger"`
+	Computed   money.Money `json:"computed"`
+	Difference money.Money `json:"difference"`
+}
+
+// Statement is an account statement read from an OFX or QIF file.
+type Statement struct {
+	AccountNumber string
+	Currency      string
+	Entries       []*Entry
+	LedgerBalance *money.Money
+	LedgerDate    time.Time
+}
+
+// Entry is a single statement transaction.
+type Entry struct {
+	Line        int
+	FITID       string
+	Date        time.Time
+	Description string
+	Amount      money.Money
+}
+
+// CSVImport and StatementImport carry the options of one import request.
+type CSVImport struct {
+	ProfileID        int64
+	DryRun           bool
+	SuggestThreshold float64
+}
+
+type StatementImport struct {
+	AccountID        int64
+	Category         int
+	Format           string
+	DateFormat       string
+	DryRun           bool
+	SuggestThreshold float64
+}
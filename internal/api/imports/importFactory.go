@@ -0,0 +1,245 @@
+package imports
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/utils"
+	"github.com/Renan-Parise/finances/pkg/money"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+func NewProfile(userID int64, name string) *Profile {
+	now := time.Now()
+	return &Profile{
+		UserID:           userID,
+		Name:             name,
+		Delimiter:        ",",
+		Encoding:         EncodingUTF8,
+		HasHeader:        true,
+		DecimalSeparator: ".",
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+}
+
+// ParseAmount reads a statement amount written with the given decimal
+// separator.
+func ParseAmount(value, decimalSeparator, currency string) (money.Money, error) {
+	value = strings.TrimSpace(value)
+	negative := false
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		negative = true
+		value = value[1 : len(value)-1]
+	}
+	if strings.HasSuffix(value, "-") {
+		negative = true
+		value = strings.TrimSuffix(value, "-")
+	}
+
+	var b strings.Builder
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '-':
+			b.WriteRune(r)
+		case string(r) == decimalSeparator:
+			b.WriteRune('.')
+		}
+	}
+
+	amount, err := money.Parse(b.String(), currency)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("invalid amount %q", value)
+	}
+	if negative {
+		amount = amount.Abs().Neg()
+	}
+	return amount, nil
+}
+
+// ParseCSV reads a statement with the given profile.
+func ParseCSV(r io.Reader, profile *Profile, currency string) ([]*Row, error) {
+	layout, err := utils.DateLayout(profile.DateFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := decode(r, profile.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(decoded)
+	reader.Comma = []rune(profile.Delimiter)[0]
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	for i := 0; i < profile.SkipRows; i++ {
+		if _, err := reader.Read(); err != nil {
+			return nil, errors.NewValidationError("file", "file ends before the rows to skip")
+		}
+	}
+
+	var header []string
+	if profile.HasHeader {
+		if header, err = reader.Read(); err != nil {
+			return nil, errors.NewValidationError("file", "could not read CSV header: "+err.Error())
+		}
+	}
+
+	columns, err := resolveColumns(profile, header)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []*Row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.NewValidationError("file", err.Error())
+		}
+		if isBlank(record) {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := &Row{Line: line}
+		if err := fillRow(row, record, columns, profile, layout, currency); err != nil {
+			row.Error = err.Error()
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+type columnIndexes struct {
+	date, description, amount, debit, credit int
+}
+
+func resolveColumns(profile *Profile, header []string) (*columnIndexes, error) {
+	byName := make(map[string]int, len(header))
+	for i, name := range header {
+		byName[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	find := func(field, column string) (int, error) {
+		if column == "" {
+			return -1, nil
+		}
+		if i, ok := byName[strings.ToLower(strings.TrimSpace(column))]; ok {
+			return i, nil
+		}
+		if position, err := strconv.Atoi(column); err == nil && position > 0 {
+			return position - 1, nil
+		}
+		return 0, errors.NewValidationError(field, "column "+column+" not found in the file")
+	}
+
+	var columns columnIndexes
+	var err error
+	if columns.date, err = find("dateColumn", profile.DateColumn); err != nil {
+		return nil, err
+	}
+	if columns.description, err = find("descriptionColumn", profile.DescriptionColumn); err != nil {
+		return nil, err
+	}
+	if columns.amount, err = find("amountColumn", profile.AmountColumn); err != nil {
+		return nil, err
+	}
+	if columns.debit, err = find("debitColumn", profile.DebitColumn); err != nil {
+		return nil, err
+	}
+	if columns.credit, err = find("creditColumn", profile.CreditColumn); err != nil {
+		return nil, err
+	}
+	return &columns, nil
+}
+
+func fillRow(row *Row, record []string, columns *columnIndexes, profile *Profile, layout, currency string) error {
+	field := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	date, err := time.Parse(layout, field(columns.date))
+	if err != nil {
+		return fmt.Errorf("invalid date %q, expected %s", field(columns.date), profile.DateFormat)
+	}
+	row.occurredAt = date
+	row.Date = date.Format("2006-01-02")
+
+	row.Description = field(columns.description)
+	if row.Description == "" {
+		return fmt.Errorf("description is empty")
+	}
+
+	if columns.amount >= 0 {
+		if row.Amount, err = ParseAmount(field(columns.amount), profile.DecimalSeparator, currency); err != nil {
+			return err
+		}
+	} else {
+		row.Amount = money.Zero(currency)
+		if value := field(columns.credit); value != "" {
+			credit, err := ParseAmount(value, profile.DecimalSeparator, currency)
+			if err != nil {
+				return err
+			}
+			row.Amount = row.Amount.Add(credit.Abs())
+		}
+		if value := field(columns.debit); value != "" {
+			debit, err := ParseAmount(value, profile.DecimalSeparator, currency)
+			if err != nil {
+				return err
+			}
+			row.Amount = row.Amount.Sub(debit.Abs())
+		}
+	}
+
+	if row.Amount.IsZero() {
+		return fmt.Errorf("amount is zero")
+	}
+	return nil
+}
+
+func decode(r io.Reader, encoding string) (io.Reader, error) {
+	switch encoding {
+	case EncodingLatin1:
+		r = charmap.ISO8859_1.NewDecoder().Reader(r)
+	case EncodingWindows1252:
+		r = charmap.Windows1252.NewDecoder().Reader(r)
+	case EncodingUTF8, "":
+	default:
+		return nil, errors.NewValidationError("encoding", "unsupported encoding "+encoding)
+	}
+
+	buffered := bufio.NewReader(r)
+	if bom, err := buffered.Peek(3); err == nil && bytes.Equal(bom, []byte{0xEF, 0xBB, 0xBF}) {
+		buffered.Discard(3)
+	}
+	return buffered, nil
+}
+
+func isBlank(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,337 @@
+package imports
+
+import (
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ImportHandler struct {
+	importUseCase ImportUseCase
+}
+
+func NewImportHandler(router *gin.RouterGroup, iu ImportUseCase) {
+	handler := &ImportHandler{
+		importUseCase: iu,
+	}
+
+	imports := router.Group("/imports")
+	imports.Use(middlewares.JWTAuthMiddleware())
+	{
+		imports.POST("/profiles", handler.CreateProfile)
+		imports.GET("/profiles", handler.GetProfiles)
+		imports.PUT("/profiles/:id", handler.UpdateProfile)
+		imports.DELETE("/profiles/:id", handler.DeleteProfile)
+		imports.POST("/csv", handler.ImportCSV)
+		imports.POST("/statement", handler.ImportStatement)
+	}
+}
+
+type profileInput struct {
+	Name              string `json:"name" binding:"required"`
+	AccountID         int64  `json:"accountId"`
+	Category          int    `json:"category" binding:"required"`
+	Currency          string `json:"currency"`
+	Delimiter         string `json:"delimiter"`
+	Encoding          string `json:"encoding"`
+	HasHeader         *bool  `json:"hasHeader"`
+	SkipRows          int    `json:"skipRows"`
+	DateColumn        string `json:"dateColumn" binding:"required"`
+	DateFormat        string `json:"dateFormat" binding:"required"`
+	DescriptionColumn string `json:"descriptionColumn" binding:"required"`
+	AmountColumn      string `json:"amountColumn"`
+	DebitColumn       string `json:"debitColumn"`
+	CreditColumn      string `json:"creditColumn"`
+	DecimalSeparator  string `json:"decimalSeparator"`
+}
+
+func (input *profileInput) toProfile(userID int64) *Profile {
+	profile := NewProfile(userID, input.Name)
+	profile.AccountID = input.AccountID
+	profile.Category = input.Category
+	profile.Currency = input.Currency
+	profile.SkipRows = input.SkipRows
+	profile.DateColumn = input.DateColumn
+	profile.DateFormat = input.DateFormat
+	profile.DescriptionColumn = input.DescriptionColumn
+	profile.AmountColumn = input.AmountColumn
+	profile.DebitColumn = input.DebitColumn
+	profile.CreditColumn = input.CreditColumn
+	if input.Delimiter != "" {
+		profile.Delimiter = input.Delimiter
+	}
+	if input.Encoding != "" {
+		profile.Encoding = input.Encoding
+	}
+	if input.HasHeader != nil {
+		profile.HasHeader = *input.HasHeader
+	}
+	if input.DecimalSeparator != "" {
+		profile.DecimalSeparator = input.DecimalSeparator
+	}
+	return profile
+}
+
+func (h *ImportHandler) CreateProfile(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	var input profileInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile := input.toProfile(userID.(int64))
+	if err := h.importUseCase.CreateProfile(profile); err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, profile)
+}
+
+func (h *ImportHandler) GetProfiles(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	profiles, err := h.importUseCase.GetProfiles(userID.(int64))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, profiles)
+}
+
+func (h *ImportHandler) UpdateProfile(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid profile ID"})
+		return
+	}
+
+	var input profileInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile := input.toProfile(userID.(int64))
+	profile.ID = id
+	if err := h.importUseCase.UpdateProfile(profile); err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Import profile updated successfully"})
+}
+
+func (h *ImportHandler) DeleteProfile(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid profile ID"})
+		return
+	}
+
+	if err := h.importUseCase.DeleteProfile(userID.(int64), id); err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Import profile deleted successfully"})
+}
+
+func (h *ImportHandler) ImportCSV(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	profileID, err := strconv.ParseInt(c.PostForm("profileId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A valid profileId is required"})
+		return
+	}
+
+	dryRun := false
+	if value := c.PostForm("dryRun"); value != "" {
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "dryRun must be true or false"})
+			return
+		}
+	}
+
+	threshold, err := parseThreshold(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A CSV file is required in the 'file' field"})
+		return
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer reader.Close()
+
+	options := &CSVImport{
+		ProfileID:        profileID,
+		DryRun:           dryRun,
+		SuggestThreshold: threshold,
+	}
+
+	report, err := h.importUseCase.ImportCSV(userID.(int64), options, reader)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	status := http.StatusCreated
+	if dryRun {
+		status = http.StatusOK
+	}
+	c.JSON(status, report)
+}
+
+func (h *ImportHandler) ImportStatement(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	accountID, err := strconv.ParseInt(c.PostForm("accountId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A valid accountId is required"})
+		return
+	}
+
+	category, err := strconv.Atoi(c.PostForm("category"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A valid category is required"})
+		return
+	}
+
+	dryRun := false
+	if value := c.PostForm("dryRun"); value != "" {
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "dryRun must be true or false"})
+			return
+		}
+	}
+
+	threshold, err := parseThreshold(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "An OFX or QIF file is required in the 'file' field"})
+		return
+	}
+
+	format := strings.ToLower(c.PostForm("format"))
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(file.Filename)) {
+		case ".ofx", ".qfx":
+			format = FormatOFX
+		case ".qif":
+			format = FormatQIF
+		}
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer reader.Close()
+
+	options := &StatementImport{
+		AccountID:        accountID,
+		Category:         category,
+		Format:           format,
+		DateFormat:       c.PostForm("dateFormat"),
+		DryRun:           dryRun,
+		SuggestThreshold: threshold,
+	}
+
+	report, err := h.importUseCase.ImportStatement(userID.(int64), options, reader)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	status := http.StatusCreated
+	if dryRun {
+		status = http.StatusOK
+	}
+	c.JSON(status, report)
+}
+
+func parseThreshold(c *gin.Context) (float64, error) {
+	value := c.PostForm("suggestThreshold")
+	if value == "" {
+		return 0, nil
+	}
+
+	threshold, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, errors.NewValidationError("suggestThreshold", "suggestThreshold must be a number between 0 and 1")
+	}
+	return threshold, nil
+}
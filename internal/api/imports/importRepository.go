@@ -0,0 +1,205 @@
+package imports
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/ledger"
+)
+
+type ImportRepository interface {
+	CreateProfile(profile *Profile) error
+	GetProfiles(userID int64) ([]*Profile, error)
+	GetProfile(userID int64, id int64) (*Profile, error)
+	UpdateProfile(profile *Profile) error
+	DeleteProfile(userID int64, id int64) error
+	GetKnownFITIDs(accountID int64, fitids []string) (map[string]bool, error)
+	ImportEntries(accountID int64, batch []*transactions.Transaction, fitids []string) (int, error)
+}
+
+const profileColumns = `id, userId, name, COALESCE(accountId, 0), category, COALESCE(currency, ''), delimiter, encoding,
+              hasHeader, skipRows, dateColumn, dateFormat, descriptionColumn, COALESCE(amountColumn, ''),
+              COALESCE(debitColumn, ''), COALESCE(creditColumn, ''), decimalSeparator, createdAt, updatedAt`
+
+type importRepository struct {
+	db *sql.DB
+}
+
+func NewImportRepository(db *sql.DB) ImportRepository {
+	return &importRepository{db: db}
+}
+
+func (r *importRepository) CreateProfile(profile *Profile) error {
+	query := `INSERT INTO import_profiles (userId, name, accountId, category, currency, delimiter, encoding, hasHeader,
+              skipRows, dateColumn, dateFormat, descriptionColumn, amountColumn, debitColumn, creditColumn,
+              decimalSeparator, createdAt, updatedAt)
+              VALUES (?, ?, NULLIF(?, 0), ?, NULLIF(?, ''), ?, ?, ?, ?, ?, ?, ?, NULLIF(?, ''), NULLIF(?, ''), NULLIF(?, ''), ?, ?, ?)`
+	res, err := r.db.Exec(query, profile.UserID, profile.Name, profile.AccountID, profile.Category, profile.Currency,
+		profile.Delimiter, profile.Encoding, profile.HasHeader, profile.SkipRows, profile.DateColumn, profile.DateFormat,
+		profile.DescriptionColumn, profile.AmountColumn, profile.DebitColumn, profile.CreditColumn,
+		profile.DecimalSeparator, profile.CreatedAt, profile.UpdatedAt)
+	if err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return errors.NewQueryError("error getting last insert ID: " + err.Error())
+	}
+
+	profile.ID = id
+	return nil
+}
+
+func (r *importRepository) GetProfiles(userID int64) ([]*Profile, error) {
+	query := `SELECT ` + profileColumns + ` FROM import_profiles WHERE userId = ? ORDER BY name`
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	var profiles []*Profile
+	for rows.Next() {
+		profile, err := scanProfile(rows)
+		if err != nil {
+			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, nil
+}
+
+func (r *importRepository) GetProfile(userID int64, id int64) (*Profile, error) {
+	query := `SELECT ` + profileColumns + ` FROM import_profiles WHERE id = ? AND userId = ?`
+	profile, err := scanProfile(r.db.QueryRow(query, id, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.NewQueryError("error scanning row: " + err.Error())
+	}
+	return profile, nil
+}
+
+func (r *importRepository) UpdateProfile(profile *Profile) error {
+	query := `UPDATE import_profiles SET name = ?, accountId = NULLIF(?, 0), category = ?, currency = NULLIF(?, ''),
+              delimiter = ?, encoding = ?, hasHeader = ?, skipRows = ?, dateColumn = ?, dateFormat = ?,
+              descriptionColumn = ?, amountColumn = NULLIF(?, ''), debitColumn = NULLIF(?, ''), creditColumn = NULLIF(?, ''),
+              decimalSeparator = ?, updatedAt = ?
+              WHERE id = ? AND userId = ?`
+	_, err := r.db.Exec(query, profile.Name, profile.AccountID, profile.Category, profile.Currency, profile.Delimiter,
+		profile.Encoding, profile.HasHeader, profile.SkipRows, profile.DateColumn, profile.DateFormat,
+		profile.DescriptionColumn, profile.AmountColumn, profile.DebitColumn, profile.CreditColumn,
+		profile.DecimalSeparator, profile.UpdatedAt, profile.ID, profile.UserID)
+	if err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+	return nil
+}
+
+func (r *importRepository) DeleteProfile(userID int64, id int64) error {
+	query := `DELETE FROM import_profiles WHERE id = ? AND userId = ?`
+	if _, err := r.db.Exec(query, id, userID); err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+	return nil
+}
+
+func (r *importRepository) GetKnownFITIDs(accountID int64, fitids []string) (map[string]bool, error) {
+	known := make(map[string]bool)
+	if len(fitids) == 0 {
+		return known, nil
+	}
+
+	placeholders := make([]string, len(fitids))
+	args := []interface{}{accountID}
+	for i, fitid := range fitids {
+		placeholders[i] = "?"
+		args = append(args, fitid)
+	}
+
+	query := `SELECT fitid FROM import_fitids WHERE accountId = ? AND fitid IN (` + strings.Join(placeholders, ", ") + `)`
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var fitid string
+		if err := rows.Scan(&fitid); err != nil {
+			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		known[fitid] = true
+	}
+	return known, nil
+}
+
+func (r *importRepository) ImportEntries(accountID int64, batch []*transactions.Transaction, fitids []string) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, errors.NewDatabaseError("error starting transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	ids := make([]int64, len(batch))
+	imported := 0
+	for i, transaction := range batch {
+		res, err := tx.Exec(`INSERT IGNORE INTO import_fitids (accountId, fitid, createdAt) VALUES (?, ?, ?)`,
+			accountID, fitids[i], now)
+		if err != nil {
+			return 0, errors.NewQueryError("error claiming FITID: " + err.Error())
+		}
+
+		claimed, err := res.RowsAffected()
+		if err != nil {
+			return 0, errors.NewQueryError("error claiming FITID: " + err.Error())
+		}
+		if claimed == 0 {
+			continue
+		}
+
+		entry := transaction.LedgerEntry()
+		entry.AccountID = accountID
+		if ids[i], err = ledger.Insert(tx, entry); err != nil {
+			return 0, err
+		}
+
+		_, err = tx.Exec(`UPDATE import_fitids SET transactionId = ? WHERE accountId = ? AND fitid = ?`,
+			ids[i], accountID, fitids[i])
+		if err != nil {
+			return 0, errors.NewQueryError("error linking FITID: " + err.Error())
+		}
+		imported++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+
+	for i, transaction := range batch {
+		transaction.ID = ids[i]
+	}
+	return imported, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanProfile(row rowScanner) (*Profile, error) {
+	var profile Profile
+	err := row.Scan(&profile.ID, &profile.UserID, &profile.Name, &profile.AccountID, &profile.Category,
+		&profile.Currency, &profile.Delimiter, &profile.Encoding, &profile.HasHeader, &profile.SkipRows,
+		&profile.DateColumn, &profile.DateFormat, &profile.DescriptionColumn, &profile.AmountColumn,
+		&profile.DebitColumn, &profile.CreditColumn, &profile.DecimalSeparator, &profile.CreatedAt, &profile.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
@@ -0,0 +1,236 @@
+package imports
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/pkg/money"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+type ofxToken struct {
+	name  string
+	close bool
+	text  string
+}
+
+// ParseOFX reads an OFX statement.
+func ParseOFX(r io.Reader) (*Statement, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.NewValidationError("file", "could not read OFX file: "+err.Error())
+	}
+
+	start := bytes.Index(bytes.ToUpper(data), []byte("<OFX>"))
+	if start < 0 {
+		return nil, errors.NewValidationError("file", "not an OFX file: <OFX> element not found")
+	}
+
+	body, err := decodeStatement(data[:start], data[start:])
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := tokenizeOFX(body)
+	statement := &Statement{}
+	statements := 0
+	var entry *Entry
+	var path []string
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if token.close {
+			for j := len(path) - 1; j >= 0; j-- {
+				if path[j] == token.name {
+					path = path[:j]
+					break
+				}
+			}
+			if token.name == "STMTTRN" && entry != nil {
+				statement.Entries = append(statement.Entries, entry)
+				entry = nil
+			}
+			continue
+		}
+
+		if token.text == "" {
+			path = append(path, token.name)
+			switch token.name {
+			case "STMTRS", "CCSTMTRS":
+				statements++
+			case "STMTTRN":
+				entry = &Entry{Line: len(statement.Entries) + 1}
+			}
+			continue
+		}
+
+		if i+1 < len(tokens) && tokens[i+1].close && tokens[i+1].name == token.name {
+			i++
+		}
+
+		parent := ""
+		if len(path) > 0 {
+			parent = path[len(path)-1]
+		}
+
+		if err := applyOFXField(statement, entry, parent, token.name, token.text); err != nil {
+			return nil, err
+		}
+	}
+
+	if statements > 1 {
+		return nil, errors.NewValidationError("file", fmt.Sprintf("file contains %d statements; import one account at a time", statements))
+	}
+
+	for _, entry := range statement.Entries {
+		entry.Amount.Currency = statement.Currency
+	}
+	assignFingerprints(statement.Entries)
+	if statement.LedgerBalance != nil {
+		statement.LedgerBalance.Currency = statement.Currency
+	}
+	return statement, nil
+}
+
+func applyOFXField(statement *Statement, entry *Entry, parent, name, value string) error {
+	switch {
+	case name == "CURDEF":
+		statement.Currency = strings.ToUpper(value)
+	case name == "ACCTID" && (parent == "BANKACCTFROM" || parent == "CCACCTFROM"):
+		statement.AccountNumber = value
+	case parent == "LEDGERBAL" && name == "BALAMT":
+		balance, err := money.Parse(strings.TrimPrefix(value, "+"), "")
+		if err != nil {
+			return errors.NewValidationError("file", "invalid ledger balance "+value)
+		}
+		statement.LedgerBalance = &balance
+	case parent == "LEDGERBAL" && name == "DTASOF":
+		date, err := parseOFXDate(value)
+		if err != nil {
+			return err
+		}
+		statement.LedgerDate = date
+	case entry == nil:
+	case name == "FITID":
+		entry.FITID = value
+	case name == "DTPOSTED":
+		date, err := parseOFXDate(value)
+		if err != nil {
+			return err
+		}
+		entry.Date = date
+	case name == "TRNAMT":
+		amount, err := money.Parse(strings.TrimPrefix(value, "+"), "")
+		if err != nil {
+			return errors.NewValidationError("file", fmt.Sprintf("entry %d: invalid amount %s", entry.Line, value))
+		}
+		entry.Amount = amount
+	case name == "NAME" || name == "MEMO":
+		switch {
+		case entry.Description == "":
+			entry.Description = value
+		case !strings.Contains(entry.Description, value):
+			entry.Description += " - " + value
+		}
+	}
+	return nil
+}
+
+func parseOFXDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, errors.NewValidationError("file", "invalid OFX date "+value)
+	}
+
+	date, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, errors.NewValidationError("file", "invalid OFX date "+value)
+	}
+	return date, nil
+}
+
+func decodeStatement(header, body []byte) (string, error) {
+	declared := strings.ToUpper(string(header))
+	var decoder *charmap.Charmap
+	switch {
+	case strings.Contains(declared, "CHARSET:1252"), strings.Contains(declared, "WINDOWS-1252"):
+		decoder = charmap.Windows1252
+	case strings.Contains(declared, "8859-1"), strings.Contains(declared, "LATIN"):
+		decoder = charmap.ISO8859_1
+	case !utf8.Valid(body):
+		decoder = charmap.Windows1252
+	}
+
+	if decoder == nil {
+		return string(body), nil
+	}
+
+	decoded, err := decoder.NewDecoder().Bytes(body)
+	if err != nil {
+		return "", errors.NewValidationError("file", "could not decode file: "+err.Error())
+	}
+	return string(decoded), nil
+}
+
+func tokenizeOFX(body string) []ofxToken {
+	var tokens []ofxToken
+	for {
+		open := strings.IndexByte(body, '<')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(body[open:], '>')
+		if end < 0 {
+			break
+		}
+
+		tag := strings.TrimSpace(body[open+1 : open+end])
+		body = body[open+end+1:]
+		if tag == "" || tag[0] == '?' || tag[0] == '!' {
+			continue
+		}
+
+		text := body
+		if next := strings.IndexByte(body, '<'); next >= 0 {
+			text = body[:next]
+		}
+		text = html.UnescapeString(strings.TrimSpace(text))
+
+		switch {
+		case tag[0] == '/':
+			tokens = append(tokens, ofxToken{name: strings.ToUpper(tag[1:]), close: true})
+		case strings.HasSuffix(tag, "/"):
+			name := strings.ToUpper(strings.TrimSpace(strings.TrimSuffix(tag, "/")))
+			tokens = append(tokens, ofxToken{name: name}, ofxToken{name: name, close: true})
+		default:
+			if space := strings.IndexAny(tag, " \t\r\n"); space >= 0 {
+				tag = tag[:space]
+			}
+			tokens = append(tokens, ofxToken{name: strings.ToUpper(tag), text: text})
+		}
+	}
+	return tokens
+}
+
+func assignFingerprints(entries []*Entry) {
+	seen := make(map[string]int)
+	for _, entry := range entries {
+		if entry.FITID != "" {
+			continue
+		}
+
+		key := fmt.Sprintf("%s|%d|%s", entry.Date.Format("2006-01-02"), entry.Amount.Amount,
+			strings.ToLower(strings.TrimSpace(entry.Description)))
+		seen[key]++
+		sum := sha1.Sum([]byte(fmt.Sprintf("%s|%d", key, seen[key])))
+		entry.FITID = "fp:" + hex.EncodeToString(sum[:])
+	}
+}
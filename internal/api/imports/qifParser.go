@@ -0,0 +1,174 @@
+package imports
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+)
+
+var qifTypes = map[string]bool{
+	"bank":  true,
+	"ccard": true,
+	"cash":  true,
+	"oth a": true,
+	"oth l": true,
+}
+
+// ParseQIF reads the cash, bank and credit card sections of a QIF file.
+func ParseQIF(r io.Reader, dateFormat string) (*Statement, error) {
+	order, err := dateOrder(dateFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.NewValidationError("file", "could not read QIF file: "+err.Error())
+	}
+
+	body, err := decodeStatement(nil, data)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(strings.TrimPrefix(body, "\ufeff")))
+	statement := &Statement{}
+	inTransactions := false
+	var entry *Entry
+	var payee, memo string
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "!") {
+			header := strings.ToLower(strings.TrimSpace(text))
+			if strings.HasPrefix(header, "!type:") {
+				section := strings.TrimPrefix(header, "!type:")
+				if section == "invst" {
+					return nil, errors.NewValidationError("file", "investment QIF files are not supported")
+				}
+				inTransactions = qifTypes[section]
+			} else {
+				inTransactions = false
+			}
+			continue
+		}
+
+		if !inTransactions {
+			continue
+		}
+
+		if entry == nil {
+			entry = &Entry{Line: line}
+		}
+
+		code, value := text[0], strings.TrimSpace(text[1:])
+		switch code {
+		case 'D':
+			date, err := parseQIFDate(value, order)
+			if err != nil {
+				return nil, errors.NewValidationError("file", fmt.Sprintf("line %d: %s", line, err.Error()))
+			}
+			entry.Date = date
+		case 'T', 'U':
+			separator := "."
+			if strings.Contains(value, ",") && !strings.Contains(value, ".") {
+				separator = ","
+			}
+			amount, err := ParseAmount(value, separator, "")
+			if err != nil {
+				return nil, errors.NewValidationError("file", fmt.Sprintf("line %d: %s", line, err.Error()))
+			}
+			entry.Amount = amount
+		case 'P':
+			payee = value
+		case 'M':
+			memo = value
+		case '^':
+			entry.Description = payee
+			if entry.Description == "" {
+				entry.Description = memo
+			} else if memo != "" && !strings.Contains(payee, memo) {
+				entry.Description += " - " + memo
+			}
+
+			if entry.Date.IsZero() {
+				return nil, errors.NewValidationError("file", fmt.Sprintf("line %d: entry has no date", line))
+			}
+			statement.Entries = append(statement.Entries, entry)
+			entry, payee, memo = nil, "", ""
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.NewValidationError("file", "could not read QIF file: "+err.Error())
+	}
+
+	assignFingerprints(statement.Entries)
+	return statement, nil
+}
+
+func dateOrder(format string) (string, error) {
+	if format == "" {
+		format = "DD/MM/YYYY"
+	}
+
+	d, m, y := strings.Index(format, "D"), strings.Index(format, "M"), strings.Index(format, "Y")
+	if d < 0 || m < 0 || y < 0 {
+		return "", errors.NewValidationError("dateFormat", "date format must contain YYYY (or YY), MM and DD")
+	}
+
+	order := []byte("DMY")
+	positions := map[byte]int{'D': d, 'M': m, 'Y': y}
+	for i := 0; i < len(order); i++ {
+		for j := i + 1; j < len(order); j++ {
+			if positions[order[j]] < positions[order[i]] {
+				order[i], order[j] = order[j], order[i]
+			}
+		}
+	}
+	return string(order), nil
+}
+
+func parseQIFDate(value, order string) (time.Time, error) {
+	parts := strings.FieldsFunc(value, func(r rune) bool {
+		return r == '/' || r == '-' || r == '.' || r == '\'' || r == ' '
+	})
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+
+	var day, month, year int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q", value)
+		}
+		switch order[i] {
+		case 'D':
+			day = n
+		case 'M':
+			month = n
+		case 'Y':
+			year = n
+		}
+	}
+
+	if year < 100 {
+		year += 2000
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Day() != day || int(date.Month()) != month {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return date, nil
+}
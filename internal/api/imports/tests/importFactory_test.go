@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Renan-Parise/finances/internal/api/imports"
+	"github.com/Renan-Parise/finances/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAmount(t *testing.T) {
+	cases := []struct {
+		value     string
+		separator string
+		expected  string
+	}{
+		{"1.234,56", ",", "1234.56"},
+		{"-1,234.56", ".", "-1234.56"},
+		{"R$ 10,00", ",", "10.00"},
+		{"(42.10)", ".", "-42.10"},
+		{"15,90-", ",", "-15.90"},
+	}
+
+	for _, tc := range cases {
+		amount, err := imports.ParseAmount(tc.value, tc.separator, "BRL")
+		require.NoError(t, err, tc.value)
+		assert.Equal(t, money.MustParse(tc.expected, "BRL"), amount, tc.value)
+	}
+
+	_, err := imports.ParseAmount("abc", ".", "BRL")
+	assert.Error(t, err)
+}
+
+func TestParseCSVWithSignedAmountColumn(t *testing.T) {
+	profile := imports.NewProfile(1, "Bank")
+	profile.Delimiter = ";"
+	profile.DecimalSeparator = ","
+	profile.DateColumn = "Data"
+	profile.DateFormat = "DD/MM/YYYY"
+	profile.DescriptionColumn = "Histórico"
+	profile.AmountColumn = "Valor"
+
+	file := "Data;Histórico;Valor\n05/01/2026;Padaria;-12,50\n06/01/2026;Salário;3.500,00\n\n31/02/2026;Broken;1,00\n"
+	rows, err := imports.ParseCSV(strings.NewReader(file), profile, "BRL")
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, "2026-01-05", rows[0].Date)
+	assert.Equal(t, money.MustParse("-12.50", "BRL"), rows[0].Amount)
+	assert.Equal(t, money.MustParse("3500.00", "BRL"), rows[1].Amount)
+	assert.Empty(t, rows[1].Error)
+	assert.Equal(t, 5, rows[2].Line)
+	assert.Contains(t, rows[2].Error, "invalid date")
+}
+
+func TestParseCSVLatin1DebitCredit(t *testing.T) {
+	profile := imports.NewProfile(1, "Bank")
+	profile.Encoding = imports.EncodingLatin1
+	profile.HasHeader = false
+	profile.SkipRows = 1
+	profile.DateColumn = "1"
+	profile.DateFormat = "YYYY-MM-DD"
+	profile.DescriptionColumn = "2"
+	profile.DebitColumn = "3"
+	profile.CreditColumn = "4"
+
+	var file bytes.Buffer
+	file.WriteString("Extrato gerado em 2026-02-01\n")
+	file.Write([]byte("2026-01-10,Padaria S\xe3o Jo\xe3o,8.40,\n"))
+	file.WriteString("2026-01-11,Reembolso,,20.00\n")
+
+	rows, err := imports.ParseCSV(&file, profile, "BRL")
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, "Padaria São João", rows[0].Description)
+	assert.Equal(t, money.MustParse("-8.40", "BRL"), rows[0].Amount)
+	assert.Equal(t, money.MustParse("20.00", "BRL"), rows[1].Amount)
+}
@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"io"
+	"testing"
+
+	"github.com/Renan-Parise/finances/internal/api/imports"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockImportUseCase struct {
+	mock.Mock
+}
+
+func TestNewImportHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.Default()
+	group := router.Group("/api")
+
+	mockUseCase := new(MockImportUseCase)
+	imports.NewImportHandler(group, mockUseCase)
+
+	routes := router.Routes()
+
+	expectedRoutes := []struct {
+		method string
+		path   string
+	}{
+		{"POST", "/api/imports/profiles"},
+		{"GET", "/api/imports/profiles"},
+		{"PUT", "/api/imports/profiles/:id"},
+		{"DELETE", "/api/imports/profiles/:id"},
+		{"POST", "/api/imports/csv"},
+		{"POST", "/api/imports/statement"},
+	}
+
+	for _, expected := range expectedRoutes {
+		found := false
+		for _, route := range routes {
+			if route.Method == expected.method && route.Path == expected.path {
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, "Route %s %s not registered", expected.method, expected.path)
+	}
+}
+
+func (m *MockImportUseCase) CreateProfile(profile *imports.Profile) error {
+	args := m.Called(profile)
+	return args.Error(0)
+}
+
+func (m *MockImportUseCase) GetProfiles(userID int64) ([]*imports.Profile, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]*imports.Profile), args.Error(1)
+}
+
+func (m *MockImportUseCase) UpdateProfile(profile *imports.Profile) error {
+	args := m.Called(profile)
+	return args.Error(0)
+}
+
+func (m *MockImportUseCase) DeleteProfile(userID int64, id int64) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
+
+func (m *MockImportUseCase) ImportCSV(userID int64, options *imports.CSVImport, file io.Reader) (*imports.Report, error) {
+	args := m.Called(userID, options, file)
+	return args.Get(0).(*imports.Report), args.Error(1)
+}
+
+func (m *MockImportUseCase) ImportStatement(userID int64, options *imports.StatementImport, file io.Reader) (*imports.Report, error) {
+	args := m.Called(userID, options, file)
+	return args.Get(0).(*imports.Report), args.Error(1)
+}
@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/imports"
+	"github.com/Renan-Parise/finances/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sgmlStatement = "OFXHEADER:100\r\nDATA:OFXSGML\r\nVERSION:102\r\nENCODING:USASCII\r\nCHARSET:1252\r\n\r\n" +
+	"<OFX><SIGNONMSGSRSV1><SONRS><STATUS><CODE>0<SEVERITY>INFO</STATUS><DTSERVER>20260131120000[-3:BRT]" +
+	"<LANGUAGE>POR</SONRS></SIGNONMSGSRSV1><BANKMSGSRSV1><STMTTRNRS><TRNUID>1<STATUS><CODE>0<SEVERITY>INFO</STATUS>" +
+	"<STMTRS><CURDEF>BRL<BANKACCTFROM><BANKID>0341<ACCTID>12345-6<ACCTTYPE>CHECKING</BANKACCTFROM>" +
+	"<BANKTRANLIST><DTSTART>20260101<DTEND>20260131" +
+	"<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20260105100000[-3:BRT]<TRNAMT>-12.50<FITID>A1<MEMO>PADARIA S\xc3O JO\xc3O</STMTTRN>" +
+	"<STMTTRN><TRNTYPE>CREDIT<DTPOSTED>20260110<TRNAMT>3500.00<FITID>A2<NAME>SALARIO<MEMO>EMPRESA X</STMTTRN>" +
+	"</BANKTRANLIST><LEDGERBAL><BALAMT>3487.50<DTASOF>20260131</LEDGERBAL></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>"
+
+func TestParseOFXSGML(t *testing.T) {
+	statement, err := imports.ParseOFX(strings.NewReader(sgmlStatement))
+	require.NoError(t, err)
+
+	assert.Equal(t, "BRL", statement.Currency)
+	assert.Equal(t, "12345-6", statement.AccountNumber)
+	require.Len(t, statement.Entries, 2)
+
+	first := statement.Entries[0]
+	assert.Equal(t, "A1", first.FITID)
+	assert.Equal(t, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), first.Date)
+	assert.Equal(t, "PADARIA SÃO JOÃO", first.Description)
+	assert.Equal(t, money.MustParse("-12.50", "BRL"), first.Amount)
+
+	assert.Equal(t, "SALARIO - EMPRESA X", statement.Entries[1].Description)
+	require.NotNil(t, statement.LedgerBalance)
+	assert.Equal(t, money.MustParse("3487.50", "BRL"), *statement.LedgerBalance)
+	assert.Equal(t, time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), statement.LedgerDate)
+}
+
+func TestParseOFXXML(t *testing.T) {
+	file := `<?xml version="1.0" encoding="UTF-8"?><?OFX OFXHEADER="200" VERSION="220"?>
+<OFX><CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS><CURDEF>USD</CURDEF>
+<CCACCTFROM><ACCTID>4111</ACCTID></CCACCTFROM><BANKTRANLIST>
+<STMTTRN><DTPOSTED>20260203</DTPOSTED><TRNAMT>-4.99</TRNAMT><NAME>Coffee &amp; Co</NAME></STMTTRN>
+</BANKTRANLIST></CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1></OFX>`
+
+	statement, err := imports.ParseOFX(strings.NewReader(file))
+	require.NoError(t, err)
+	require.Len(t, statement.Entries, 1)
+	assert.Equal(t, "4111", statement.AccountNumber)
+	assert.Equal(t, "Coffee & Co", statement.Entries[0].Description)
+	assert.True(t, strings.HasPrefix(statement.Entries[0].FITID, "fp:"))
+	assert.Nil(t, statement.LedgerBalance)
+}
+
+func TestParseQIF(t *testing.T) {
+	file := "!Type:Bank\nD01/31'26\nT-1,234.56\nPRent\nMJanuary\n^\nD02/01/2026\nT10.00\nPRefund\n^\nD02/01/2026\nT10.00\nPRefund\n^\n"
+
+	statement, err := imports.ParseQIF(strings.NewReader(file), "MM/DD/YYYY")
+	require.NoError(t, err)
+	require.Len(t, statement.Entries, 3)
+
+	assert.Equal(t, time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), statement.Entries[0].Date)
+	assert.Equal(t, int64(-123456), statement.Entries[0].Amount.Amount)
+	assert.Equal(t, "Rent - January", statement.Entries[0].Description)
+
+	assert.NotEqual(t, statement.Entries[1].FITID, statement.Entries[2].FITID)
+	again, err := imports.ParseQIF(strings.NewReader(file), "MM/DD/YYYY")
+	require.NoError(t, err)
+	assert.Equal(t, statement.Entries[2].FITID, again.Entries[2].FITID)
+}
@@ -0,0 +1,211 @@
+package installments
+
+import (
+	"log"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/accounts"
+	"github.com/Renan-Parise/finances/internal/api/currencies"
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/cache"
+	"github.com/Renan-Parise/finances/internal/errors"
+)
+
+const maxInstallments = 120
+
+type InstallmentUseCase interface {
+	CreatePlan(plan *InstallmentPlan) error
+	GetPlans(userID int64) ([]*InstallmentPlan, error)
+	GetPlan(userID int64, id int64) (*InstallmentPlan, error)
+	PayoffPlan(userID int64, id int64, date time.Time) (*InstallmentPlan, error)
+	CancelPlan(userID int64, id int64) (*InstallmentPlan, error)
+	PostDue(now time.Time) error
+}
+
+type installmentUseCase struct {
+	installmentRepo InstallmentRepository
+	accountRepo     accounts.AccountRepository
+	currencyRepo    currencies.CurrencyRepository
+	learner         transactions.Learner
+	cache           cache.Invalidator
+}
+
+func NewInstallmentUseCase(ir InstallmentRepository, ar accounts.AccountRepository, cr currencies.CurrencyRepository,
+	ln transactions.Learner, ci cache.Invalidator) InstallmentUseCase {
+	return &installmentUseCase{
+		installmentRepo: ir,
+		accountRepo:     ar,
+		currencyRepo:    cr,
+		learner:         ln,
+		cache:           ci,
+	}
+}
+
+func (uc *installmentUseCase) CreatePlan(plan *InstallmentPlan) error {
+	if !plan.TotalAmount.IsPositive() {
+		return errors.NewValidationError("totalAmount", "total amount must be positive")
+	}
+
+	if plan.Count < 2 || plan.Count > maxInstallments {
+		return errors.NewValidationError("installments", "number of installments must be between 2 and 120")
+	}
+
+	rate, ok := ParseMonthlyRate(plan.InterestRate)
+	if !ok {
+		return errors.NewValidationError("interestRate", "interest rate must be a percentage between 0 and 100")
+	}
+
+	currency, err := accounts.ResolveCurrency(uc.accountRepo, uc.currencyRepo, plan.UserID, plan.AccountID, plan.Currency)
+	if err != nil {
+		return err
+	}
+
+	plan.Currency = currency
+	plan.TotalAmount.Currency = currency
+	plan.Schedule = NewSchedule(plan.TotalAmount, plan.Count, plan.FirstDueDate, rate)
+	plan.RemainingBalance = PayoffAmount(plan.Schedule, nil, currency)
+
+	if err := uc.installmentRepo.CreatePlan(plan); err != nil {
+		return err
+	}
+
+	if err := uc.postPlan(plan, startOfDay(time.Now())); err != nil {
+		log.Printf("Failed to post due installments for plan %d: %v", plan.ID, err)
+	}
+	return nil
+}
+
+func (uc *installmentUseCase) GetPlans(userID int64) ([]*InstallmentPlan, error) {
+	return uc.installmentRepo.GetPlans(userID)
+}
+
+func (uc *installmentUseCase) GetPlan(userID int64, id int64) (*InstallmentPlan, error) {
+	plan, err := uc.installmentRepo.GetPlan(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if plan == nil {
+		return nil, errors.NewValidationError("id", "installment plan not found")
+	}
+
+	schedule, err := uc.installmentRepo.GetSchedule(plan.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, installment := range schedule {
+		installment.Amount.Currency = plan.Currency
+	}
+	plan.Schedule = schedule
+	return plan, nil
+}
+
+func (uc *installmentUseCase) PayoffPlan(userID int64, id int64, date time.Time) (*InstallmentPlan, error) {
+	plan, pending, err := uc.getActivePlan(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rate, _ := ParseMonthlyRate(plan.InterestRate)
+	payoff := pending[0]
+	payoff.Amount = PayoffAmount(pending, rate, plan.Currency)
+	payoff.DueDate = startOfDay(date)
+
+	if err := uc.installmentRepo.Payoff(plan, payoff); err != nil {
+		return nil, err
+	}
+	cache.InvalidateUserOrLog(uc.cache, userID)
+	transactions.Relearn(uc.learner, userID, nil, []*transactions.Transaction{postedTransaction(plan, payoff)})
+
+	return uc.GetPlan(userID, id)
+}
+
+func (uc *installmentUseCase) CancelPlan(userID int64, id int64) (*InstallmentPlan, error) {
+	plan, _, err := uc.getActivePlan(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.installmentRepo.Cancel(plan); err != nil {
+		return nil, err
+	}
+	return uc.GetPlan(userID, id)
+}
+
+func (uc *installmentUseCase) PostDue(now time.Time) error {
+	plans, err := uc.installmentRepo.GetDue(startOfDay(now))
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, plan := range plans {
+		if err := uc.postInstallments(plan, plan.Schedule); err != nil {
+			log.Printf("Failed to post installments for plan %d: %v", plan.ID, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return errors.NewServiceError("failed to post some installments")
+	}
+	return nil
+}
+
+func (uc *installmentUseCase) postPlan(plan *InstallmentPlan, today time.Time) error {
+	var due []*Installment
+	for _, installment := range plan.Schedule {
+		if !installment.DueDate.After(today) {
+			due = append(due, installment)
+		}
+	}
+	return uc.postInstallments(plan, due)
+}
+
+func (uc *installmentUseCase) postInstallments(plan *InstallmentPlan, due []*Installment) error {
+	var posted []*transactions.Transaction
+	var err error
+	for _, installment := range due {
+		var ok bool
+		if ok, err = uc.installmentRepo.PostInstallment(plan, installment); err != nil {
+			break
+		}
+		if ok {
+			posted = append(posted, postedTransaction(plan, installment))
+		}
+	}
+
+	if len(posted) > 0 {
+		cache.InvalidateUserOrLog(uc.cache, plan.UserID)
+		transactions.Relearn(uc.learner, plan.UserID, nil, posted)
+	}
+	return err
+}
+
+func (uc *installmentUseCase) getActivePlan(userID int64, id int64) (*InstallmentPlan, []*Installment, error) {
+	plan, err := uc.GetPlan(userID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if plan.Status != PlanActive {
+		return nil, nil, errors.NewValidationError("id", "installment plan is already "+plan.Status)
+	}
+
+	var pending []*Installment
+	for _, installment := range plan.Schedule {
+		if installment.Status == InstallmentPending {
+			pending = append(pending, installment)
+		}
+	}
+
+	if len(pending) == 0 {
+		return nil, nil, errors.NewValidationError("id", "installment plan has no pending installments")
+	}
+	return plan, pending, nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
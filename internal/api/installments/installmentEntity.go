@@ -0,0 +1,45 @@
+package installments
+
+import (
+	"time"
+
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+const (
+	PlanActive    = "active"
+	PlanPaidOff   = "paid_off"
+	PlanCancelled = "cancelled"
+
+	InstallmentPending   = "pending"
+	InstallmentPosted    = "posted"
+	InstallmentCancelled = "cancelled"
+)
+
+type InstallmentPlan struct {
+	ID               int64          `json:"id"`
+	UserID           int64          `json:"userId"`
+	AccountID        int64          `json:"accountId,omitempty"`
+	Description      string         `json:"description"`
+	Category         int            `json:"category"`
+	TotalAmount      money.Money    `json:"totalAmount"`
+	Currency         string         `json:"currency"`
+	Count            int            `json:"installments"`
+	FirstDueDate     time.Time      `json:"firstDueDate"`
+	InterestRate     string         `json:"interestRate"`
+	Status           string         `json:"status"`
+	RemainingBalance money.Money    `json:"remainingBalance"`
+	Schedule         []*Installment `json:"schedule,omitempty"`
+	CreatedAt        time.Time      `json:"createdAt"`
+	UpdatedAt        time.Time      `json:"updatedAt"`
+}
+
+type Installment struct {
+	ID            int64       `json:"id"`
+	PlanID        int64       `json:"planId"`
+	Number        int         `json:"number"`
+	DueDate       time.Time   `json:"dueDate"`
+	Amount        money.Money `json:"amount"`
+	Status        string      `json:"status"`
+	TransactionID int64       `json:"transactionId,omitempty"`
+}
@@ -0,0 +1,126 @@
+package installments
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/utils"
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+var monthlyRatePattern = regexp.MustCompile(`^\d+(\.\d{1,4})?$`)
+
+func NewInstallmentPlan(userID, accountID int64, description string, category int, total money.Money, count int, firstDueDate time.Time, interestRate string) *InstallmentPlan {
+	now := time.Now()
+	return &InstallmentPlan{
+		UserID:       userID,
+		AccountID:    accountID,
+		Description:  description,
+		Category:     category,
+		TotalAmount:  total,
+		Count:        count,
+		FirstDueDate: time.Date(firstDueDate.Year(), firstDueDate.Month(), firstDueDate.Day(), 0, 0, 0, 0, time.UTC),
+		InterestRate: interestRate,
+		Status:       PlanActive,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// NewSchedule splits total into count monthly installments.
+func NewSchedule(total money.Money, count int, firstDueDate time.Time, monthlyRate *big.Rat) []*Installment {
+	payable := total
+	payment := money.New(total.Amount/int64(count), total.Currency)
+
+	if monthlyRate != nil && monthlyRate.Sign() > 0 {
+		exact := pricePayment(total.Rat(), monthlyRate, count)
+		payment = money.FromRat(exact, total.Currency)
+		payable = money.FromRat(new(big.Rat).Mul(exact, big.NewRat(int64(count), 1)), total.Currency)
+	}
+
+	schedule := make([]*Installment, count)
+	for i := 0; i < count; i++ {
+		amount := payment
+		if i == count-1 {
+			amount = payable.Sub(payment.Mul(int64(count - 1)))
+		}
+		schedule[i] = &Installment{
+			Number:  i + 1,
+			DueDate: utils.AddMonths(firstDueDate, i),
+			Amount:  amount,
+			Status:  InstallmentPending,
+		}
+	}
+	return schedule
+}
+
+// PayoffAmount discounts the pending installments back to the first pending
+// due date, so paying early does not include interest not yet incurred.
+func PayoffAmount(pending []*Installment, monthlyRate *big.Rat, currency string) money.Money {
+	if monthlyRate == nil || monthlyRate.Sign() <= 0 {
+		total := money.Zero(currency)
+		for _, installment := range pending {
+			total = total.Add(installment.Amount)
+		}
+		return total
+	}
+
+	factor := new(big.Rat).Add(big.NewRat(1, 1), monthlyRate)
+	discount := big.NewRat(1, 1)
+	sum := new(big.Rat)
+	for _, installment := range pending {
+		sum.Add(sum, new(big.Rat).Quo(installment.Amount.Rat(), discount))
+		discount.Mul(discount, factor)
+	}
+	return money.FromRat(sum, currency)
+}
+
+// ParseMonthlyRate converts a percentage such as "1.99" into 0.0199.
+func ParseMonthlyRate(percent string) (*big.Rat, bool) {
+	if percent == "" {
+		return new(big.Rat), true
+	}
+
+	if !monthlyRatePattern.MatchString(percent) {
+		return nil, false
+	}
+
+	rate, _ := new(big.Rat).SetString(percent)
+	if rate.Cmp(big.NewRat(100, 1)) > 0 {
+		return nil, false
+	}
+	return rate.Quo(rate, big.NewRat(100, 1)), true
+}
+
+func postedDescription(plan *InstallmentPlan, installment *Installment) string {
+	return fmt.Sprintf("%s (%d/%d)", plan.Description, installment.Number, plan.Count)
+}
+
+func postedTransaction(plan *InstallmentPlan, installment *Installment) *transactions.Transaction {
+	return &transactions.Transaction{
+		ID:          installment.TransactionID,
+		UserID:      plan.UserID,
+		AccountID:   plan.AccountID,
+		OccurredAt:  installment.DueDate,
+		Description: postedDescription(plan, installment),
+		Category:    plan.Category,
+		Amount:      installment.Amount.Neg(),
+		Currency:    plan.Currency,
+	}
+}
+
+func pricePayment(principal, rate *big.Rat, count int) *big.Rat {
+	factor := new(big.Rat).Add(big.NewRat(1, 1), rate)
+	compound := big.NewRat(1, 1)
+	for i := 0; i < count; i++ {
+		compound.Mul(compound, factor)
+	}
+
+	numerator := new(big.Rat).Mul(principal, rate)
+	numerator.Mul(numerator, compound)
+	denominator := new(big.Rat).Sub(compound, big.NewRat(1, 1))
+	return numerator.Quo(numerator, denominator)
+}
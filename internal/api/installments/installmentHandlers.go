@@ -0,0 +1,191 @@
+package installments
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/middlewares"
+	"github.com/Renan-Parise/finances/pkg/money"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InstallmentHandler struct {
+	installmentUseCase InstallmentUseCase
+}
+
+func NewInstallmentHandler(router *gin.RouterGroup, iu InstallmentUseCase) {
+	handler := &InstallmentHandler{
+		installmentUseCase: iu,
+	}
+
+	installments := router.Group("/installments")
+	installments.Use(middlewares.JWTAuthMiddleware())
+	{
+		installments.POST("/", handler.CreatePlan)
+		installments.GET("/", handler.GetPlans)
+		installments.GET("/:id", handler.GetPlan)
+		installments.POST("/:id/payoff", handler.PayoffPlan)
+		installments.POST("/:id/cancel", handler.CancelPlan)
+	}
+}
+
+func (h *InstallmentHandler) CreatePlan(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	var input struct {
+		AccountID    int64       `json:"accountId"`
+		Description  string      `json:"description" binding:"required"`
+		Category     int         `json:"category" binding:"required"`
+		TotalAmount  money.Money `json:"totalAmount"`
+		Currency     string      `json:"currency"`
+		Installments int         `json:"installments" binding:"required"`
+		FirstDueDate string      `json:"firstDueDate" binding:"required"`
+		InterestRate string      `json:"interestRate"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	firstDueDate, err := time.Parse("2006-01-02", input.FirstDueDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid first due date, expected YYYY-MM-DD"})
+		return
+	}
+
+	plan := NewInstallmentPlan(userID.(int64), input.AccountID, input.Description, input.Category,
+		input.TotalAmount, input.Installments, firstDueDate, input.InterestRate)
+	plan.Currency = input.Currency
+
+	err = h.installmentUseCase.CreatePlan(plan)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, plan)
+}
+
+func (h *InstallmentHandler) GetPlans(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	plans, err := h.installmentUseCase.GetPlans(userID.(int64))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, plans)
+}
+
+func (h *InstallmentHandler) GetPlan(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid installment plan ID"})
+		return
+	}
+
+	plan, err := h.installmentUseCase.GetPlan(userID.(int64), id)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
+
+func (h *InstallmentHandler) PayoffPlan(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid installment plan ID"})
+		return
+	}
+
+	var input struct {
+		Date string `json:"date"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	date := time.Now()
+	if input.Date != "" {
+		if date, err = time.Parse("2006-01-02", input.Date); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date, expected YYYY-MM-DD"})
+			return
+		}
+	}
+
+	plan, err := h.installmentUseCase.PayoffPlan(userID.(int64), id, date)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
+
+func (h *InstallmentHandler) CancelPlan(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid installment plan ID"})
+		return
+	}
+
+	plan, err := h.installmentUseCase.CancelPlan(userID.(int64), id)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
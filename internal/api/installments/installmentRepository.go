@@ -0,0 +1,328 @@
+package installments
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/ledger"
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+type InstallmentRepository interface {
+	CreatePlan(plan *InstallmentPlan) error
+	GetPlans(userID int64) ([]*InstallmentPlan, error)
+	GetPlan(userID int64, id int64) (*InstallmentPlan, error)
+	GetSchedule(planID int64) ([]*Installment, error)
+	GetDue(until time.Time) ([]*InstallmentPlan, error)
+	PostInstallment(plan *InstallmentPlan, installment *Installment) (bool, error)
+	Payoff(plan *InstallmentPlan, installment *Installment) error
+	Cancel(plan *InstallmentPlan) error
+}
+
+type installmentRepository struct {
+	db *sql.DB
+}
+
+func NewInstallmentRepository(db *sql.DB) InstallmentRepository {
+	return &installmentRepository{db: db}
+}
+
+const planColumns = `p.id, p.userId, COALESCE(p.accountId, 0), p.description, p.category, p.totalAmount, p.currency,
+              p.installments, p.firstDueDate, p.interestRate, p.status, p.createdAt, p.updatedAt`
+
+func (r *installmentRepository) CreatePlan(plan *InstallmentPlan) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return errors.NewDatabaseError("error starting transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`INSERT INTO installment_plans (userId, accountId, description, category, totalAmount, currency,
+              installments, firstDueDate, interestRate, status, createdAt, updatedAt)
+              VALUES (?, NULLIF(?, 0), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		plan.UserID, plan.AccountID, plan.Description, plan.Category, plan.TotalAmount, plan.Currency, plan.Count,
+		plan.FirstDueDate.Format("2006-01-02"), plan.InterestRate, plan.Status, plan.CreatedAt, plan.UpdatedAt)
+	if err != nil {
+		return errors.NewQueryError("error inserting installment plan: " + err.Error())
+	}
+
+	planID, err := res.LastInsertId()
+	if err != nil {
+		return errors.NewQueryError("error getting last insert ID: " + err.Error())
+	}
+
+	stmt, err := tx.Prepare(`INSERT INTO installments (planId, number, dueDate, amount, status) VALUES (?, ?, ?, ?, ?)`)
+	if err != nil {
+		return errors.NewQueryError("error preparing query: " + err.Error())
+	}
+	defer stmt.Close()
+
+	for _, installment := range plan.Schedule {
+		res, err := stmt.Exec(planID, installment.Number, installment.DueDate.Format("2006-01-02"),
+			installment.Amount, installment.Status)
+		if err != nil {
+			return errors.NewQueryError("error inserting installment: " + err.Error())
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			return errors.NewQueryError("error getting last insert ID: " + err.Error())
+		}
+		installment.ID = id
+		installment.PlanID = planID
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+
+	plan.ID = planID
+	return nil
+}
+
+func (r *installmentRepository) GetPlans(userID int64) ([]*InstallmentPlan, error) {
+	query := `SELECT ` + planColumns + `,
+              COALESCE((SELECT SUM(i.amount) FROM installments i WHERE i.planId = p.id AND i.status = ?), 0)
+              FROM installment_plans p
+              WHERE p.userId = ?
+              ORDER BY p.firstDueDate DESC`
+	rows, err := r.db.Query(query, InstallmentPending, userID)
+	if err != nil {
+		return nil, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	var plans []*InstallmentPlan
+	for rows.Next() {
+		plan, err := scanPlan(rows)
+		if err != nil {
+			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+func (r *installmentRepository) GetPlan(userID int64, id int64) (*InstallmentPlan, error) {
+	query := `SELECT ` + planColumns + `,
+              COALESCE((SELECT SUM(i.amount) FROM installments i WHERE i.planId = p.id AND i.status = ?), 0)
+              FROM installment_plans p
+              WHERE p.id = ? AND p.userId = ?`
+	plan, err := scanPlan(r.db.QueryRow(query, InstallmentPending, id, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.NewQueryError("error scanning row: " + err.Error())
+	}
+	return plan, nil
+}
+
+func (r *installmentRepository) GetSchedule(planID int64) ([]*Installment, error) {
+	query := `SELECT id, planId, number, dueDate, amount, status, COALESCE(transactionId, 0)
+              FROM installments WHERE planId = ? ORDER BY number`
+	rows, err := r.db.Query(query, planID)
+	if err != nil {
+		return nil, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	var schedule []*Installment
+	for rows.Next() {
+		var installment Installment
+		err := rows.Scan(&installment.ID, &installment.PlanID, &installment.Number, &installment.DueDate,
+			&installment.Amount, &installment.Status, &installment.TransactionID)
+		if err != nil {
+			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		schedule = append(schedule, &installment)
+	}
+	return schedule, nil
+}
+
+func (r *installmentRepository) GetDue(until time.Time) ([]*InstallmentPlan, error) {
+	query := `SELECT ` + planColumns + `, i.id, i.number, i.dueDate, i.amount
+              FROM installments i
+              JOIN installment_plans p ON p.id = i.planId
+              WHERE i.status = ? AND i.dueDate <= ? AND p.status = ?
+              ORDER BY p.id, i.number`
+	rows, err := r.db.Query(query, InstallmentPending, until.Format("2006-01-02"), PlanActive)
+	if err != nil {
+		return nil, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	var plans []*InstallmentPlan
+	byID := make(map[int64]*InstallmentPlan)
+	for rows.Next() {
+		var plan InstallmentPlan
+		var installment Installment
+		err := rows.Scan(&plan.ID, &plan.UserID, &plan.AccountID, &plan.Description, &plan.Category,
+			&plan.TotalAmount, &plan.Currency, &plan.Count, &plan.FirstDueDate, &plan.InterestRate, &plan.Status,
+			&plan.CreatedAt, &plan.UpdatedAt, &installment.ID, &installment.Number, &installment.DueDate,
+			&installment.Amount)
+		if err != nil {
+			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+		}
+
+		existing, ok := byID[plan.ID]
+		if !ok {
+			plan.TotalAmount.Currency = plan.Currency
+			existing = &plan
+			byID[plan.ID] = existing
+			plans = append(plans, existing)
+		}
+
+		installment.PlanID = plan.ID
+		installment.Status = InstallmentPending
+		installment.Amount.Currency = existing.Currency
+		existing.Schedule = append(existing.Schedule, &installment)
+	}
+	return plans, nil
+}
+
+func (r *installmentRepository) PostInstallment(plan *InstallmentPlan, installment *Installment) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, errors.NewDatabaseError("error starting transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	transactionID, err := postInstallment(tx, plan, installment)
+	if err != nil || transactionID == 0 {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+
+	installment.Status = InstallmentPosted
+	installment.TransactionID = transactionID
+	return true, nil
+}
+
+func (r *installmentRepository) Payoff(plan *InstallmentPlan, installment *Installment) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return errors.NewDatabaseError("error starting transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`UPDATE installments SET amount = ?, dueDate = ? WHERE id = ? AND status = ?`,
+		installment.Amount, installment.DueDate.Format("2006-01-02"), installment.ID, InstallmentPending)
+	if err != nil {
+		return errors.NewQueryError("error updating installment: " + err.Error())
+	}
+
+	_, err = tx.Exec(`UPDATE installments SET status = ? WHERE planId = ? AND id <> ? AND status = ?`,
+		InstallmentCancelled, plan.ID, installment.ID, InstallmentPending)
+	if err != nil {
+		return errors.NewQueryError("error cancelling installments: " + err.Error())
+	}
+
+	transactionID, err := postInstallment(tx, plan, installment)
+	if err != nil {
+		return err
+	}
+	if transactionID == 0 {
+		return errors.NewValidationError("id", "installment was posted in the meantime, try again")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+
+	installment.Status = InstallmentPosted
+	installment.TransactionID = transactionID
+	return nil
+}
+
+func postInstallment(tx *sql.Tx, plan *InstallmentPlan, installment *Installment) (int64, error) {
+	res, err := tx.Exec(`UPDATE installments SET status = ? WHERE id = ? AND status = ?`,
+		InstallmentPosted, installment.ID, InstallmentPending)
+	if err != nil {
+		return 0, errors.NewQueryError("error claiming installment: " + err.Error())
+	}
+
+	claimed, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.NewQueryError("error claiming installment: " + err.Error())
+	}
+	if claimed == 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	transactionID, err := ledger.Insert(tx, &ledger.Entry{
+		UserID:      plan.UserID,
+		AccountID:   plan.AccountID,
+		OccurredAt:  installment.DueDate,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Description: postedDescription(plan, installment),
+		Category:    int64(plan.Category),
+		Amount:      installment.Amount.Neg(),
+		Currency:    plan.Currency,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(`UPDATE installments SET transactionId = ? WHERE id = ?`, transactionID, installment.ID)
+	if err != nil {
+		return 0, errors.NewQueryError("error linking installment: " + err.Error())
+	}
+
+	_, err = tx.Exec(`UPDATE installment_plans SET status = ?, updatedAt = ? WHERE id = ? AND status = ?
+              AND NOT EXISTS (SELECT 1 FROM installments WHERE planId = ? AND status = ?)`,
+		PlanPaidOff, now, plan.ID, PlanActive, plan.ID, InstallmentPending)
+	if err != nil {
+		return 0, errors.NewQueryError("error closing installment plan: " + err.Error())
+	}
+	return transactionID, nil
+}
+
+func (r *installmentRepository) Cancel(plan *InstallmentPlan) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return errors.NewDatabaseError("error starting transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`UPDATE installments SET status = ? WHERE planId = ? AND status = ?`,
+		InstallmentCancelled, plan.ID, InstallmentPending)
+	if err != nil {
+		return errors.NewQueryError("error cancelling installments: " + err.Error())
+	}
+
+	_, err = tx.Exec(`UPDATE installment_plans SET status = ?, updatedAt = ? WHERE id = ?`,
+		PlanCancelled, time.Now(), plan.ID)
+	if err != nil {
+		return errors.NewQueryError("error updating installment plan: " + err.Error())
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPlan(row rowScanner) (*InstallmentPlan, error) {
+	var plan InstallmentPlan
+	err := row.Scan(&plan.ID, &plan.UserID, &plan.AccountID, &plan.Description, &plan.Category,
+		&plan.TotalAmount, &plan.Currency, &plan.Count, &plan.FirstDueDate, &plan.InterestRate, &plan.Status,
+		&plan.CreatedAt, &plan.UpdatedAt, &plan.RemainingBalance)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.TotalAmount.Currency = plan.Currency
+	plan.RemainingBalance = money.New(plan.RemainingBalance.Amount, plan.Currency)
+	return &plan, nil
+}
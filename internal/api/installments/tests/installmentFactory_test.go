@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/installments"
+	"github.com/Renan-Parise/finances/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleWithoutInterestRoundsOnLastInstallment(t *testing.T) {
+	first := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	schedule := installments.NewSchedule(money.MustParse("1000.00", "BRL"), 3, first, nil)
+
+	assert.Len(t, schedule, 3)
+	assert.Equal(t, "333.33", schedule[0].Amount.String())
+	assert.Equal(t, "333.33", schedule[1].Amount.String())
+	assert.Equal(t, "333.34", schedule[2].Amount.String())
+
+	assert.Equal(t, time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), schedule[1].DueDate)
+	assert.Equal(t, time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), schedule[2].DueDate)
+}
+
+func TestScheduleWithInterestUsesPriceTable(t *testing.T) {
+	rate, ok := installments.ParseMonthlyRate("2")
+	assert.True(t, ok)
+
+	first := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	schedule := installments.NewSchedule(money.MustParse("1000.00", "BRL"), 10, first, rate)
+
+	total := money.Zero("BRL")
+	for _, installment := range schedule[:9] {
+		assert.Equal(t, "111.33", installment.Amount.String())
+		total = total.Add(installment.Amount)
+	}
+	total = total.Add(schedule[9].Amount)
+	assert.Equal(t, "111.30", schedule[9].Amount.String())
+	assert.Equal(t, "1113.27", total.String())
+}
+
+func TestPayoffDiscountsFutureInterest(t *testing.T) {
+	rate, _ := installments.ParseMonthlyRate("2")
+	first := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	schedule := installments.NewSchedule(money.MustParse("1000.00", "BRL"), 10, first, rate)
+
+	payoff := installments.PayoffAmount(schedule, rate, "BRL")
+	assert.Equal(t, "1020.01", payoff.String())
+
+	noInterest := installments.NewSchedule(money.MustParse("1000.00", "BRL"), 4, first, nil)
+	assert.Equal(t, "1000.00", installments.PayoffAmount(noInterest, nil, "BRL").String())
+}
@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/installments"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInstallmentUseCase struct {
+	mock.Mock
+}
+
+func TestNewInstallmentHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.Default()
+	group := router.Group("/api")
+
+	mockUseCase := new(MockInstallmentUseCase)
+	installments.NewInstallmentHandler(group, mockUseCase)
+
+	routes := router.Routes()
+
+	expectedRoutes := []struct {
+		method string
+		path   string
+	}{
+		{"POST", "/api/installments/"},
+		{"GET", "/api/installments/"},
+		{"GET", "/api/installments/:id"},
+		{"POST", "/api/installments/:id/payoff"},
+		{"POST", "/api/installments/:id/cancel"},
+	}
+
+	for _, expected := range expectedRoutes {
+		found := false
+		for _, route := range routes {
+			if route.Method == expected.method && route.Path == expected.path {
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, "Route %s %s not registered", expected.method, expected.path)
+	}
+}
+
+func (m *MockInstallmentUseCase) CreatePlan(plan *installments.InstallmentPlan) error {
+	args := m.Called(plan)
+	return args.Error(0)
+}
+
+func (m *MockInstallmentUseCase) GetPlans(userID int64) ([]*installments.InstallmentPlan, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]*installments.InstallmentPlan), args.Error(1)
+}
+
+func (m *MockInstallmentUseCase) GetPlan(userID int64, id int64) (*installments.InstallmentPlan, error) {
+	args := m.Called(userID, id)
+	return args.Get(0).(*installments.InstallmentPlan), args.Error(1)
+}
+
+func (m *MockInstallmentUseCase) PayoffPlan(userID int64, id int64, date time.Time) (*installments.InstallmentPlan, error) {
+	args := m.Called(userID, id, date)
+	return args.Get(0).(*installments.InstallmentPlan), args.Error(1)
+}
+
+func (m *MockInstallmentUseCase) CancelPlan(userID int64, id int64) (*installments.InstallmentPlan, error) {
+	args := m.Called(userID, id)
+	return args.Get(0).(*installments.InstallmentPlan), args.Error(1)
+}
+
+func (m *MockInstallmentUseCase) PostDue(now time.Time) error {
+	args := m.Called(now)
+	return args.Error(0)
+}
@@ -0,0 +1,340 @@
+package journals
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/accounts"
+	"github.com/Renan-Parise/finances/internal/api/categories"
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/cache"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+type JournalUseCase interface {
+	GetJournal(userID int64, format string) (*Journal, error)
+	ImportBeancount(userID int64, file io.Reader, dryRun bool) (*ImportReport, error)
+}
+
+type journalUseCase struct {
+	journalRepo     JournalRepository
+	transactionRepo transactions.TransactionRepositories
+	accountRepo     accounts.AccountRepository
+	categoryRepo    categories.CategoryRepository
+	learner         transactions.Learner
+	cache           cache.Invalidator
+}
+
+func NewJournalUseCase(jr JournalRepository, tr transactions.TransactionRepositories, ar accounts.AccountRepository,
+	cr categories.CategoryRepository, ln transactions.Learner, ci cache.Invalidator) JournalUseCase {
+	return &journalUseCase{
+		journalRepo:     jr,
+		transactionRepo: tr,
+		accountRepo:     ar,
+		categoryRepo:    cr,
+		learner:         ln,
+		cache:           ci,
+	}
+}
+
+func (uc *journalUseCase) GetJournal(userID int64, format string) (*Journal, error) {
+	if format != FormatLedger && format != FormatBeancount {
+		return nil, errors.NewValidationError("format", "format must be ledger or beancount")
+	}
+
+	accountList, err := uc.accountRepo.GetAll(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := uc.accountRepo.GetBalances(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryList, err := uc.categoryRepo.GetAll(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionList, err := uc.transactionRepo.Filter(userID, &transactions.Filter{Field: "occurredAt", Order: "ASC"})
+	if err != nil {
+		return nil, err
+	}
+
+	journal := &Journal{
+		Accounts:     accountList,
+		Balances:     make(map[int64]money.Money, len(balances)),
+		Categories:   make(map[int]string, len(categoryList)),
+		Transactions: transactionList,
+		AsOf:         time.Now(),
+	}
+	for _, balance := range balances {
+		journal.Balances[balance.AccountID] = balance.Balance
+	}
+	for _, category := range categoryList {
+		journal.Categories[category.ID] = category.Name
+	}
+	return journal, nil
+}
+
+type importCandidate struct {
+	entry      *Entry
+	syncID     string
+	accountID  int64
+	amount     money.Money
+	categories []string
+	amounts    []money.Money
+}
+
+func (uc *journalUseCase) ImportBeancount(userID int64, file io.Reader, dryRun bool) (*ImportReport, error) {
+	entries, _, problems, err := ParseBeancount(file)
+	if err != nil {
+		return nil, err
+	}
+
+	accountList, err := uc.accountRepo.GetAll(userID)
+	if err != nil {
+		return nil, err
+	}
+	accountsByName := make(map[string]*accounts.Account, len(accountList))
+	for _, account := range accountList {
+		accountsByName[AccountName(account)] = account
+	}
+
+	categoryList, err := uc.categoryRepo.GetAll(userID)
+	if err != nil {
+		return nil, err
+	}
+	categoryIDs := make(map[string]int, len(categoryList))
+	for _, category := range categoryList {
+		categoryIDs[Component(category.Name)] = category.ID
+	}
+
+	report := &ImportReport{DryRun: dryRun, Entries: len(entries), Problems: problems}
+	fingerprints := make(map[string]int)
+	var candidates []*importCandidate
+	for _, entry := range entries {
+		candidate, err := toCandidate(entry, accountsByName)
+		if err != nil {
+			report.Problems = append(report.Problems, &Problem{Line: entry.Line, Message: err.Error()})
+			continue
+		}
+
+		if entry.SyncID != "" {
+			if id, err := strconv.ParseInt(entry.SyncID, 10, 64); err == nil {
+				existing, err := uc.transactionRepo.GetByID(userID, id)
+				if err != nil {
+					return nil, err
+				}
+				if existing != nil {
+					report.AlreadySynced++
+					continue
+				}
+			}
+			candidate.syncID = "id:" + entry.SyncID
+		} else {
+			key := entryKey(entry)
+			fingerprints[key]++
+			sum := sha1.Sum([]byte(fmt.Sprintf("%s|%d", key, fingerprints[key])))
+			candidate.syncID = "fp:" + hex.EncodeToString(sum[:])
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	syncIDs := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		syncIDs[i] = candidate.syncID
+	}
+	known, err := uc.journalRepo.GetKnownSyncIDs(userID, syncIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*importCandidate
+	for _, candidate := range candidates {
+		if known[candidate.syncID] {
+			report.AlreadySynced++
+			continue
+		}
+		pending = append(pending, candidate)
+
+		for _, name := range candidate.categories {
+			if _, ok := categoryIDs[Component(name)]; ok {
+				continue
+			}
+			categoryIDs[Component(name)] = 0
+			report.CategoriesCreated = append(report.CategoriesCreated, name)
+		}
+	}
+
+	if dryRun {
+		report.Imported = len(pending)
+		return report, nil
+	}
+
+	for _, name := range report.CategoriesCreated {
+		category := categories.NewCategory(userID, name)
+		if err := uc.categoryRepo.Create(category); err != nil {
+			return nil, err
+		}
+		categoryIDs[Component(name)] = category.ID
+	}
+
+	batch := make([]*transactions.Transaction, len(pending))
+	batchSyncIDs := make([]string, len(pending))
+	for i, candidate := range pending {
+		batch[i] = candidate.toTransaction(userID, categoryIDs)
+		batchSyncIDs[i] = candidate.syncID
+	}
+
+	if len(batch) > 0 {
+		imported, err := uc.journalRepo.ImportTransactions(userID, batch, batchSyncIDs)
+		if err != nil {
+			return nil, err
+		}
+		report.Imported = imported
+		report.AlreadySynced += len(batch) - imported
+	}
+
+	if report.Imported > 0 || len(report.CategoriesCreated) > 0 {
+		cache.InvalidateUserOrLog(uc.cache, userID)
+	}
+
+	var imported []*transactions.Transaction
+	for _, transaction := range batch {
+		if transaction.ID != 0 {
+			imported = append(imported, transaction)
+		}
+	}
+	transactions.Relearn(uc.learner, userID, nil, imported)
+	return report, nil
+}
+
+func toCandidate(entry *Entry, accountsByName map[string]*accounts.Account) (*importCandidate, error) {
+	if err := inferMissingAmount(entry); err != nil {
+		return nil, err
+	}
+
+	candidate := &importCandidate{entry: entry}
+	var assets []*Posting
+	currency := ""
+	for _, posting := range entry.Postings {
+		if currency == "" {
+			currency = posting.Amount.Currency
+		} else if posting.Amount.Currency != currency {
+			return nil, fmt.Errorf("entries mixing currencies are not imported")
+		}
+
+		root, rest, _ := strings.Cut(posting.Account, ":")
+		switch root {
+		case "Assets", "Liabilities":
+			assets = append(assets, posting)
+		case "Expenses", "Income":
+			candidate.categories = append(candidate.categories, strings.NewReplacer("-", " ", ":", " ").Replace(rest))
+			candidate.amounts = append(candidate.amounts, posting.Amount.Neg())
+		default:
+			return nil, fmt.Errorf("entries posting to %s are not imported", root)
+		}
+	}
+
+	if len(candidate.categories) == 0 {
+		return nil, fmt.Errorf("transfers between accounts are not imported")
+	}
+
+	if len(assets) != 1 {
+		return nil, fmt.Errorf("entry must post to exactly one Assets or Liabilities account")
+	}
+
+	candidate.amount = *assets[0].Amount
+	if candidate.amount.IsZero() {
+		return nil, fmt.Errorf("entry amount is zero")
+	}
+
+	for i, amount := range candidate.amounts {
+		if amount.IsNegative() != candidate.amount.IsNegative() {
+			return nil, fmt.Errorf("category posting %d has the wrong sign for this entry", i+1)
+		}
+	}
+
+	if account, ok := accountsByName[assets[0].Account]; ok {
+		if account.Currency != currency {
+			return nil, fmt.Errorf("currency %s does not match account %s", currency, assets[0].Account)
+		}
+		candidate.accountID = account.ID
+	} else if assets[0].Account != unassignedAccount {
+		return nil, fmt.Errorf("unknown account %s", assets[0].Account)
+	}
+	return candidate, nil
+}
+
+func inferMissingAmount(entry *Entry) error {
+	var missing *Posting
+	total := money.Money{}
+	for _, posting := range entry.Postings {
+		if posting.Amount == nil {
+			if missing != nil {
+				return fmt.Errorf("only one posting may leave out its amount")
+			}
+			missing = posting
+			continue
+		}
+		if total.Currency != "" && posting.Amount.Currency != total.Currency {
+			return fmt.Errorf("entries mixing currencies are not imported")
+		}
+		total = total.Add(*posting.Amount)
+	}
+
+	if missing != nil {
+		amount := total.Neg()
+		missing.Amount = &amount
+	} else if !total.IsZero() {
+		return fmt.Errorf("entry does not balance")
+	}
+
+	if len(entry.Postings) < 2 {
+		return fmt.Errorf("entry needs at least two postings")
+	}
+	return nil
+}
+
+func (c *importCandidate) toTransaction(userID int64, categoryIDs map[string]int) *transactions.Transaction {
+	description := c.entry.Narration
+	if description == "" {
+		description = c.categories[0]
+	}
+
+	transaction := transactions.NewTransaction(userID, c.accountID, description, categoryIDs[Component(c.categories[0])], c.amount)
+	transaction.OccurredAt = c.entry.Date
+	transaction.Currency = c.amount.Currency
+	if len(c.categories) == 1 {
+		return transaction
+	}
+
+	largest := money.Money{}
+	for i, name := range c.categories {
+		split := &transactions.Split{Category: categoryIDs[Component(name)], Amount: c.amounts[i]}
+		transaction.Splits = append(transaction.Splits, split)
+		if split.Amount.Abs().Cmp(largest) > 0 {
+			largest = split.Amount.Abs()
+			transaction.Category = split.Category
+		}
+	}
+	return transaction
+}
+
+func entryKey(entry *Entry) string {
+	postings := make([]string, len(entry.Postings))
+	for i, posting := range entry.Postings {
+		postings[i] = posting.Account + " " + posting.Amount.String() + " " + posting.Amount.Currency
+	}
+	sort.Strings(postings)
+	return entry.Date.Format("2006-01-02") + "|" + entry.Narration + "|" + strings.Join(postings, "|")
+}
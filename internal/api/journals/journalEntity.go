@@ -0,0 +1,56 @@
+package journals
+
+import (
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/accounts"
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+const (
+	FormatLedger    = "ledger"
+	FormatBeancount = "beancount"
+)
+
+// SyncKey is the metadata key carrying a transaction's ID through an export
+// and back, so importing a file this API produced never duplicates entries.
+const SyncKey = "finx-id"
+
+// Journal is everything a journal file is rendered from.
+type Journal struct {
+	Accounts     []*accounts.Account
+	Balances     map[int64]money.Money
+	Categories   map[int]string
+	Transactions []*transactions.Transaction
+	AsOf         time.Time
+}
+
+// Entry is a transaction read from a Beancount file.
+type Entry struct {
+	Line      int
+	Date      time.Time
+	Narration string
+	SyncID    string
+	Postings  []*Posting
+}
+
+// Posting is one leg of an entry.
+type Posting struct {
+	Account string
+	Amount  *money.Money
+}
+
+type Problem struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+type ImportReport struct {
+	DryRun            bool       `json:"dryRun"`
+	Entries           int        `json:"entries"`
+	Imported          int        `json:"imported"`
+	AlreadySynced     int        `json:"alreadySynced"`
+	CategoriesCreated []string   `json:"categoriesCreated"`
+	Problems          []*Problem `json:"problems"`
+}
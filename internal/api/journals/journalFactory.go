@@ -0,0 +1,369 @@
+package journals
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Renan-Parise/finances/internal/api/accounts"
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/utils"
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+const (
+	unassignedAccount = "Assets:Unassigned"
+	openingAccount    = "Equity:Opening-Balances"
+	uncategorized     = "Uncategorized"
+)
+
+var accountTypeNames = map[string]string{
+	accounts.TypeChecking:   "Assets:Checking",
+	accounts.TypeSavings:    "Assets:Savings",
+	accounts.TypeCreditCard: "Liabilities:CreditCard",
+	accounts.TypeCash:       "Assets:Cash",
+	accounts.TypeInvestment: "Assets:Investment",
+}
+
+// AccountName maps an API account to a journal account such as
+// Assets:Checking:Nubank.
+func AccountName(account *accounts.Account) string {
+	prefix, ok := accountTypeNames[account.Type]
+	if !ok {
+		prefix = "Assets:Other"
+	}
+	return prefix + ":" + Component(account.Name)
+}
+
+// CategoryAccount maps a category to Expenses:<Name> for money going out and
+// Income:<Name> for money coming in.
+func CategoryAccount(name string, amount money.Money) string {
+	if name == "" {
+		name = uncategorized
+	}
+	if amount.IsPositive() {
+		return "Income:" + Component(name)
+	}
+	return "Expenses:" + Component(name)
+}
+
+// Component turns a free-form name into a valid account component.
+func Component(name string) string {
+	words := strings.FieldsFunc(utils.RemoveAccents(name), func(r rune) bool {
+		return r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r))
+	})
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	if len(words) == 0 {
+		return "Unnamed"
+	}
+	return strings.Join(words, "-")
+}
+
+type journalPosting struct {
+	account string
+	amount  money.Money
+}
+
+type journalTransaction struct {
+	date        time.Time
+	description string
+	syncID      string
+	postings    []journalPosting
+}
+
+// Render writes the journal in Ledger (also read by hledger) or Beancount
+// syntax.
+func Render(w io.Writer, format string, journal *Journal) error {
+	if format != FormatLedger && format != FormatBeancount {
+		return errors.NewValidationError("format", "format must be ledger or beancount")
+	}
+
+	names := make(map[int64]string, len(journal.Accounts))
+	currencies := make(map[string]string)
+	for _, account := range journal.Accounts {
+		names[account.ID] = AccountName(account)
+		currencies[names[account.ID]] = account.Currency
+	}
+
+	entries := buildEntries(journal, names)
+	start := journal.AsOf
+	used := map[string]bool{}
+	for _, entry := range entries {
+		if entry.date.Before(start) {
+			start = entry.date
+		}
+		for _, posting := range entry.postings {
+			used[posting.account] = true
+		}
+	}
+	for _, account := range journal.Accounts {
+		used[names[account.ID]] = true
+		if account.CreatedAt.Before(start) {
+			start = account.CreatedAt
+		}
+	}
+
+	opened := make([]string, 0, len(used))
+	for account := range used {
+		opened = append(opened, account)
+	}
+	sort.Strings(opened)
+
+	out := bufio.NewWriter(w)
+	if format == FormatBeancount {
+		renderBeancount(out, journal, names, currencies, opened, start, entries)
+	} else {
+		renderLedger(out, journal, names, opened, entries)
+	}
+	return out.Flush()
+}
+
+func buildEntries(journal *Journal, names map[int64]string) []*journalTransaction {
+	var entries []*journalTransaction
+	for _, account := range journal.Accounts {
+		if account.OpeningBalance.IsZero() {
+			continue
+		}
+		opening := account.OpeningBalance
+		opening.Currency = account.Currency
+		entries = append(entries, &journalTransaction{
+			date:        account.CreatedAt,
+			description: "Opening balance",
+			postings: []journalPosting{
+				{names[account.ID], opening},
+				{openingAccount, opening.Neg()},
+			},
+		})
+	}
+
+	accountOf := func(transaction *transactions.Transaction) string {
+		if name, ok := names[transaction.AccountID]; ok {
+			return name
+		}
+		return unassignedAccount
+	}
+
+	transfers := make(map[int64]*journalTransaction)
+	for _, transaction := range journal.Transactions {
+		if transaction.TransferID != 0 {
+			if entry, ok := transfers[transaction.TransferID]; ok {
+				entry.postings = append(entry.postings, journalPosting{accountOf(transaction), transaction.Amount})
+				continue
+			}
+			entry := &journalTransaction{
+				date:        transaction.OccurredAt,
+				description: transaction.Description,
+				postings:    []journalPosting{{accountOf(transaction), transaction.Amount}},
+			}
+			transfers[transaction.TransferID] = entry
+			entries = append(entries, entry)
+			continue
+		}
+
+		entry := &journalTransaction{
+			date:        transaction.OccurredAt,
+			description: transaction.Description,
+			syncID:      fmt.Sprint(transaction.ID),
+			postings:    []journalPosting{{accountOf(transaction), transaction.Amount}},
+		}
+		if len(transaction.Splits) == 0 {
+			entry.postings = append(entry.postings, journalPosting{
+				CategoryAccount(journal.Categories[transaction.Category], transaction.Amount), transaction.Amount.Neg(),
+			})
+		}
+		for _, split := range transaction.Splits {
+			entry.postings = append(entry.postings, journalPosting{
+				CategoryAccount(journal.Categories[split.Category], split.Amount), split.Amount.Neg(),
+			})
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].date.Format("2006-01-02") < entries[j].date.Format("2006-01-02")
+	})
+	return entries
+}
+
+func renderBeancount(out *bufio.Writer, journal *Journal, names map[int64]string, currencies map[string]string,
+	opened []string, start time.Time, entries []*journalTransaction) {
+	fmt.Fprintf(out, "; Exported on %s\n\n", journal.AsOf.Format("2006-01-02"))
+	for _, account := range opened {
+		if currency := currencies[account]; currency != "" {
+			fmt.Fprintf(out, "%s open %s %s\n", start.Format("2006-01-02"), account, currency)
+		} else {
+			fmt.Fprintf(out, "%s open %s\n", start.Format("2006-01-02"), account)
+		}
+	}
+
+	for _, entry := range entries {
+		fmt.Fprintf(out, "\n%s * %s\n", entry.date.Format("2006-01-02"), quoteBeancount(entry.description))
+		if entry.syncID != "" {
+			fmt.Fprintf(out, "  %s: %s\n", SyncKey, quoteBeancount(entry.syncID))
+		}
+		for _, posting := range entry.postings {
+			fmt.Fprintf(out, "  %-40s %12s %s\n", posting.account, posting.amount.String(), posting.amount.Currency)
+		}
+	}
+
+	if len(journal.Accounts) > 0 {
+		out.WriteString("\n")
+	}
+	for _, account := range journal.Accounts {
+		balance := journal.Balances[account.ID]
+		fmt.Fprintf(out, "%s balance %s %s %s\n", journal.AsOf.AddDate(0, 0, 1).Format("2006-01-02"),
+			names[account.ID], balance.String(), account.Currency)
+	}
+}
+
+func renderLedger(out *bufio.Writer, journal *Journal, names map[int64]string, opened []string, entries []*journalTransaction) {
+	fmt.Fprintf(out, "; Exported on %s\n\n", journal.AsOf.Format("2006-01-02"))
+	for _, account := range opened {
+		fmt.Fprintf(out, "account %s\n", account)
+	}
+
+	for _, entry := range entries {
+		fmt.Fprintf(out, "\n%s * %s\n", entry.date.Format("2006/01/02"), strings.ReplaceAll(entry.description, "\n", " "))
+		if entry.syncID != "" {
+			fmt.Fprintf(out, "    ; %s: %s\n", SyncKey, entry.syncID)
+		}
+		for _, posting := range entry.postings {
+			fmt.Fprintf(out, "    %-40s  %s %s\n", posting.account, posting.amount.String(), posting.amount.Currency)
+		}
+	}
+
+	for _, account := range journal.Accounts {
+		balance := journal.Balances[account.ID]
+		fmt.Fprintf(out, "\n%s * Balance assertion\n    %-40s  0 %s = %s %s\n", journal.AsOf.Format("2006/01/02"),
+			names[account.ID], account.Currency, balance.String(), account.Currency)
+	}
+}
+
+func quoteBeancount(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + strings.ReplaceAll(value, "\n", " ") + `"`
+}
+
+var (
+	beancountDirective = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})\s+(\S+)\s*(.*)$`)
+	beancountString    = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"`)
+	beancountMetadata  = regexp.MustCompile(`^([a-z][a-zA-Z0-9_-]*):\s*(.*)$`)
+)
+
+// ParseBeancount reads the transactions of a Beancount file along with the
+// accounts it opens.
+func ParseBeancount(r io.Reader) ([]*Entry, []string, []*Problem, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var entries []*Entry
+	var opened []string
+	var problems []*Problem
+	var current *Entry
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" || strings.HasPrefix(trimmed, ";") || strings.HasPrefix(trimmed, "*") {
+			continue
+		}
+
+		if text[0] == ' ' || text[0] == '\t' {
+			if current == nil {
+				continue
+			}
+			if err := parseEntryLine(current, stripComment(trimmed)); err != nil {
+				problems = append(problems, &Problem{Line: line, Message: err.Error()})
+			}
+			continue
+		}
+
+		current = nil
+		match := beancountDirective.FindStringSubmatch(stripComment(text))
+		if match == nil {
+			continue
+		}
+
+		date, err := time.Parse("2006-01-02", match[1])
+		if err != nil {
+			problems = append(problems, &Problem{Line: line, Message: "invalid date " + match[1]})
+			continue
+		}
+
+		switch directive := match[2]; directive {
+		case "open":
+			if fields := strings.Fields(match[3]); len(fields) > 0 {
+				opened = append(opened, fields[0])
+			}
+		case "*", "!", "txn":
+			var texts []string
+			for _, quoted := range beancountString.FindAllStringSubmatch(match[3], -1) {
+				texts = append(texts, unquoteBeancount(quoted[1]))
+			}
+			current = &Entry{Line: line, Date: date, Narration: strings.Join(texts, " - ")}
+			entries = append(entries, current)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, nil, errors.NewValidationError("file", "could not read Beancount file: "+err.Error())
+	}
+	return entries, opened, problems, nil
+}
+
+func parseEntryLine(entry *Entry, text string) error {
+	if match := beancountMetadata.FindStringSubmatch(text); match != nil {
+		if match[1] == SyncKey {
+			entry.SyncID = strings.Trim(strings.TrimSpace(match[2]), `"`)
+		}
+		return nil
+	}
+
+	fields := strings.Fields(text)
+	if len(fields) > 0 && (fields[0] == "*" || fields[0] == "!") {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	posting := &Posting{Account: fields[0]}
+	if len(fields) >= 3 {
+		amount, err := money.Parse(strings.ReplaceAll(fields[1], ",", ""), fields[2])
+		if err != nil {
+			return fmt.Errorf("invalid amount %s on posting to %s", fields[1], fields[0])
+		}
+		posting.Amount = &amount
+	} else if len(fields) == 2 {
+		return fmt.Errorf("posting to %s has an amount without a currency", fields[0])
+	}
+	entry.Postings = append(entry.Postings, posting)
+	return nil
+}
+
+func stripComment(text string) string {
+	inString := false
+	for i, r := range text {
+		switch {
+		case r == '"' && (i == 0 || text[i-1] != '\\'):
+			inString = !inString
+		case r == ';' && !inString:
+			return strings.TrimSpace(text[:i])
+		}
+	}
+	return text
+}
+
+func unquoteBeancount(value string) string {
+	value = strings.ReplaceAll(value, `\"`, `"`)
+	return strings.ReplaceAll(value, `\\`, `\`)
+}
@@ -0,0 +1,108 @@
+package journals
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JournalHandler struct {
+	journalUseCase JournalUseCase
+}
+
+func NewJournalHandler(router *gin.RouterGroup, ju JournalUseCase) {
+	handler := &JournalHandler{
+		journalUseCase: ju,
+	}
+
+	journals := router.Group("/journals")
+	journals.Use(middlewares.JWTAuthMiddleware())
+	{
+		journals.GET("/export", handler.ExportJournal)
+		journals.POST("/import", handler.ImportBeancount)
+	}
+}
+
+func (h *JournalHandler) ExportJournal(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	format := c.DefaultQuery("format", FormatBeancount)
+	journal, err := h.journalUseCase.GetJournal(userID.(int64), format)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	extension := "beancount"
+	if format == FormatLedger {
+		extension = "ledger"
+	}
+
+	c.Header("Content-Type", "text/plain; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="finances-`+time.Now().Format("20060102")+"."+extension+`"`)
+	c.Status(http.StatusOK)
+
+	if err := Render(c.Writer, format, journal); err != nil {
+		log.Printf("Failed to render journal for user %d: %v", userID.(int64), err)
+	}
+}
+
+func (h *JournalHandler) ImportBeancount(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	dryRun := false
+	if value := c.PostForm("dryRun"); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "dryRun must be true or false"})
+			return
+		}
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A Beancount file is required in the 'file' field"})
+		return
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer reader.Close()
+
+	report, err := h.journalUseCase.ImportBeancount(userID.(int64), reader, dryRun)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	status := http.StatusCreated
+	if dryRun {
+		status = http.StatusOK
+	}
+	c.JSON(status, report)
+}
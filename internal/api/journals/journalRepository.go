@@ -0,0 +1,110 @@
+package journals
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/ledger"
+)
+
+type JournalRepository interface {
+	GetKnownSyncIDs(userID int64, syncIDs []string) (map[string]bool, error)
+	ImportTransactions(userID int64, batch []*transactions.Transaction, syncIDs []string) (int, error)
+}
+
+type journalRepository struct {
+	db *sql.DB
+}
+
+func NewJournalRepository(db *sql.DB) JournalRepository {
+	return &journalRepository{db: db}
+}
+
+func (r *journalRepository) GetKnownSyncIDs(userID int64, syncIDs []string) (map[string]bool, error) {
+	known := make(map[string]bool)
+	if len(syncIDs) == 0 {
+		return known, nil
+	}
+
+	placeholders := make([]string, len(syncIDs))
+	args := []interface{}{userID}
+	for i, syncID := range syncIDs {
+		placeholders[i] = "?"
+		args = append(args, syncID)
+	}
+
+	query := `SELECT syncId FROM journal_sync WHERE userId = ? AND syncId IN (` + strings.Join(placeholders, ", ") + `)`
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var syncID string
+		if err := rows.Scan(&syncID); err != nil {
+			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		known[syncID] = true
+	}
+	return known, nil
+}
+
+func (r *journalRepository) ImportTransactions(userID int64, batch []*transactions.Transaction, syncIDs []string) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, errors.NewDatabaseError("error starting transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	ids := make([]int64, len(batch))
+	imported := 0
+	for i, transaction := range batch {
+		res, err := tx.Exec(`INSERT IGNORE INTO journal_sync (userId, syncId, createdAt) VALUES (?, ?, ?)`,
+			userID, syncIDs[i], now)
+		if err != nil {
+			return 0, errors.NewQueryError("error claiming sync ID: " + err.Error())
+		}
+
+		claimed, err := res.RowsAffected()
+		if err != nil {
+			return 0, errors.NewQueryError("error claiming sync ID: " + err.Error())
+		}
+		if claimed == 0 {
+			continue
+		}
+
+		entry := transaction.LedgerEntry()
+		entry.UserID = userID
+		if ids[i], err = ledger.Insert(tx, entry); err != nil {
+			return 0, err
+		}
+
+		for _, split := range transaction.Splits {
+			_, err := tx.Exec(`INSERT INTO transaction_splits (transactionId, category, amount, note) VALUES (?, ?, ?, ?)`,
+				ids[i], split.Category, split.Amount, split.Note)
+			if err != nil {
+				return 0, errors.NewQueryError("error inserting split: " + err.Error())
+			}
+		}
+
+		_, err = tx.Exec(`UPDATE journal_sync SET transactionId = ? WHERE userId = ? AND syncId = ?`, ids[i], userID, syncIDs[i])
+		if err != nil {
+			return 0, errors.NewQueryError("error linking sync ID: " + err.Error())
+		}
+		imported++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+
+	for i, transaction := range batch {
+		transaction.ID = ids[i]
+	}
+	return imported, nil
+}
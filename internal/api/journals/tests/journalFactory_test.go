@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/accounts"
+	"github.com/Renan-Parise/finances/internal/api/journals"
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleJournal() *journals.Journal {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 12, 0, 0, 0, time.UTC) }
+	brl := func(v string) money.Money { return money.MustParse(v, "BRL") }
+
+	return &journals.Journal{
+		Accounts: []*accounts.Account{
+			{ID: 1, Name: "Nubank", Type: accounts.TypeChecking, Currency: "BRL", OpeningBalance: brl("100"), CreatedAt: day(1)},
+			{ID: 2, Name: "Cartão Visa", Type: accounts.TypeCreditCard, Currency: "BRL", CreatedAt: day(1)},
+		},
+		Balances:   map[int64]money.Money{1: brl("3050.00"), 2: brl("-80.00")},
+		Categories: map[int]string{1: "Alimentação", 2: "Salary", 3: "Shopping"},
+		Transactions: []*transactions.Transaction{
+			{ID: 10, AccountID: 1, OccurredAt: day(2), Description: `Padaria "Central"`, Category: 1, Amount: brl("-50.00"), Currency: "BRL"},
+			{ID: 11, AccountID: 1, OccurredAt: day(5), Description: "Salário", Category: 2, Amount: brl("3000.00"), Currency: "BRL"},
+			{ID: 12, AccountID: 2, OccurredAt: day(6), Description: "Supermarket", Category: 1, Amount: brl("-80.00"), Currency: "BRL",
+				Splits: []*transactions.Split{
+					{Category: 1, Amount: brl("-60.00")},
+					{Category: 3, Amount: brl("-20.00")},
+				}},
+		},
+		AsOf: day(31),
+	}
+}
+
+func TestComponent(t *testing.T) {
+	assert.Equal(t, "Alimentacao-E-Bebidas", journals.Component("alimentação e bebidas"))
+	assert.Equal(t, "Food-Drinks", journals.Component("Food & Drinks"))
+	assert.Equal(t, "Unnamed", journals.Component("  "))
+}
+
+func TestRenderBeancount(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, journals.Render(&out, journals.FormatBeancount, sampleJournal()))
+	journal := out.String()
+
+	assert.Contains(t, journal, "2026-03-01 open Assets:Checking:Nubank BRL\n")
+	assert.Contains(t, journal, "2026-03-01 open Liabilities:CreditCard:Cartao-Visa BRL\n")
+	assert.Contains(t, journal, "2026-03-01 open Expenses:Alimentacao\n")
+	assert.Contains(t, journal, "2026-03-01 open Income:Salary\n")
+	assert.Contains(t, journal, `2026-03-02 * "Padaria \"Central\""`)
+	assert.Contains(t, journal, `  finx-id: "10"`)
+	assert.Contains(t, journal, "2026-04-01 balance Assets:Checking:Nubank 3050.00 BRL\n")
+}
+
+func TestRenderLedger(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, journals.Render(&out, journals.FormatLedger, sampleJournal()))
+	journal := out.String()
+
+	assert.Contains(t, journal, "account Expenses:Shopping\n")
+	assert.Contains(t, journal, "2026/03/05 * Salário\n    ; finx-id: 11\n")
+	assert.Contains(t, journal, "= 3050.00 BRL")
+}
+
+func TestParseBeancountReadsRenderedJournal(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, journals.Render(&out, journals.FormatBeancount, sampleJournal()))
+
+	entries, opened, problems, err := journals.ParseBeancount(&out)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+	assert.Contains(t, opened, "Equity:Opening-Balances")
+	require.Len(t, entries, 4)
+
+	padaria := entries[1]
+	assert.Equal(t, `Padaria "Central"`, padaria.Narration)
+	assert.Equal(t, "10", padaria.SyncID)
+	require.Len(t, padaria.Postings, 2)
+	assert.Equal(t, "Expenses:Alimentacao", padaria.Postings[1].Account)
+	assert.Equal(t, money.MustParse("50.00", "BRL"), *padaria.Postings[1].Amount)
+	assert.Len(t, entries[3].Postings, 3)
+}
+
+func TestParseBeancountHandWrittenEntry(t *testing.T) {
+	file := `option "title" "Mine"
+2026-01-01 open Assets:Checking:Nubank BRL
+
+2026-01-15 * "Uber" "ride home" #work ; a comment
+  Expenses:Transport        23.90 BRL
+  Assets:Checking:Nubank
+
+2026-01-16 balance Assets:Checking:Nubank 100 BRL
+`
+	entries, _, problems, err := journals.ParseBeancount(strings.NewReader(file))
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "Uber - ride home", entries[0].Narration)
+	assert.Nil(t, entries[0].Postings[1].Amount)
+}
@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"io"
+	"testing"
+
+	"github.com/Renan-Parise/finances/internal/api/journals"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockJournalUseCase struct {
+	mock.Mock
+}
+
+func TestNewJournalHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.Default()
+	group := router.Group("/api")
+
+	mockUseCase := new(MockJournalUseCase)
+	journals.NewJournalHandler(group, mockUseCase)
+
+	routes := router.Routes()
+
+	expectedRoutes := []struct {
+		method string
+		path   string
+	}{
+		{"GET", "/api/journals/export"},
+		{"POST", "/api/journals/import"},
+	}
+
+	for _, expected := range expectedRoutes {
+		found := false
+		for _, route := range routes {
+			if route.Method == expected.method && route.Path == expected.path {
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, "Route %s %s not registered", expected.method, expected.path)
+	}
+}
+
+func (m *MockJournalUseCase) GetJournal(userID int64, format string) (*journals.Journal, error) {
+	args := m.Called(userID, format)
+	return args.Get(0).(*journals.Journal), args.Error(1)
+}
+
+func (m *MockJournalUseCase) ImportBeancount(userID int64, file io.Reader, dryRun bool) (*journals.ImportReport, error) {
+	args := m.Called(userID, file, dryRun)
+	return args.Get(0).(*journals.ImportReport), args.Error(1)
+}
@@ -0,0 +1,151 @@
+package reconciliations
+
+import (
+	"github.com/Renan-Parise/finances/internal/api/accounts"
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/errors"
+)
+
+type ReconciliationUseCase interface {
+	StartReconciliation(reconciliation *Reconciliation) (*Report, error)
+	GetReconciliations(userID int64, accountID int64) ([]*Reconciliation, error)
+	GetReconciliation(userID int64, id int64) (*Report, error)
+	FinishReconciliation(userID int64, id int64) (*Report, error)
+	DeleteReconciliation(userID int64, id int64) error
+}
+
+type reconciliationUseCase struct {
+	reconciliationRepo ReconciliationRepository
+	transactionRepo    transactions.TransactionRepositories
+	accountRepo        accounts.AccountRepository
+}
+
+func NewReconciliationUseCase(rr ReconciliationRepository, tr transactions.TransactionRepositories,
+	ar accounts.AccountRepository) ReconciliationUseCase {
+	return &reconciliationUseCase{
+		reconciliationRepo: rr,
+		transactionRepo:    tr,
+		accountRepo:        ar,
+	}
+}
+
+func (uc *reconciliationUseCase) StartReconciliation(reconciliation *Reconciliation) (*Report, error) {
+	account, err := uc.getAccount(reconciliation.UserID, reconciliation.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.reconciliationRepo.GetAll(reconciliation.UserID, reconciliation.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, other := range existing {
+		if other.Status == StatusOpen {
+			return nil, errors.NewValidationError("accountId", "account already has an open reconciliation; finish or delete it first")
+		}
+
+		if reconciliation.StatementDate.Before(other.StatementDate) {
+			return nil, errors.NewValidationError("statementDate",
+				"statement date must not be before the last reconciled statement on "+other.StatementDate.Format("2006-01-02"))
+		}
+	}
+
+	reconciliation.Currency = account.Currency
+	reconciliation.StatementBalance.Currency = account.Currency
+	if err := uc.reconciliationRepo.Create(reconciliation); err != nil {
+		return nil, err
+	}
+	return uc.report(reconciliation, account)
+}
+
+func (uc *reconciliationUseCase) GetReconciliations(userID int64, accountID int64) ([]*Reconciliation, error) {
+	return uc.reconciliationRepo.GetAll(userID, accountID)
+}
+
+func (uc *reconciliationUseCase) GetReconciliation(userID int64, id int64) (*Report, error) {
+	reconciliation, err := uc.getReconciliation(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := uc.getAccount(userID, reconciliation.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.report(reconciliation, account)
+}
+
+func (uc *reconciliationUseCase) FinishReconciliation(userID int64, id int64) (*Report, error) {
+	report, err := uc.GetReconciliation(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.Status != StatusOpen {
+		return nil, errors.NewValidationError("id", "reconciliation is already finished")
+	}
+
+	if !report.Difference.IsZero() {
+		return nil, errors.NewValidationError("id", "cleared balance differs from the statement by "+report.Difference.String())
+	}
+
+	finished, err := uc.reconciliationRepo.Finish(report.Reconciliation, report.ClearedIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	if !finished {
+		return nil, errors.NewValidationError("id", "transactions changed while reconciling; review the reconciliation again")
+	}
+	return uc.GetReconciliation(userID, id)
+}
+
+func (uc *reconciliationUseCase) DeleteReconciliation(userID int64, id int64) error {
+	reconciliation, err := uc.getReconciliation(userID, id)
+	if err != nil {
+		return err
+	}
+
+	if reconciliation.Status != StatusOpen {
+		return errors.NewValidationError("id", "only open reconciliations can be deleted")
+	}
+	return uc.reconciliationRepo.Delete(userID, id)
+}
+
+func (uc *reconciliationUseCase) report(reconciliation *Reconciliation, account *accounts.Account) (*Report, error) {
+	history, err := uc.transactionRepo.Filter(reconciliation.UserID, &transactions.Filter{
+		AccountID: reconciliation.AccountID,
+		To:        reconciliation.StatementDate.Format("2006-01-02"),
+		Field:     "occurredAt",
+		Order:     "ASC",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewReport(reconciliation, account.OpeningBalance, history), nil
+}
+
+func (uc *reconciliationUseCase) getReconciliation(userID int64, id int64) (*Reconciliation, error) {
+	reconciliation, err := uc.reconciliationRepo.GetByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if reconciliation == nil {
+		return nil, errors.NewValidationError("id", "reconciliation not found")
+	}
+	return reconciliation, nil
+}
+
+func (uc *reconciliationUseCase) getAccount(userID int64, accountID int64) (*accounts.Account, error) {
+	account, err := uc.accountRepo.GetByID(userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if account == nil {
+		return nil, errors.NewValidationError("accountId", "account not found")
+	}
+	return account, nil
+}
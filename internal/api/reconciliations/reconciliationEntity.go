@@ -0,0 +1,35 @@
+package reconciliations
+
+import (
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+const (
+	StatusOpen     = "open"
+	StatusFinished = "finished"
+)
+
+// Reconciliation is a session in which the user checks an account against a
+// bank statement closing on StatementDate with StatementBalance.
+type Reconciliation struct {
+	ID               int64       `json:"id"`
+	UserID           int64       `json:"userId"`
+	AccountID        int64       `json:"accountId"`
+	StatementDate    time.Time   `json:"statementDate"`
+	StatementBalance money.Money `json:"statementBalance"`
+	Currency         string      `json:"currency"`
+	Status           string      `json:"status"`
+	CreatedAt        time.Time   `json:"createdAt"`
+	FinishedAt       *time.Time  `json:"finishedAt,omitempty"`
+}
+
+// Report compares a reconciliation with the account.
+type Report struct {
+	*Reconciliation
+	ClearedBalance money.Money                 `json:"clearedBalance"`
+	Difference     money.Money                 `json:"difference"`
+	Transactions   []*transactions.Transaction `json:"transactions"`
+}
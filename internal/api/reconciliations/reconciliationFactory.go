@@ -0,0 +1,55 @@
+package reconciliations
+
+import (
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+func NewReconciliation(userID, accountID int64, statementDate time.Time, statementBalance money.Money) *Reconciliation {
+	return &Reconciliation{
+		UserID:           userID,
+		AccountID:        accountID,
+		StatementDate:    time.Date(statementDate.Year(), statementDate.Month(), statementDate.Day(), 0, 0, 0, 0, time.UTC),
+		StatementBalance: statementBalance,
+		Status:           StatusOpen,
+		CreatedAt:        time.Now(),
+	}
+}
+
+// NewReport balances history, the account's transactions up to the statement
+// date, against the statement.
+func NewReport(reconciliation *Reconciliation, openingBalance money.Money, history []*transactions.Transaction) *Report {
+	cleared := money.New(openingBalance.Amount, reconciliation.Currency)
+	listed := []*transactions.Transaction{}
+	for _, transaction := range history {
+		if transaction.Status != transactions.StatusPending {
+			cleared.Amount += transaction.Amount.Amount
+		}
+
+		if reconciliation.Status == StatusOpen && transaction.Status != transactions.StatusReconciled ||
+			reconciliation.Status == StatusFinished && transaction.ReconciliationID == reconciliation.ID {
+			listed = append(listed, transaction)
+		}
+	}
+
+	return &Report{
+		Reconciliation: reconciliation,
+		ClearedBalance: cleared,
+		Difference:     reconciliation.StatementBalance.Sub(cleared),
+		Transactions:   listed,
+	}
+}
+
+// ClearedIDs are the transactions finishing the reconciliation will mark as
+// reconciled and lock.
+func (r *Report) ClearedIDs() []int64 {
+	var ids []int64
+	for _, transaction := range r.Transactions {
+		if transaction.Status == transactions.StatusCleared {
+			ids = append(ids, transaction.ID)
+		}
+	}
+	return ids
+}
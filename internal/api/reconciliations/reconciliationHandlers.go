@@ -0,0 +1,175 @@
+package reconciliations
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/middlewares"
+	"github.com/Renan-Parise/finances/pkg/money"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReconciliationHandler struct {
+	reconciliationUseCase ReconciliationUseCase
+}
+
+func NewReconciliationHandler(router *gin.RouterGroup, ru ReconciliationUseCase) {
+	handler := &ReconciliationHandler{
+		reconciliationUseCase: ru,
+	}
+
+	reconciliations := router.Group("/reconciliations")
+	reconciliations.Use(middlewares.JWTAuthMiddleware())
+	{
+		reconciliations.POST("/", handler.StartReconciliation)
+		reconciliations.GET("/", handler.GetReconciliations)
+		reconciliations.GET("/:id", handler.GetReconciliation)
+		reconciliations.POST("/:id/finish", handler.FinishReconciliation)
+		reconciliations.DELETE("/:id", handler.DeleteReconciliation)
+	}
+}
+
+func (h *ReconciliationHandler) StartReconciliation(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	var input struct {
+		AccountID        int64       `json:"accountId" binding:"required"`
+		StatementDate    string      `json:"statementDate" binding:"required"`
+		StatementBalance money.Money `json:"statementBalance"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	statementDate, err := time.Parse("2006-01-02", input.StatementDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid statement date, expected YYYY-MM-DD"})
+		return
+	}
+
+	reconciliation := NewReconciliation(userID.(int64), input.AccountID, statementDate, input.StatementBalance)
+
+	report, err := h.reconciliationUseCase.StartReconciliation(reconciliation)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+func (h *ReconciliationHandler) GetReconciliations(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	var accountID int64
+	if value := c.Query("accountId"); value != "" {
+		var err error
+		if accountID, err = strconv.ParseInt(value, 10, 64); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+			return
+		}
+	}
+
+	reconciliations, err := h.reconciliationUseCase.GetReconciliations(userID.(int64), accountID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reconciliations)
+}
+
+func (h *ReconciliationHandler) GetReconciliation(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reconciliation ID"})
+		return
+	}
+
+	report, err := h.reconciliationUseCase.GetReconciliation(userID.(int64), id)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *ReconciliationHandler) FinishReconciliation(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reconciliation ID"})
+		return
+	}
+
+	report, err := h.reconciliationUseCase.FinishReconciliation(userID.(int64), id)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *ReconciliationHandler) DeleteReconciliation(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reconciliation ID"})
+		return
+	}
+
+	err = h.reconciliationUseCase.DeleteReconciliation(userID.(int64), id)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Reconciliation deleted successfully"})
+}
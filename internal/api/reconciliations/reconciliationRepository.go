@@ -0,0 +1,184 @@
+package reconciliations
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+type ReconciliationRepository interface {
+	Create(reconciliation *Reconciliation) error
+	GetAll(userID int64, accountID int64) ([]*Reconciliation, error)
+	GetByID(userID int64, id int64) (*Reconciliation, error)
+	Finish(reconciliation *Reconciliation, ids []int64) (bool, error)
+	Delete(userID int64, id int64) error
+}
+
+const reconciliationColumns = `r.id, r.userId, r.accountId, r.statementDate, r.statementBalance, a.currency, r.status,
+              r.createdAt, r.finishedAt`
+
+type reconciliationRepository struct {
+	db *sql.DB
+}
+
+func NewReconciliationRepository(db *sql.DB) ReconciliationRepository {
+	return &reconciliationRepository{db: db}
+}
+
+func (r *reconciliationRepository) Create(reconciliation *Reconciliation) error {
+	query := `INSERT INTO reconciliations (userId, accountId, statementDate, statementBalance, status, createdAt)
+              VALUES (?, ?, ?, ?, ?, ?)`
+	res, err := r.db.Exec(query, reconciliation.UserID, reconciliation.AccountID, reconciliation.StatementDate,
+		reconciliation.StatementBalance, reconciliation.Status, reconciliation.CreatedAt)
+	if err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+
+	if reconciliation.ID, err = res.LastInsertId(); err != nil {
+		return errors.NewQueryError("error getting last insert ID: " + err.Error())
+	}
+	return nil
+}
+
+func (r *reconciliationRepository) GetAll(userID int64, accountID int64) ([]*Reconciliation, error) {
+	query := `SELECT ` + reconciliationColumns + `
+              FROM reconciliations r
+              JOIN accounts a ON a.id = r.accountId
+              WHERE r.userId = ? AND (? = 0 OR r.accountId = ?)
+              ORDER BY r.statementDate DESC, r.id DESC`
+	rows, err := r.db.Query(query, userID, accountID, accountID)
+	if err != nil {
+		return nil, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	var reconciliations []*Reconciliation
+	for rows.Next() {
+		reconciliation, err := scanReconciliation(rows)
+		if err != nil {
+			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		reconciliations = append(reconciliations, reconciliation)
+	}
+	return reconciliations, nil
+}
+
+func (r *reconciliationRepository) GetByID(userID int64, id int64) (*Reconciliation, error) {
+	query := `SELECT ` + reconciliationColumns + `
+              FROM reconciliations r
+              JOIN accounts a ON a.id = r.accountId
+              WHERE r.id = ? AND r.userId = ?`
+	reconciliation, err := scanReconciliation(r.db.QueryRow(query, id, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.NewQueryError("error scanning row: " + err.Error())
+	}
+	return reconciliation, nil
+}
+
+func (r *reconciliationRepository) Finish(reconciliation *Reconciliation, ids []int64) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, errors.NewDatabaseError("error starting transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	cleared := money.Money{Currency: reconciliation.Currency}
+	err = tx.QueryRow(`SELECT openingBalance FROM accounts WHERE id = ? AND userId = ? FOR UPDATE`,
+		reconciliation.AccountID, reconciliation.UserID).Scan(&cleared)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.NewQueryError("error reading account: " + err.Error())
+	}
+
+	sum := money.Money{Currency: reconciliation.Currency}
+	err = tx.QueryRow(`SELECT COALESCE(SUM(amount), 0) FROM transactions
+              WHERE userId = ? AND accountId = ? AND status <> ? AND occurredAt < DATE_ADD(?, INTERVAL 1 DAY)
+              FOR UPDATE`,
+		reconciliation.UserID, reconciliation.AccountID, transactions.StatusPending,
+		reconciliation.StatementDate.Format("2006-01-02")).Scan(&sum)
+	if err != nil {
+		return false, errors.NewQueryError("error summing cleared transactions: " + err.Error())
+	}
+
+	if cleared.Add(sum).Cmp(reconciliation.StatementBalance) != 0 {
+		return false, nil
+	}
+
+	if len(ids) > 0 {
+		placeholders := make([]string, len(ids))
+		args := []interface{}{transactions.StatusReconciled, reconciliation.ID, reconciliation.UserID,
+			reconciliation.AccountID, transactions.StatusCleared}
+		for i, id := range ids {
+			placeholders[i] = "?"
+			args = append(args, id)
+		}
+
+		query := `UPDATE transactions SET status = ?, locked = TRUE, reconciliationId = ?
+                  WHERE userId = ? AND accountId = ? AND status = ? AND id IN (` + strings.Join(placeholders, ", ") + `)`
+		res, err := tx.Exec(query, args...)
+		if err != nil {
+			return false, errors.NewQueryError("error reconciling transactions: " + err.Error())
+		}
+
+		if affected, err := res.RowsAffected(); err != nil || affected != int64(len(ids)) {
+			return false, nil
+		}
+	}
+
+	now := time.Now()
+	res, err := tx.Exec(`UPDATE reconciliations SET status = ?, finishedAt = ? WHERE id = ? AND status = ?`,
+		StatusFinished, now, reconciliation.ID, StatusOpen)
+	if err != nil {
+		return false, errors.NewQueryError("error updating reconciliation: " + err.Error())
+	}
+
+	if affected, err := res.RowsAffected(); err != nil || affected == 0 {
+		return false, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+
+	reconciliation.Status = StatusFinished
+	reconciliation.FinishedAt = &now
+	return true, nil
+}
+
+func (r *reconciliationRepository) Delete(userID int64, id int64) error {
+	query := `DELETE FROM reconciliations WHERE id = ? AND userId = ? AND status = ?`
+	if _, err := r.db.Exec(query, id, userID, StatusOpen); err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReconciliation(row rowScanner) (*Reconciliation, error) {
+	var reconciliation Reconciliation
+	var finishedAt sql.NullTime
+	err := row.Scan(&reconciliation.ID, &reconciliation.UserID, &reconciliation.AccountID, &reconciliation.StatementDate,
+		&reconciliation.StatementBalance, &reconciliation.Currency, &reconciliation.Status, &reconciliation.CreatedAt,
+		&finishedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if finishedAt.Valid {
+		reconciliation.FinishedAt = &finishedAt.Time
+	}
+	reconciliation.StatementBalance.Currency = reconciliation.Currency
+	return &reconciliation, nil
+}
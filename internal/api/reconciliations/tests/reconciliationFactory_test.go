@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/reconciliations"
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func entry(id int64, amount, status string) *transactions.Transaction {
+	transaction := transactions.NewTransaction(1, 1, "Entry", 1, money.MustParse(amount, "BRL"))
+	transaction.ID = id
+	transaction.Status = status
+	return transaction
+}
+
+func TestReportComparesClearedBalanceWithStatement(t *testing.T) {
+	statementDate := time.Date(2026, 3, 31, 15, 0, 0, 0, time.UTC)
+	reconciliation := reconciliations.NewReconciliation(1, 1, statementDate, money.MustParse("850.00", "BRL"))
+	reconciliation.ID = 3
+	reconciliation.Currency = "BRL"
+
+	history := []*transactions.Transaction{
+		entry(1, "-200.00", transactions.StatusReconciled),
+		entry(2, "-100.00", transactions.StatusCleared),
+		entry(3, "-80.00", transactions.StatusPending),
+		entry(4, "150.00", transactions.StatusCleared),
+	}
+
+	report := reconciliations.NewReport(reconciliation, money.MustParse("1000.00", "BRL"), history)
+
+	assert.Equal(t, time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), report.StatementDate)
+	assert.Equal(t, "850.00", report.ClearedBalance.String())
+	assert.True(t, report.Difference.IsZero())
+	assert.Len(t, report.Transactions, 3)
+	assert.Equal(t, []int64{2, 4}, report.ClearedIDs())
+
+	history[1].Status = transactions.StatusPending
+	report = reconciliations.NewReport(reconciliation, money.MustParse("1000.00", "BRL"), history)
+	assert.Equal(t, "-100.00", report.Difference.String())
+}
+
+func TestFinishedReportListsReconciledTransactions(t *testing.T) {
+	reconciliation := reconciliations.NewReconciliation(1, 1, time.Now(), money.MustParse("700.00", "BRL"))
+	reconciliation.ID = 3
+	reconciliation.Status = reconciliations.StatusFinished
+
+	history := []*transactions.Transaction{
+		entry(1, "-200.00", transactions.StatusReconciled),
+		entry(2, "-100.00", transactions.StatusReconciled),
+		entry(3, "-80.00", transactions.StatusPending),
+	}
+	history[0].ReconciliationID = 2
+	history[1].ReconciliationID = 3
+
+	report := reconciliations.NewReport(reconciliation, money.MustParse("1000.00", "BRL"), history)
+
+	assert.Len(t, report.Transactions, 1)
+	assert.Equal(t, int64(2), report.Transactions[0].ID)
+	assert.Empty(t, report.ClearedIDs())
+}
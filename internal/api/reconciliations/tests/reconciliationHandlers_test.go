@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Renan-Parise/finances/internal/api/reconciliations"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReconciliationUseCase struct {
+	mock.Mock
+}
+
+func TestNewReconciliationHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.Default()
+	group := router.Group("/api")
+
+	mockUseCase := new(MockReconciliationUseCase)
+	reconciliations.NewReconciliationHandler(group, mockUseCase)
+
+	routes := router.Routes()
+
+	expectedRoutes := []struct {
+		method string
+		path   string
+	}{
+		{"POST", "/api/reconciliations/"},
+		{"GET", "/api/reconciliations/"},
+		{"GET", "/api/reconciliations/:id"},
+		{"POST", "/api/reconciliations/:id/finish"},
+		{"DELETE", "/api/reconciliations/:id"},
+	}
+
+	for _, expected := range expectedRoutes {
+		found := false
+		for _, route := range routes {
+			if route.Method == expected.method && route.Path == expected.path {
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, "Route %s %s not registered", expected.method, expected.path)
+	}
+}
+
+func (m *MockReconciliationUseCase) StartReconciliation(reconciliation *reconciliations.Reconciliation) (*reconciliations.Report, error) {
+	args := m.Called(reconciliation)
+	return args.Get(0).(*reconciliations.Report), args.Error(1)
+}
+
+func (m *MockReconciliationUseCase) GetReconciliations(userID int64, accountID int64) ([]*reconciliations.Reconciliation, error) {
+	args := m.Called(userID, accountID)
+	return args.Get(0).([]*reconciliations.Reconciliation), args.Error(1)
+}
+
+func (m *MockReconciliationUseCase) GetReconciliation(userID int64, id int64) (*reconciliations.Report, error) {
+	args := m.Called(userID, id)
+	return args.Get(0).(*reconciliations.Report), args.Error(1)
+}
+
+func (m *MockReconciliationUseCase) FinishReconciliation(userID int64, id int64) (*reconciliations.Report, error) {
+	args := m.Called(userID, id)
+	return args.Get(0).(*reconciliations.Report), args.Error(1)
+}
+
+func (m *MockReconciliationUseCase) DeleteReconciliation(userID int64, id int64) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
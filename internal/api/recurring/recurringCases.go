@@ -0,0 +1,259 @@
+package recurring
+
+import (
+	"log"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/accounts"
+	"github.com/Renan-Parise/finances/internal/api/currencies"
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/cache"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/pkg/rrule"
+)
+
+const (
+	defaultUpcomingDays = 30
+	maxUpcomingDays     = 366
+)
+
+type RecurringUseCase interface {
+	CreateRecurring(recurring *RecurringTransaction) error
+	GetRecurring(userID int64) ([]*RecurringTransaction, error)
+	UpdateRecurring(recurring *RecurringTransaction) error
+	DeleteRecurring(userID int64, id int64) error
+	GetUpcoming(userID int64, days int) ([]*Occurrence, error)
+	SkipOccurrence(userID int64, id int64, date time.Time) error
+	MaterializeDue(now time.Time) error
+}
+
+type recurringUseCase struct {
+	recurringRepo RecurringRepository
+	accountRepo   accounts.AccountRepository
+	currencyRepo  currencies.CurrencyRepository
+	learner       transactions.Learner
+	cache         cache.Invalidator
+	now           func() time.Time
+}
+
+func NewRecurringUseCase(rr RecurringRepository, ar accounts.AccountRepository, cr currencies.CurrencyRepository,
+	ln transactions.Learner, ci cache.Invalidator) RecurringUseCase {
+	return &recurringUseCase{
+		recurringRepo: rr,
+		accountRepo:   ar,
+		currencyRepo:  cr,
+		learner:       ln,
+		cache:         ci,
+		now:           time.Now,
+	}
+}
+
+func (uc *recurringUseCase) CreateRecurring(recurring *RecurringTransaction) error {
+	if err := uc.validate(recurring); err != nil {
+		return err
+	}
+	return uc.recurringRepo.Create(recurring)
+}
+
+func (uc *recurringUseCase) GetRecurring(userID int64) ([]*RecurringTransaction, error) {
+	return uc.recurringRepo.GetAll(userID)
+}
+
+func (uc *recurringUseCase) UpdateRecurring(recurring *RecurringTransaction) error {
+	existing, err := uc.getRecurring(recurring.UserID, recurring.ID)
+	if err != nil {
+		return err
+	}
+
+	if recurring.StartDate.IsZero() {
+		recurring.StartDate = existing.StartDate
+	}
+
+	if err := uc.validate(recurring); err != nil {
+		return err
+	}
+
+	recurring.UpdatedAt = time.Now()
+	return uc.recurringRepo.Update(recurring)
+}
+
+func (uc *recurringUseCase) DeleteRecurring(userID int64, id int64) error {
+	return uc.recurringRepo.Delete(userID, id)
+}
+
+func (uc *recurringUseCase) GetUpcoming(userID int64, days int) ([]*Occurrence, error) {
+	if days <= 0 {
+		days = defaultUpcomingDays
+	}
+	if days > maxUpcomingDays {
+		return nil, errors.NewValidationError("days", "cannot look further than one year ahead")
+	}
+
+	templates, err := uc.recurringRepo.GetAll(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := rrule.Date(uc.now())
+	until := today.AddDate(0, 0, days)
+
+	var upcoming []*Occurrence
+	for _, recurring := range templates {
+		if !recurring.Active {
+			continue
+		}
+
+		rule, err := rrule.Parse(recurring.Rule, recurring.StartDate)
+		if err != nil {
+			log.Printf("Skipping recurring transaction %d with invalid rule: %v", recurring.ID, err)
+			continue
+		}
+
+		from := nextUnposted(recurring, today)
+		recorded, err := uc.recurringRepo.GetOccurrences(recurring.ID, from, until)
+		if err != nil {
+			return nil, err
+		}
+
+		statuses := make(map[string]string)
+		for _, occurrence := range recorded {
+			statuses[occurrence.Date] = occurrence.Status
+		}
+
+		for _, date := range rule.Between(from, until) {
+			status := StatusScheduled
+			if recordedStatus, ok := statuses[date.Format("2006-01-02")]; ok {
+				status = recordedStatus
+			}
+			upcoming = append(upcoming, NewOccurrence(recurring, date, status))
+		}
+	}
+	return upcoming, nil
+}
+
+func (uc *recurringUseCase) SkipOccurrence(userID int64, id int64, date time.Time) error {
+	recurring, err := uc.getRecurring(userID, id)
+	if err != nil {
+		return err
+	}
+
+	rule, err := rrule.Parse(recurring.Rule, recurring.StartDate)
+	if err != nil {
+		return errors.NewValidationError("rule", err.Error())
+	}
+
+	date = rrule.Date(date)
+	if !rule.Includes(date) {
+		return errors.NewValidationError("date", "no occurrence is scheduled on "+date.Format("2006-01-02"))
+	}
+
+	skipped, err := uc.recurringRepo.SkipOccurrence(recurring.ID, date)
+	if err != nil {
+		return err
+	}
+
+	if !skipped {
+		return errors.NewValidationError("date", "occurrence on "+date.Format("2006-01-02")+" was already posted or skipped")
+	}
+	return nil
+}
+
+func (uc *recurringUseCase) MaterializeDue(now time.Time) error {
+	today := rrule.Date(now)
+
+	templates, err := uc.recurringRepo.GetDue(today)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, recurring := range templates {
+		posted, err := uc.materialize(recurring, today)
+		if err != nil {
+			log.Printf("Failed to materialize recurring transaction %d: %v", recurring.ID, err)
+			failed++
+		}
+
+		if len(posted) > 0 {
+			cache.InvalidateUserOrLog(uc.cache, recurring.UserID)
+			transactions.Relearn(uc.learner, recurring.UserID, nil, posted)
+		}
+	}
+
+	if failed > 0 {
+		return errors.NewServiceError("failed to materialize some recurring transactions")
+	}
+	return nil
+}
+
+func (uc *recurringUseCase) materialize(recurring *RecurringTransaction, today time.Time) ([]*transactions.Transaction, error) {
+	rule, err := rrule.Parse(recurring.Rule, recurring.StartDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var posted []*transactions.Transaction
+	for _, date := range rule.Between(nextUnposted(recurring, recurring.StartDate), today) {
+		ok, err := uc.recurringRepo.PostOccurrence(recurring, date)
+		if err != nil {
+			return posted, err
+		}
+		if ok {
+			posted = append(posted, &transactions.Transaction{
+				UserID:      recurring.UserID,
+				AccountID:   recurring.AccountID,
+				OccurredAt:  date,
+				Description: recurring.Description,
+				Category:    recurring.Category,
+				Amount:      recurring.Amount,
+				Currency:    recurring.Currency,
+			})
+		}
+	}
+
+	return posted, uc.recurringRepo.UpdatePostedThrough(recurring.ID, today)
+}
+
+func (uc *recurringUseCase) validate(recurring *RecurringTransaction) error {
+	if recurring.Amount.IsZero() {
+		return errors.NewValidationError("amount", "amount must be different from zero")
+	}
+
+	rule, err := rrule.Parse(recurring.Rule, recurring.StartDate)
+	if err != nil {
+		return errors.NewValidationError("rule", err.Error())
+	}
+	recurring.Rule = rule.String()
+
+	currency, err := accounts.ResolveCurrency(uc.accountRepo, uc.currencyRepo, recurring.UserID,
+		recurring.AccountID, recurring.Currency)
+	if err != nil {
+		return err
+	}
+
+	recurring.Currency = currency
+	recurring.Amount.Currency = currency
+	return nil
+}
+
+func (uc *recurringUseCase) getRecurring(userID int64, id int64) (*RecurringTransaction, error) {
+	recurring, err := uc.recurringRepo.GetByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if recurring == nil {
+		return nil, errors.NewValidationError("id", "recurring transaction not found")
+	}
+	return recurring, nil
+}
+
+func nextUnposted(recurring *RecurringTransaction, fallback time.Time) time.Time {
+	if recurring.PostedThrough != nil {
+		next := rrule.Date(*recurring.PostedThrough).AddDate(0, 0, 1)
+		if next.After(fallback) {
+			return next
+		}
+	}
+	return fallback
+}
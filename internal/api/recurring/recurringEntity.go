@@ -0,0 +1,39 @@
+package recurring
+
+import (
+	"time"
+
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+const (
+	StatusScheduled = "scheduled"
+	StatusPosted    = "posted"
+	StatusSkipped   = "skipped"
+)
+
+type RecurringTransaction struct {
+	ID            int64       `json:"id"`
+	UserID        int64       `json:"userId"`
+	AccountID     int64       `json:"accountId,omitempty"`
+	Description   string      `json:"description"`
+	Category      int         `json:"category"`
+	Amount        money.Money `json:"amount"`
+	Currency      string      `json:"currency"`
+	Rule          string      `json:"rule"`
+	StartDate     time.Time   `json:"startDate"`
+	PostedThrough *time.Time  `json:"postedThrough,omitempty"`
+	Active        bool        `json:"active"`
+	CreatedAt     time.Time   `json:"createdAt"`
+	UpdatedAt     time.Time   `json:"updatedAt"`
+}
+
+type Occurrence struct {
+	RecurringID   int64       `json:"recurringId"`
+	Date          string      `json:"date"`
+	Description   string      `json:"description"`
+	Amount        money.Money `json:"amount"`
+	Currency      string      `json:"currency"`
+	Status        string      `json:"status"`
+	TransactionID int64       `json:"transactionId,omitempty"`
+}
@@ -0,0 +1,35 @@
+package recurring
+
+import (
+	"time"
+
+	"github.com/Renan-Parise/finances/pkg/money"
+	"github.com/Renan-Parise/finances/pkg/rrule"
+)
+
+func NewRecurringTransaction(userID, accountID int64, description string, category int, amount money.Money, rule string, startDate time.Time) *RecurringTransaction {
+	now := time.Now()
+	return &RecurringTransaction{
+		UserID:      userID,
+		AccountID:   accountID,
+		Description: description,
+		Category:    category,
+		Amount:      amount,
+		Rule:        rule,
+		StartDate:   rrule.Date(startDate),
+		Active:      true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+func NewOccurrence(recurring *RecurringTransaction, date time.Time, status string) *Occurrence {
+	return &Occurrence{
+		RecurringID: recurring.ID,
+		Date:        date.Format("2006-01-02"),
+		Description: recurring.Description,
+		Amount:      recurring.Amount,
+		Currency:    recurring.Currency,
+		Status:      status,
+	}
+}
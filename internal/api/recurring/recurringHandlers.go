@@ -0,0 +1,249 @@
+package recurring
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/middlewares"
+	"github.com/Renan-Parise/finances/pkg/money"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecurringHandler struct {
+	recurringUseCase RecurringUseCase
+}
+
+type recurringInput struct {
+	AccountID   int64       `json:"accountId"`
+	Description string      `json:"description" binding:"required"`
+	Category    int         `json:"category" binding:"required"`
+	Amount      money.Money `json:"amount"`
+	Currency    string      `json:"currency"`
+	Rule        string      `json:"rule" binding:"required"`
+	StartDate   string      `json:"startDate"`
+	Active      *bool       `json:"active"`
+}
+
+func NewRecurringHandler(router *gin.RouterGroup, ru RecurringUseCase) {
+	handler := &RecurringHandler{
+		recurringUseCase: ru,
+	}
+
+	recurring := router.Group("/recurring")
+	recurring.Use(middlewares.JWTAuthMiddleware())
+	{
+		recurring.POST("/", handler.CreateRecurring)
+		recurring.GET("/", handler.GetRecurring)
+		recurring.GET("/upcoming", handler.GetUpcoming)
+		recurring.PUT("/:id", handler.UpdateRecurring)
+		recurring.DELETE("/:id", handler.DeleteRecurring)
+		recurring.POST("/:id/skip", handler.SkipOccurrence)
+	}
+}
+
+func (h *RecurringHandler) CreateRecurring(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	var input recurringInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	startDate := time.Now()
+	if input.StartDate != "" {
+		parsed, err := time.Parse("2006-01-02", input.StartDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start date, expected YYYY-MM-DD"})
+			return
+		}
+		startDate = parsed
+	}
+
+	recurring := NewRecurringTransaction(userID.(int64), input.AccountID, input.Description, input.Category,
+		input.Amount, input.Rule, startDate)
+	recurring.Currency = input.Currency
+	if input.Active != nil {
+		recurring.Active = *input.Active
+	}
+
+	err := h.recurringUseCase.CreateRecurring(recurring)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, recurring)
+}
+
+func (h *RecurringHandler) GetRecurring(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	recurring, err := h.recurringUseCase.GetRecurring(userID.(int64))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, recurring)
+}
+
+func (h *RecurringHandler) UpdateRecurring(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recurring transaction ID"})
+		return
+	}
+
+	var input recurringInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	recurring := &RecurringTransaction{
+		ID:          id,
+		UserID:      userID.(int64),
+		AccountID:   input.AccountID,
+		Description: input.Description,
+		Category:    input.Category,
+		Amount:      input.Amount,
+		Currency:    input.Currency,
+		Rule:        input.Rule,
+		Active:      input.Active == nil || *input.Active,
+	}
+
+	if input.StartDate != "" {
+		recurring.StartDate, err = time.Parse("2006-01-02", input.StartDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start date, expected YYYY-MM-DD"})
+			return
+		}
+	}
+
+	err = h.recurringUseCase.UpdateRecurring(recurring)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Recurring transaction updated successfully"})
+}
+
+func (h *RecurringHandler) DeleteRecurring(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recurring transaction ID"})
+		return
+	}
+
+	err = h.recurringUseCase.DeleteRecurring(userID.(int64), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Recurring transaction deleted successfully"})
+}
+
+func (h *RecurringHandler) GetUpcoming(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	days := 0
+	if value := c.Query("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid days parameter"})
+			return
+		}
+		days = parsed
+	}
+
+	occurrences, err := h.recurringUseCase.GetUpcoming(userID.(int64), days)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, occurrences)
+}
+
+func (h *RecurringHandler) SkipOccurrence(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recurring transaction ID"})
+		return
+	}
+
+	var input struct {
+		Date string `json:"date" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", input.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date, expected YYYY-MM-DD"})
+		return
+	}
+
+	err = h.recurringUseCase.SkipOccurrence(userID.(int64), id, date)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Occurrence skipped successfully"})
+}
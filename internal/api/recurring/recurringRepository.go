@@ -0,0 +1,248 @@
+package recurring
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/ledger"
+)
+
+type RecurringRepository interface {
+	Create(recurring *RecurringTransaction) error
+	GetAll(userID int64) ([]*RecurringTransaction, error)
+	GetByID(userID int64, id int64) (*RecurringTransaction, error)
+	GetDue(until time.Time) ([]*RecurringTransaction, error)
+	Update(recurring *RecurringTransaction) error
+	Delete(userID int64, id int64) error
+	UpdatePostedThrough(id int64, date time.Time) error
+	GetOccurrences(recurringID int64, from, to time.Time) ([]*Occurrence, error)
+	PostOccurrence(recurring *RecurringTransaction, date time.Time) (bool, error)
+	SkipOccurrence(recurringID int64, date time.Time) (bool, error)
+}
+
+type recurringRepository struct {
+	db *sql.DB
+}
+
+func NewRecurringRepository(db *sql.DB) RecurringRepository {
+	return &recurringRepository{db: db}
+}
+
+const recurringColumns = `id, userId, COALESCE(accountId, 0), description, category, amount, currency, rule,
+              startDate, postedThrough, active, createdAt, updatedAt`
+
+func (r *recurringRepository) Create(recurring *RecurringTransaction) error {
+	query := `INSERT INTO recurring_transactions (userId, accountId, description, category, amount, currency, rule,
+              startDate, active, createdAt, updatedAt)
+              VALUES (?, NULLIF(?, 0), ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return errors.NewQueryError("error preparing query: " + err.Error())
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(recurring.UserID, recurring.AccountID, recurring.Description, recurring.Category,
+		recurring.Amount, recurring.Currency, recurring.Rule, recurring.StartDate.Format("2006-01-02"),
+		recurring.Active, recurring.CreatedAt, recurring.UpdatedAt)
+	if err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return errors.NewQueryError("error getting last insert ID: " + err.Error())
+	}
+
+	recurring.ID = id
+	return nil
+}
+
+func (r *recurringRepository) GetAll(userID int64) ([]*RecurringTransaction, error) {
+	query := `SELECT ` + recurringColumns + ` FROM recurring_transactions WHERE userId = ? ORDER BY startDate`
+	return r.query(query, userID)
+}
+
+func (r *recurringRepository) GetByID(userID int64, id int64) (*RecurringTransaction, error) {
+	query := `SELECT ` + recurringColumns + ` FROM recurring_transactions WHERE id = ? AND userId = ?`
+	recurring, err := scanRecurring(r.db.QueryRow(query, id, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.NewQueryError("error scanning row: " + err.Error())
+	}
+	return recurring, nil
+}
+
+func (r *recurringRepository) GetDue(until time.Time) ([]*RecurringTransaction, error) {
+	query := `SELECT ` + recurringColumns + ` FROM recurring_transactions
+              WHERE active = 1 AND startDate <= ? AND (postedThrough IS NULL OR postedThrough < ?)`
+	day := until.Format("2006-01-02")
+	return r.query(query, day, day)
+}
+
+func (r *recurringRepository) Update(recurring *RecurringTransaction) error {
+	query := `UPDATE recurring_transactions SET accountId = NULLIF(?, 0), description = ?, category = ?, amount = ?,
+              currency = ?, rule = ?, startDate = ?, active = ?, updatedAt = ?
+              WHERE id = ? AND userId = ?`
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return errors.NewQueryError("error preparing query: " + err.Error())
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(recurring.AccountID, recurring.Description, recurring.Category, recurring.Amount,
+		recurring.Currency, recurring.Rule, recurring.StartDate.Format("2006-01-02"), recurring.Active,
+		recurring.UpdatedAt, recurring.ID, recurring.UserID)
+	return err
+}
+
+func (r *recurringRepository) Delete(userID int64, id int64) error {
+	query := `DELETE FROM recurring_transactions WHERE id = ? AND userId = ?`
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return errors.NewQueryError("error preparing query: " + err.Error())
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(id, userID)
+	return err
+}
+
+func (r *recurringRepository) UpdatePostedThrough(id int64, date time.Time) error {
+	query := `UPDATE recurring_transactions SET postedThrough = ? WHERE id = ? AND (postedThrough IS NULL OR postedThrough < ?)`
+	day := date.Format("2006-01-02")
+	_, err := r.db.Exec(query, day, id, day)
+	if err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+	return nil
+}
+
+func (r *recurringRepository) GetOccurrences(recurringID int64, from, to time.Time) ([]*Occurrence, error) {
+	query := `SELECT recurringId, date, status, COALESCE(transactionId, 0) FROM recurring_occurrences
+              WHERE recurringId = ? AND date >= ? AND date <= ?
+              ORDER BY date`
+	rows, err := r.db.Query(query, recurringID, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		return nil, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	var occurrences []*Occurrence
+	for rows.Next() {
+		var occurrence Occurrence
+		var date time.Time
+		err := rows.Scan(&occurrence.RecurringID, &date, &occurrence.Status, &occurrence.TransactionID)
+		if err != nil {
+			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		occurrence.Date = date.Format("2006-01-02")
+		occurrences = append(occurrences, &occurrence)
+	}
+	return occurrences, nil
+}
+
+func (r *recurringRepository) PostOccurrence(recurring *RecurringTransaction, date time.Time) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, errors.NewDatabaseError("error starting transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	day := date.Format("2006-01-02")
+	res, err := tx.Exec(`INSERT IGNORE INTO recurring_occurrences (recurringId, date, status) VALUES (?, ?, ?)`,
+		recurring.ID, day, StatusPosted)
+	if err != nil {
+		return false, errors.NewQueryError("error claiming occurrence: " + err.Error())
+	}
+
+	claimed, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.NewQueryError("error claiming occurrence: " + err.Error())
+	}
+	if claimed == 0 {
+		return false, nil
+	}
+
+	now := time.Now()
+	transactionID, err := ledger.Insert(tx, &ledger.Entry{
+		UserID:      recurring.UserID,
+		AccountID:   recurring.AccountID,
+		OccurredAt:  date,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Description: recurring.Description,
+		Category:    int64(recurring.Category),
+		Amount:      recurring.Amount,
+		Currency:    recurring.Currency,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	_, err = tx.Exec(`UPDATE recurring_occurrences SET transactionId = ? WHERE recurringId = ? AND date = ?`,
+		transactionID, recurring.ID, day)
+	if err != nil {
+		return false, errors.NewQueryError("error linking occurrence: " + err.Error())
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+	return true, nil
+}
+
+func (r *recurringRepository) SkipOccurrence(recurringID int64, date time.Time) (bool, error) {
+	res, err := r.db.Exec(`INSERT IGNORE INTO recurring_occurrences (recurringId, date, status) VALUES (?, ?, ?)`,
+		recurringID, date.Format("2006-01-02"), StatusSkipped)
+	if err != nil {
+		return false, errors.NewQueryError("error skipping occurrence: " + err.Error())
+	}
+
+	skipped, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.NewQueryError("error skipping occurrence: " + err.Error())
+	}
+	return skipped > 0, nil
+}
+
+func (r *recurringRepository) query(query string, args ...interface{}) ([]*RecurringTransaction, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	var templates []*RecurringTransaction
+	for rows.Next() {
+		recurring, err := scanRecurring(rows)
+		if err != nil {
+			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		templates = append(templates, recurring)
+	}
+	return templates, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRecurring(row rowScanner) (*RecurringTransaction, error) {
+	var recurring RecurringTransaction
+	var postedThrough sql.NullTime
+	err := row.Scan(&recurring.ID, &recurring.UserID, &recurring.AccountID, &recurring.Description,
+		&recurring.Category, &recurring.Amount, &recurring.Currency, &recurring.Rule, &recurring.StartDate,
+		&postedThrough, &recurring.Active, &recurring.CreatedAt, &recurring.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if postedThrough.Valid {
+		recurring.PostedThrough = &postedThrough.Time
+	}
+	recurring.Amount.Currency = recurring.Currency
+	return &recurring, nil
+}
@@ -0,0 +1,139 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/recurring"
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryRecurringRepository struct {
+	templates   []*recurring.RecurringTransaction
+	occurrences map[string]string
+	posts       int
+}
+
+func (r *memoryRecurringRepository) Create(rt *recurring.RecurringTransaction) error {
+	rt.ID = int64(len(r.templates) + 1)
+	r.templates = append(r.templates, rt)
+	return nil
+}
+
+func (r *memoryRecurringRepository) GetAll(userID int64) ([]*recurring.RecurringTransaction, error) {
+	return r.templates, nil
+}
+
+func (r *memoryRecurringRepository) GetByID(userID int64, id int64) (*recurring.RecurringTransaction, error) {
+	for _, rt := range r.templates {
+		if rt.ID == id {
+			return rt, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryRecurringRepository) GetDue(until time.Time) ([]*recurring.RecurringTransaction, error) {
+	var due []*recurring.RecurringTransaction
+	for _, rt := range r.templates {
+		if rt.PostedThrough == nil || rt.PostedThrough.Before(until) {
+			due = append(due, rt)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryRecurringRepository) Update(rt *recurring.RecurringTransaction) error {
+	return nil
+}
+
+func (r *memoryRecurringRepository) Delete(userID int64, id int64) error {
+	return nil
+}
+
+func (r *memoryRecurringRepository) UpdatePostedThrough(id int64, date time.Time) error {
+	for _, rt := range r.templates {
+		if rt.ID == id {
+			rt.PostedThrough = &date
+		}
+	}
+	return nil
+}
+
+func (r *memoryRecurringRepository) GetOccurrences(recurringID int64, from, to time.Time) ([]*recurring.Occurrence, error) {
+	var occurrences []*recurring.Occurrence
+	for date, status := range r.occurrences {
+		occurrences = append(occurrences, &recurring.Occurrence{RecurringID: recurringID, Date: date, Status: status})
+	}
+	return occurrences, nil
+}
+
+func (r *memoryRecurringRepository) PostOccurrence(rt *recurring.RecurringTransaction, date time.Time) (bool, error) {
+	return r.claim(date, recurring.StatusPosted), nil
+}
+
+func (r *memoryRecurringRepository) SkipOccurrence(recurringID int64, date time.Time) (bool, error) {
+	return r.claim(date, recurring.StatusSkipped), nil
+}
+
+func (r *memoryRecurringRepository) claim(date time.Time, status string) bool {
+	key := date.Format("2006-01-02")
+	if _, ok := r.occurrences[key]; ok {
+		return false
+	}
+	r.occurrences[key] = status
+	if status == recurring.StatusPosted {
+		r.posts++
+	}
+	return true
+}
+
+type noopInvalidator struct{}
+
+func (noopInvalidator) InvalidateUser(userID int64) error { return nil }
+
+func (noopInvalidator) InvalidateAll() error { return nil }
+
+type recordingLearner struct {
+	learned []string
+}
+
+func (l *recordingLearner) Learn(userID int64, batch []*transactions.Transaction) error {
+	for _, transaction := range batch {
+		l.learned = append(l.learned, transaction.OccurredAt.Format("2006-01-02")+" "+transaction.Description)
+	}
+	return nil
+}
+
+func (l *recordingLearner) Forget(userID int64, batch []*transactions.Transaction) error {
+	return nil
+}
+
+func TestMaterializeDueIsIdempotent(t *testing.T) {
+	repo := &memoryRecurringRepository{occurrences: make(map[string]string)}
+	learner := &recordingLearner{}
+	uc := recurring.NewRecurringUseCase(repo, nil, nil, learner, noopInvalidator{})
+
+	rt := recurring.NewRecurringTransaction(1, 0, "Rent", 1, money.MustParse("-1500", "BRL"),
+		"FREQ=MONTHLY;BYMONTHDAY=5", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	rt.Currency = "BRL"
+	assert.NoError(t, repo.Create(rt))
+
+	assert.NoError(t, uc.SkipOccurrence(1, rt.ID, time.Date(2026, 2, 5, 0, 0, 0, 0, time.UTC)))
+
+	now := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	assert.NoError(t, uc.MaterializeDue(now))
+	assert.Equal(t, 2, repo.posts)
+
+	rt.PostedThrough = nil
+	assert.NoError(t, uc.MaterializeDue(now))
+	assert.Equal(t, 2, repo.posts)
+
+	assert.Equal(t, recurring.StatusPosted, repo.occurrences["2026-01-05"])
+	assert.Equal(t, recurring.StatusSkipped, repo.occurrences["2026-02-05"])
+	assert.Equal(t, recurring.StatusPosted, repo.occurrences["2026-03-05"])
+
+	assert.Equal(t, []string{"2026-01-05 Rent", "2026-03-05 Rent"}, learner.learned)
+}
@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/recurring"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRecurringUseCase struct {
+	mock.Mock
+}
+
+func TestNewRecurringHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.Default()
+	group := router.Group("/api")
+
+	mockUseCase := new(MockRecurringUseCase)
+	recurring.NewRecurringHandler(group, mockUseCase)
+
+	routes := router.Routes()
+
+	expectedRoutes := []struct {
+		method string
+		path   string
+	}{
+		{"POST", "/api/recurring/"},
+		{"GET", "/api/recurring/"},
+		{"GET", "/api/recurring/upcoming"},
+		{"PUT", "/api/recurring/:id"},
+		{"DELETE", "/api/recurring/:id"},
+		{"POST", "/api/recurring/:id/skip"},
+	}
+
+	for _, expected := range expectedRoutes {
+		found := false
+		for _, route := range routes {
+			if route.Method == expected.method && route.Path == expected.path {
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, "Route %s %s not registered", expected.method, expected.path)
+	}
+}
+
+func (m *MockRecurringUseCase) CreateRecurring(r *recurring.RecurringTransaction) error {
+	args := m.Called(r)
+	return args.Error(0)
+}
+
+func (m *MockRecurringUseCase) GetRecurring(userID int64) ([]*recurring.RecurringTransaction, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]*recurring.RecurringTransaction), args.Error(1)
+}
+
+func (m *MockRecurringUseCase) UpdateRecurring(r *recurring.RecurringTransaction) error {
+	args := m.Called(r)
+	return args.Error(0)
+}
+
+func (m *MockRecurringUseCase) DeleteRecurring(userID int64, id int64) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
+
+func (m *MockRecurringUseCase) GetUpcoming(userID int64, days int) ([]*recurring.Occurrence, error) {
+	args := m.Called(userID, days)
+	return args.Get(0).([]*recurring.Occurrence), args.Error(1)
+}
+
+func (m *MockRecurringUseCase) SkipOccurrence(userID int64, id int64, date time.Time) error {
+	args := m.Called(userID, id, date)
+	return args.Error(0)
+}
+
+func (m *MockRecurringUseCase) MaterializeDue(now time.Time) error {
+	args := m.Called(now)
+	return args.Error(0)
+}
@@ -0,0 +1,295 @@
+package rules
+
+import (
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/accounts"
+	"github.com/Renan-Parise/finances/internal/api/categories"
+	"github.com/Renan-Parise/finances/internal/api/tags"
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/cache"
+	"github.com/Renan-Parise/finances/internal/errors"
+)
+
+type RuleUseCase interface {
+	CreateRule(rule *Rule) error
+	GetRules(userID int64) ([]*Rule, error)
+	UpdateRule(rule *Rule) error
+	DeleteRule(userID int64, id int64) error
+	TestRule(rule *Rule, scope *Range) (*ApplyReport, error)
+	ApplyRules(userID int64, scope *Range, dryRun bool) (*ApplyReport, error)
+	Enrich(userID int64, batch []*transactions.Transaction) error
+}
+
+type ruleUseCase struct {
+	ruleRepo        RuleRepository
+	transactionRepo transactions.TransactionRepositories
+	accountRepo     accounts.AccountRepository
+	categoryRepo    categories.CategoryRepository
+	tagRepo         tags.TagRepository
+	learner         transactions.Learner
+	cache           cache.Invalidator
+}
+
+func NewRuleUseCase(rr RuleRepository, tr transactions.TransactionRepositories, ar accounts.AccountRepository,
+	cr categories.CategoryRepository, tg tags.TagRepository, ln transactions.Learner, ci cache.Invalidator) RuleUseCase {
+	return &ruleUseCase{
+		ruleRepo:        rr,
+		transactionRepo: tr,
+		accountRepo:     ar,
+		categoryRepo:    cr,
+		tagRepo:         tg,
+		learner:         ln,
+		cache:           ci,
+	}
+}
+
+func (uc *ruleUseCase) CreateRule(rule *Rule) error {
+	if err := uc.validateRule(rule); err != nil {
+		return err
+	}
+	return uc.ruleRepo.Create(rule)
+}
+
+func (uc *ruleUseCase) GetRules(userID int64) ([]*Rule, error) {
+	return uc.ruleRepo.GetAll(userID)
+}
+
+func (uc *ruleUseCase) UpdateRule(rule *Rule) error {
+	existing, err := uc.getRule(rule.UserID, rule.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.validateRule(rule); err != nil {
+		return err
+	}
+
+	rule.CreatedAt = existing.CreatedAt
+	rule.UpdatedAt = time.Now()
+	return uc.ruleRepo.Update(rule)
+}
+
+func (uc *ruleUseCase) DeleteRule(userID int64, id int64) error {
+	if _, err := uc.getRule(userID, id); err != nil {
+		return err
+	}
+	return uc.ruleRepo.Delete(userID, id)
+}
+
+func (uc *ruleUseCase) TestRule(rule *Rule, scope *Range) (*ApplyReport, error) {
+	if err := uc.validateDefinition(rule); err != nil {
+		return nil, err
+	}
+	return uc.run(rule.UserID, []*Rule{rule}, scope, true)
+}
+
+func (uc *ruleUseCase) ApplyRules(userID int64, scope *Range, dryRun bool) (*ApplyReport, error) {
+	rules, err := uc.enabledRules(userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.run(userID, rules, scope, dryRun)
+}
+
+func (uc *ruleUseCase) Enrich(userID int64, batch []*transactions.Transaction) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	rules, err := uc.enabledRules(userID)
+	if err != nil || len(rules) == 0 {
+		return err
+	}
+
+	engine, err := NewEngine(rules)
+	if err != nil {
+		return err
+	}
+
+	for _, transaction := range batch {
+		engine.Apply(transaction, false)
+	}
+	return nil
+}
+
+func (uc *ruleUseCase) run(userID int64, rules []*Rule, scope *Range, dryRun bool) (*ApplyReport, error) {
+	engine, err := NewEngine(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := uc.transactionRepo.Filter(userID, &transactions.Filter{
+		AccountID: scope.AccountID,
+		From:      scope.From,
+		To:        scope.To,
+		Field:     "occurredAt",
+		Order:     "ASC",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ApplyReport{DryRun: dryRun, Scanned: len(history), Changes: []*Change{}}
+	var before, after []*transactions.Transaction
+	for _, transaction := range history {
+		if transaction.Locked || transaction.TransferID != 0 {
+			continue
+		}
+
+		updated := *transaction
+		matched := engine.Apply(&updated, true)
+		if change := diff(transaction, &updated, matched); change != nil {
+			report.Changes = append(report.Changes, change)
+			before = append(before, transaction)
+			after = append(after, &updated)
+		}
+	}
+	report.Changed = len(report.Changes)
+
+	if dryRun || report.Changed == 0 {
+		return report, nil
+	}
+
+	if err := uc.ruleRepo.ApplyChanges(userID, report.Changes); err != nil {
+		return nil, err
+	}
+	cache.InvalidateUserOrLog(uc.cache, userID)
+	transactions.Relearn(uc.learner, userID, before, after)
+	return report, nil
+}
+
+func diff(before, after *transactions.Transaction, matched []int64) *Change {
+	if len(matched) == 0 {
+		return nil
+	}
+
+	change := &Change{
+		TransactionID: before.ID,
+		Date:          before.OccurredAt,
+		Description:   before.Description,
+		Category:      before.Category,
+		RuleIDs:       matched,
+	}
+	if after.Description != before.Description {
+		change.NewDescription = after.Description
+	}
+	if after.Category != before.Category {
+		change.NewCategory = after.Category
+	}
+	change.AddTags = after.Tags[len(before.Tags):]
+
+	if change.NewDescription == "" && change.NewCategory == 0 && len(change.AddTags) == 0 {
+		return nil
+	}
+	return change
+}
+
+func (uc *ruleUseCase) enabledRules(userID int64) ([]*Rule, error) {
+	all, err := uc.ruleRepo.GetAll(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []*Rule
+	for _, rule := range all {
+		if rule.Enabled {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func (uc *ruleUseCase) getRule(userID int64, id int64) (*Rule, error) {
+	rule, err := uc.ruleRepo.GetByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule == nil {
+		return nil, errors.NewValidationError("id", "rule not found")
+	}
+	return rule, nil
+}
+
+func (uc *ruleUseCase) validateRule(rule *Rule) error {
+	if rule.Name == "" {
+		return errors.NewValidationError("name", "name is required")
+	}
+	return uc.validateDefinition(rule)
+}
+
+func (uc *ruleUseCase) validateDefinition(rule *Rule) error {
+	if rule.DescriptionContains == "" && rule.DescriptionRegex == "" && rule.AmountMin == nil &&
+		rule.AmountMax == nil && rule.AccountID == 0 && len(rule.Weekdays) == 0 {
+		return errors.NewValidationError("conditions", "a rule needs at least one condition")
+	}
+
+	if rule.SetCategory == 0 && rule.SetDescription == "" && len(rule.AddTags) == 0 {
+		return errors.NewValidationError("actions", "a rule needs at least one action")
+	}
+
+	if _, err := compile(rule); err != nil {
+		return err
+	}
+
+	if (rule.AmountMin != nil && rule.AmountMin.IsNegative()) || (rule.AmountMax != nil && rule.AmountMax.IsNegative()) {
+		return errors.NewValidationError("amountMin", "amount bounds are absolute values and cannot be negative")
+	}
+
+	if rule.AmountMin != nil && rule.AmountMax != nil && rule.AmountMin.Cmp(*rule.AmountMax) > 0 {
+		return errors.NewValidationError("amountMin", "amountMin cannot be greater than amountMax")
+	}
+
+	if rule.AccountID != 0 {
+		account, err := uc.accountRepo.GetByID(rule.UserID, rule.AccountID)
+		if err != nil {
+			return err
+		}
+		if account == nil {
+			return errors.NewValidationError("accountId", "account not found")
+		}
+	}
+
+	if rule.SetCategory != 0 {
+		categoryList, err := uc.categoryRepo.GetAll(rule.UserID)
+		if err != nil {
+			return err
+		}
+
+		found := false
+		for _, category := range categoryList {
+			if category.ID == rule.SetCategory {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.NewValidationError("setCategory", "category not found")
+		}
+	}
+
+	if len(rule.AddTags) > 0 {
+		normalized, err := transactions.NormalizeTags(rule.AddTags)
+		if err != nil {
+			return err
+		}
+		rule.AddTags = normalized
+
+		tagList, err := uc.tagRepo.GetAll(rule.UserID)
+		if err != nil {
+			return err
+		}
+
+		owned := make(map[int64]bool, len(tagList))
+		for _, tag := range tagList {
+			owned[tag.ID] = true
+		}
+		for _, id := range rule.AddTags {
+			if !owned[id] {
+				return errors.NewValidationError("addTags", "tag not found")
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package rules
+
+import (
+	"time"
+
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+// Rule categorizes or renames transactions automatically.
+type Rule struct {
+	ID             int64  `json:"id"`
+	UserID         int64  `json:"userId"`
+	Name           string `json:"name"`
+	Priority       int    `json:"priority"`
+	Enabled        bool   `json:"enabled"`
+	StopProcessing bool   `json:"stopProcessing"`
+
+	DescriptionContains string       `json:"descriptionContains,omitempty"`
+	DescriptionRegex    string       `json:"descriptionRegex,omitempty"`
+	AmountMin           *money.Money `json:"amountMin,omitempty"`
+	AmountMax           *money.Money `json:"amountMax,omitempty"`
+	AccountID           int64        `json:"accountId,omitempty"`
+	Weekdays            []int        `json:"weekdays,omitempty"`
+
+	SetCategory    int     `json:"setCategory,omitempty"`
+	SetDescription string  `json:"setDescription,omitempty"`
+	AddTags        []int64 `json:"addTags,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Change is what the rules did, or would do, to one stored transaction.
+type Change struct {
+	TransactionID  int64     `json:"transactionId"`
+	Date           time.Time `json:"date"`
+	Description    string    `json:"description"`
+	NewDescription string    `json:"newDescription,omitempty"`
+	Category       int       `json:"category"`
+	NewCategory    int       `json:"newCategory,omitempty"`
+	AddTags        []int64   `json:"addTags,omitempty"`
+	RuleIDs        []int64   `json:"ruleIds"`
+}
+
+// Range limits which stored transactions a rule test or a bulk apply reads.
+type Range struct {
+	AccountID int64  `json:"accountId"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+}
+
+type ApplyReport struct {
+	DryRun  bool      `json:"dryRun"`
+	Scanned int       `json:"scanned"`
+	Changed int       `json:"changed"`
+	Changes []*Change `json:"changes"`
+}
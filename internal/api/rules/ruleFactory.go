@@ -0,0 +1,180 @@
+package rules
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/utils"
+)
+
+func NewRule(userID int64, name string) *Rule {
+	now := time.Now()
+	return &Rule{
+		UserID:    userID,
+		Name:      name,
+		Enabled:   true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+type matcher struct {
+	rule     *Rule
+	contains string
+	regex    *regexp.Regexp
+	weekdays uint8
+}
+
+// Engine evaluates a set of rules in priority order.
+type Engine struct {
+	matchers []*matcher
+}
+
+// NewEngine compiles the rules once so they can be run against many
+// transactions.
+func NewEngine(rules []*Rule) (*Engine, error) {
+	ordered := make([]*Rule, len(rules))
+	copy(ordered, rules)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Priority < ordered[j].Priority
+	})
+
+	engine := &Engine{}
+	for _, rule := range ordered {
+		m, err := compile(rule)
+		if err != nil {
+			return nil, err
+		}
+		engine.matchers = append(engine.matchers, m)
+	}
+	return engine, nil
+}
+
+// Apply runs the rules against a transaction and returns the IDs of the rules
+// that matched.
+func (e *Engine) Apply(transaction *transactions.Transaction, overrideCategory bool) []int64 {
+	if transaction.TransferID != 0 {
+		return nil
+	}
+
+	original := *transaction
+	categorySet := len(transaction.Splits) > 0 || (transaction.Category != 0 && !overrideCategory)
+	descriptionSet := false
+
+	var matched []int64
+	for _, m := range e.matchers {
+		if !m.matches(&original) {
+			continue
+		}
+		matched = append(matched, m.rule.ID)
+
+		if m.rule.SetCategory != 0 && !categorySet {
+			transaction.Category = m.rule.SetCategory
+			categorySet = true
+		}
+
+		if m.rule.SetDescription != "" && !descriptionSet {
+			transaction.Description = m.rule.SetDescription
+			descriptionSet = true
+		}
+
+		transaction.Tags = addTags(transaction.Tags, m.rule.AddTags)
+
+		if m.rule.StopProcessing {
+			break
+		}
+	}
+	return matched
+}
+
+func addTags(tags []int64, added []int64) []int64 {
+	result := tags
+	for _, tag := range added {
+		present := false
+		for _, existing := range result {
+			if existing == tag {
+				present = true
+				break
+			}
+		}
+		if !present {
+			result = append(result[:len(result):len(result)], tag)
+		}
+	}
+	return result
+}
+
+func compile(rule *Rule) (*matcher, error) {
+	m := &matcher{rule: rule, contains: normalize(rule.DescriptionContains)}
+
+	if rule.DescriptionRegex != "" {
+		regex, err := regexp.Compile("(?i)" + rule.DescriptionRegex)
+		if err != nil {
+			return nil, errors.NewValidationError("descriptionRegex", "invalid regular expression: "+err.Error())
+		}
+		m.regex = regex
+	}
+
+	for _, day := range rule.Weekdays {
+		if day < 0 || day > 6 {
+			return nil, errors.NewValidationError("weekdays", "weekdays must be between 0 (Sunday) and 6 (Saturday)")
+		}
+	}
+	m.weekdays = weekdayMask(rule.Weekdays)
+	return m, nil
+}
+
+func (m *matcher) matches(transaction *transactions.Transaction) bool {
+	rule := m.rule
+	if rule.AccountID != 0 && transaction.AccountID != rule.AccountID {
+		return false
+	}
+
+	if m.contains != "" && !strings.Contains(normalize(transaction.Description), m.contains) {
+		return false
+	}
+
+	if m.regex != nil && !m.regex.MatchString(transaction.Description) {
+		return false
+	}
+
+	amount := transaction.Amount.Abs()
+	if rule.AmountMin != nil && amount.Cmp(*rule.AmountMin) < 0 {
+		return false
+	}
+
+	if rule.AmountMax != nil && amount.Cmp(*rule.AmountMax) > 0 {
+		return false
+	}
+
+	if m.weekdays != 0 && m.weekdays&(1<<uint(transaction.OccurredAt.Weekday())) == 0 {
+		return false
+	}
+	return true
+}
+
+func weekdayMask(weekdays []int) uint8 {
+	var mask uint8
+	for _, day := range weekdays {
+		mask |= 1 << uint(day)
+	}
+	return mask
+}
+
+func weekdaysFromMask(mask uint8) []int {
+	var weekdays []int
+	for day := 0; day < 7; day++ {
+		if mask&(1<<uint(day)) != 0 {
+			weekdays = append(weekdays, day)
+		}
+	}
+	return weekdays
+}
+
+func normalize(value string) string {
+	return strings.Join(strings.Fields(strings.ToLower(utils.RemoveAccents(value))), " ")
+}
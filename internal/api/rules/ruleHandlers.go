@@ -0,0 +1,228 @@
+package rules
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/middlewares"
+	"github.com/Renan-Parise/finances/pkg/money"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RuleHandler struct {
+	ruleUseCase RuleUseCase
+}
+
+func NewRuleHandler(router *gin.RouterGroup, ru RuleUseCase) {
+	handler := &RuleHandler{
+		ruleUseCase: ru,
+	}
+
+	rules := router.Group("/rules")
+	rules.Use(middlewares.JWTAuthMiddleware())
+	{
+		rules.POST("/", handler.CreateRule)
+		rules.GET("/", handler.GetRules)
+		rules.PUT("/:id", handler.UpdateRule)
+		rules.DELETE("/:id", handler.DeleteRule)
+		rules.POST("/test", handler.TestRule)
+		rules.POST("/apply", handler.ApplyRules)
+	}
+}
+
+type ruleInput struct {
+	Name                string       `json:"name"`
+	Priority            int          `json:"priority"`
+	Enabled             *bool        `json:"enabled"`
+	StopProcessing      bool         `json:"stopProcessing"`
+	DescriptionContains string       `json:"descriptionContains"`
+	DescriptionRegex    string       `json:"descriptionRegex"`
+	AmountMin           *money.Money `json:"amountMin"`
+	AmountMax           *money.Money `json:"amountMax"`
+	AccountID           int64        `json:"accountId"`
+	Weekdays            []int        `json:"weekdays"`
+	SetCategory         int          `json:"setCategory"`
+	SetDescription      string       `json:"setDescription"`
+	AddTags             []int64      `json:"addTags"`
+}
+
+func (input *ruleInput) toRule(userID int64) *Rule {
+	rule := NewRule(userID, input.Name)
+	rule.Priority = input.Priority
+	rule.StopProcessing = input.StopProcessing
+	rule.DescriptionContains = input.DescriptionContains
+	rule.DescriptionRegex = input.DescriptionRegex
+	rule.AmountMin = input.AmountMin
+	rule.AmountMax = input.AmountMax
+	rule.AccountID = input.AccountID
+	rule.Weekdays = input.Weekdays
+	rule.SetCategory = input.SetCategory
+	rule.SetDescription = input.SetDescription
+	rule.AddTags = input.AddTags
+	if input.Enabled != nil {
+		rule.Enabled = *input.Enabled
+	}
+	return rule
+}
+
+func (h *RuleHandler) CreateRule(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	var input ruleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule := input.toRule(userID.(int64))
+	if err := h.ruleUseCase.CreateRule(rule); err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+func (h *RuleHandler) GetRules(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	rules, err := h.ruleUseCase.GetRules(userID.(int64))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+func (h *RuleHandler) UpdateRule(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule ID"})
+		return
+	}
+
+	var input ruleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule := input.toRule(userID.(int64))
+	rule.ID = id
+	if err := h.ruleUseCase.UpdateRule(rule); err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Rule updated successfully"})
+}
+
+func (h *RuleHandler) DeleteRule(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule ID"})
+		return
+	}
+
+	if err := h.ruleUseCase.DeleteRule(userID.(int64), id); err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Rule deleted successfully"})
+}
+
+// TestRule previews a rule, usually one still being edited, against the user's
+// stored transactions.
+func (h *RuleHandler) TestRule(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	var input struct {
+		Rule ruleInput `json:"rule"`
+		Range
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.ruleUseCase.TestRule(input.Rule.toRule(userID.(int64)), &input.Range)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *RuleHandler) ApplyRules(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	var input struct {
+		Range
+		DryRun bool `json:"dryRun"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.ruleUseCase.ApplyRules(userID.(int64), &input.Range, input.DryRun)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
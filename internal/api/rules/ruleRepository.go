@@ -0,0 +1,283 @@
+package rules
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/ledger"
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+type RuleRepository interface {
+	Create(rule *Rule) error
+	GetAll(userID int64) ([]*Rule, error)
+	GetByID(userID int64, id int64) (*Rule, error)
+	Update(rule *Rule) error
+	Delete(userID int64, id int64) error
+	ApplyChanges(userID int64, changes []*Change) error
+}
+
+const ruleColumns = `id, userId, name, priority, enabled, stopProcessing, COALESCE(descriptionContains, ''),
+              COALESCE(descriptionRegex, ''), amountMin, amountMax, COALESCE(accountId, 0), weekdays,
+              COALESCE(setCategory, 0), COALESCE(setDescription, ''), createdAt, updatedAt`
+
+type ruleRepository struct {
+	db *sql.DB
+}
+
+func NewRuleRepository(db *sql.DB) RuleRepository {
+	return &ruleRepository{db: db}
+}
+
+func (r *ruleRepository) Create(rule *Rule) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return errors.NewDatabaseError("error starting transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO rules (userId, name, priority, enabled, stopProcessing, descriptionContains, descriptionRegex,
+              amountMin, amountMax, accountId, weekdays, setCategory, setDescription, createdAt, updatedAt)
+              VALUES (?, ?, ?, ?, ?, NULLIF(?, ''), NULLIF(?, ''), ?, ?, NULLIF(?, 0), ?, NULLIF(?, 0), NULLIF(?, ''), ?, ?)`
+	res, err := tx.Exec(query, rule.UserID, rule.Name, rule.Priority, rule.Enabled, rule.StopProcessing,
+		rule.DescriptionContains, rule.DescriptionRegex, rule.AmountMin, rule.AmountMax, rule.AccountID,
+		weekdayMask(rule.Weekdays), rule.SetCategory, rule.SetDescription, rule.CreatedAt, rule.UpdatedAt)
+	if err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return errors.NewQueryError("error getting last insert ID: " + err.Error())
+	}
+
+	if err := insertRuleTags(tx, id, rule.AddTags); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+
+	rule.ID = id
+	return nil
+}
+
+func (r *ruleRepository) GetAll(userID int64) ([]*Rule, error) {
+	query := `SELECT ` + ruleColumns + ` FROM rules WHERE userId = ? ORDER BY priority, id`
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	var rules []*Rule
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		rules = append(rules, rule)
+	}
+
+	err = r.loadTags(rules, `SELECT rt.ruleId, rt.tagId FROM rule_tags rt JOIN rules r ON r.id = rt.ruleId
+              WHERE r.userId = ? ORDER BY rt.tagId`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *ruleRepository) GetByID(userID int64, id int64) (*Rule, error) {
+	query := `SELECT ` + ruleColumns + ` FROM rules WHERE id = ? AND userId = ?`
+	rule, err := scanRule(r.db.QueryRow(query, id, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.NewQueryError("error scanning row: " + err.Error())
+	}
+
+	if err := r.loadTags([]*Rule{rule}, `SELECT ruleId, tagId FROM rule_tags WHERE ruleId = ? ORDER BY tagId`, id); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (r *ruleRepository) Update(rule *Rule) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return errors.NewDatabaseError("error starting transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE rules SET name = ?, priority = ?, enabled = ?, stopProcessing = ?, descriptionContains = NULLIF(?, ''),
+              descriptionRegex = NULLIF(?, ''), amountMin = ?, amountMax = ?, accountId = NULLIF(?, 0), weekdays = ?,
+              setCategory = NULLIF(?, 0), setDescription = NULLIF(?, ''), updatedAt = ?
+              WHERE id = ? AND userId = ?`
+	_, err = tx.Exec(query, rule.Name, rule.Priority, rule.Enabled, rule.StopProcessing, rule.DescriptionContains,
+		rule.DescriptionRegex, rule.AmountMin, rule.AmountMax, rule.AccountID, weekdayMask(rule.Weekdays),
+		rule.SetCategory, rule.SetDescription, rule.UpdatedAt, rule.ID, rule.UserID)
+	if err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+
+	if _, err := tx.Exec(`DELETE FROM rule_tags WHERE ruleId = ?`, rule.ID); err != nil {
+		return errors.NewQueryError("error deleting rule tags: " + err.Error())
+	}
+
+	if err := insertRuleTags(tx, rule.ID, rule.AddTags); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+	return nil
+}
+
+func (r *ruleRepository) Delete(userID int64, id int64) error {
+	query := `DELETE FROM rules WHERE id = ? AND userId = ?`
+	if _, err := r.db.Exec(query, id, userID); err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+	return nil
+}
+
+func (r *ruleRepository) ApplyChanges(userID int64, changes []*Change) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return errors.NewDatabaseError("error starting transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`UPDATE transactions SET description = ?, category = NULLIF(?, 0), updatedAt = ?
+              WHERE id = ? AND userId = ? AND locked = FALSE AND transferId IS NULL`)
+	if err != nil {
+		return errors.NewQueryError("error preparing query: " + err.Error())
+	}
+	defer stmt.Close()
+
+	tagStmt, err := tx.Prepare(`INSERT IGNORE INTO transaction_tags (transactionId, tagId) VALUES (?, ?)`)
+	if err != nil {
+		return errors.NewQueryError("error preparing query: " + err.Error())
+	}
+	defer tagStmt.Close()
+
+	now := time.Now()
+	for _, change := range changes {
+		description := change.Description
+		if change.NewDescription != "" {
+			description = change.NewDescription
+		}
+
+		category := change.Category
+		if change.NewCategory != 0 {
+			category = change.NewCategory
+		}
+
+		res, err := stmt.Exec(description, category, now, change.TransactionID, userID)
+		if err != nil {
+			return errors.NewQueryError("error updating transaction: " + err.Error())
+		}
+
+		updated, err := res.RowsAffected()
+		if err != nil {
+			return errors.NewQueryError("error updating transaction: " + err.Error())
+		}
+
+		if updated == 0 {
+			continue
+		}
+
+		if err := ledger.RefreshSearchText(tx, change.TransactionID); err != nil {
+			return err
+		}
+
+		for _, tag := range change.AddTags {
+			if _, err := tagStmt.Exec(change.TransactionID, tag); err != nil {
+				return errors.NewQueryError("error tagging transaction: " + err.Error())
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+	return nil
+}
+
+func (r *ruleRepository) loadTags(rules []*Rule, query string, args ...interface{}) error {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*Rule, len(rules))
+	for _, rule := range rules {
+		byID[rule.ID] = rule
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ruleID, tagID int64
+		if err := rows.Scan(&ruleID, &tagID); err != nil {
+			return errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		if rule, ok := byID[ruleID]; ok {
+			rule.AddTags = append(rule.AddTags, tagID)
+		}
+	}
+	return rows.Err()
+}
+
+func insertRuleTags(tx *sql.Tx, ruleID int64, tags []int64) error {
+	for _, tag := range tags {
+		if _, err := tx.Exec(`INSERT INTO rule_tags (ruleId, tagId) VALUES (?, ?)`, ruleID, tag); err != nil {
+			return errors.NewQueryError("error inserting rule tag: " + err.Error())
+		}
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRule(row rowScanner) (*Rule, error) {
+	var rule Rule
+	var amountMin, amountMax sql.NullString
+	var weekdays uint8
+	err := row.Scan(&rule.ID, &rule.UserID, &rule.Name, &rule.Priority, &rule.Enabled, &rule.StopProcessing,
+		&rule.DescriptionContains, &rule.DescriptionRegex, &amountMin, &amountMax, &rule.AccountID, &weekdays,
+		&rule.SetCategory, &rule.SetDescription, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule.AmountMin, err = parseBound(amountMin); err != nil {
+		return nil, err
+	}
+	if rule.AmountMax, err = parseBound(amountMax); err != nil {
+		return nil, err
+	}
+	rule.Weekdays = weekdaysFromMask(weekdays)
+	return &rule, nil
+}
+
+func parseBound(value sql.NullString) (*money.Money, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+
+	bound, err := money.Parse(value.String, "")
+	if err != nil {
+		return nil, errors.NewQueryError("error reading amount bound: " + err.Error())
+	}
+	return &bound, nil
+}
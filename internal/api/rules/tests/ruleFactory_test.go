@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/rules"
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bound(value string) *money.Money {
+	m := money.MustParse(value, "BRL")
+	return &m
+}
+
+func newTransaction(description, amount string, category int) *transactions.Transaction {
+	transaction := transactions.NewTransaction(1, 7, description, category, money.MustParse(amount, "BRL"))
+	transaction.OccurredAt = time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	return transaction
+}
+
+func TestEngineMatchesConditions(t *testing.T) {
+	cases := []struct {
+		name    string
+		rule    rules.Rule
+		matches bool
+	}{
+		{"contains ignores case and accents", rules.Rule{DescriptionContains: "padaria sao"}, true},
+		{"contains mismatch", rules.Rule{DescriptionContains: "mercado"}, false},
+		{"regex", rules.Rule{DescriptionRegex: `^PAG\*`}, true},
+		{"absolute amount in range", rules.Rule{AmountMin: bound("10"), AmountMax: bound("50")}, true},
+		{"amount above range", rules.Rule{AmountMax: bound("20")}, false},
+		{"account", rules.Rule{AccountID: 7}, true},
+		{"other account", rules.Rule{AccountID: 8}, false},
+		{"weekday", rules.Rule{Weekdays: []int{1, 2}}, true},
+		{"other weekday", rules.Rule{Weekdays: []int{0, 6}}, false},
+		{"all conditions must match", rules.Rule{DescriptionContains: "padaria", AccountID: 8}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := tc.rule
+			rule.ID = 1
+			rule.SetCategory = 5
+
+			engine, err := rules.NewEngine([]*rules.Rule{&rule})
+			require.NoError(t, err)
+
+			transaction := newTransaction("PAG*Padaria São João", "-23.50", 0)
+			matched := engine.Apply(transaction, false)
+			assert.Equal(t, tc.matches, len(matched) == 1)
+			assert.Equal(t, tc.matches, transaction.Category == 5)
+		})
+	}
+}
+
+func TestEngineAppliesRulesByPriority(t *testing.T) {
+	engine, err := rules.NewEngine([]*rules.Rule{
+		{ID: 1, Priority: 20, DescriptionContains: "uber", SetCategory: 3, SetDescription: "Transport"},
+		{ID: 2, Priority: 10, DescriptionContains: "uber eats", SetCategory: 4, SetDescription: "Uber Eats"},
+		{ID: 3, Priority: 30, DescriptionContains: "uber", SetCategory: 9},
+	})
+	require.NoError(t, err)
+
+	transaction := newTransaction("UBER EATS 123", "-40.00", 0)
+	assert.Equal(t, []int64{2, 1, 3}, engine.Apply(transaction, false))
+	assert.Equal(t, 4, transaction.Category)
+	assert.Equal(t, "Uber Eats", transaction.Description)
+}
+
+func TestEngineStopProcessing(t *testing.T) {
+	engine, err := rules.NewEngine([]*rules.Rule{
+		{ID: 1, Priority: 1, DescriptionContains: "uber", SetDescription: "Uber", StopProcessing: true},
+		{ID: 2, Priority: 2, DescriptionContains: "uber", SetCategory: 3},
+	})
+	require.NoError(t, err)
+
+	transaction := newTransaction("UBER TRIP", "-15.00", 0)
+	assert.Equal(t, []int64{1}, engine.Apply(transaction, false))
+	assert.Equal(t, 0, transaction.Category)
+	assert.Equal(t, "Uber", transaction.Description)
+}
+
+func TestEngineKeepsChosenCategory(t *testing.T) {
+	engine, err := rules.NewEngine([]*rules.Rule{{ID: 1, DescriptionContains: "uber", SetCategory: 3}})
+	require.NoError(t, err)
+
+	chosen := newTransaction("Uber", "-15.00", 8)
+	engine.Apply(chosen, false)
+	assert.Equal(t, 8, chosen.Category)
+
+	engine.Apply(chosen, true)
+	assert.Equal(t, 3, chosen.Category)
+
+	split := newTransaction("Uber", "-15.00", 8)
+	split.Splits = []*transactions.Split{{Category: 8}, {Category: 9}}
+	engine.Apply(split, true)
+	assert.Equal(t, 8, split.Category)
+
+	transfer := newTransaction("Uber", "-15.00", 8)
+	transfer.TransferID = 2
+	assert.Empty(t, engine.Apply(transfer, true))
+}
+
+func TestEngineAddsTagsOfEveryMatchingRule(t *testing.T) {
+	engine, err := rules.NewEngine([]*rules.Rule{
+		{ID: 1, Priority: 1, DescriptionContains: "uber", SetCategory: 3, AddTags: []int64{4}},
+		{ID: 2, Priority: 2, DescriptionContains: "uber", SetCategory: 9, AddTags: []int64{2, 4}},
+	})
+	require.NoError(t, err)
+
+	transaction := newTransaction("UBER TRIP", "-15.00", 0)
+	transaction.Tags = []int64{4}
+	assert.Equal(t, []int64{1, 2}, engine.Apply(transaction, false))
+	assert.Equal(t, 3, transaction.Category)
+	assert.Equal(t, []int64{4, 2}, transaction.Tags)
+}
+
+func TestNewEngineRejectsInvalidRules(t *testing.T) {
+	_, err := rules.NewEngine([]*rules.Rule{{DescriptionRegex: "(unclosed"}})
+	assert.True(t, errors.IsValidationError(err))
+
+	_, err = rules.NewEngine([]*rules.Rule{{Weekdays: []int{7}}})
+	assert.True(t, errors.IsValidationError(err))
+}
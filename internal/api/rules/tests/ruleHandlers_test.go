@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Renan-Parise/finances/internal/api/rules"
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRuleUseCase struct {
+	mock.Mock
+}
+
+func TestNewRuleHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.Default()
+	group := router.Group("/api")
+
+	mockUseCase := new(MockRuleUseCase)
+	rules.NewRuleHandler(group, mockUseCase)
+
+	routes := router.Routes()
+
+	expectedRoutes := []struct {
+		method string
+		path   string
+	}{
+		{"POST", "/api/rules/"},
+		{"GET", "/api/rules/"},
+		{"PUT", "/api/rules/:id"},
+		{"DELETE", "/api/rules/:id"},
+		{"POST", "/api/rules/test"},
+		{"POST", "/api/rules/apply"},
+	}
+
+	for _, expected := range expectedRoutes {
+		found := false
+		for _, route := range routes {
+			if route.Method == expected.method && route.Path == expected.path {
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, "Route %s %s not registered", expected.method, expected.path)
+	}
+}
+
+func (m *MockRuleUseCase) CreateRule(rule *rules.Rule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *MockRuleUseCase) GetRules(userID int64) ([]*rules.Rule, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]*rules.Rule), args.Error(1)
+}
+
+func (m *MockRuleUseCase) UpdateRule(rule *rules.Rule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *MockRuleUseCase) DeleteRule(userID int64, id int64) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
+
+func (m *MockRuleUseCase) TestRule(rule *rules.Rule, scope *rules.Range) (*rules.ApplyReport, error) {
+	args := m.Called(rule, scope)
+	return args.Get(0).(*rules.ApplyReport), args.Error(1)
+}
+
+func (m *MockRuleUseCase) ApplyRules(userID int64, scope *rules.Range, dryRun bool) (*rules.ApplyReport, error) {
+	args := m.Called(userID, scope, dryRun)
+	return args.Get(0).(*rules.ApplyReport), args.Error(1)
+}
+
+func (m *MockRuleUseCase) Enrich(userID int64, batch []*transactions.Transaction) error {
+	args := m.Called(userID, batch)
+	return args.Error(0)
+}
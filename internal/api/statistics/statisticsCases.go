@@ -1,10 +1,13 @@
 package statistics
 
 import (
-	"math"
+	"sort"
 	"time"
 
+	"github.com/Renan-Parise/finances/internal/api/currencies"
+	"github.com/Renan-Parise/finances/internal/errors"
 	"github.com/Renan-Parise/finances/internal/utils"
+	"github.com/Renan-Parise/finances/pkg/money"
 )
 
 type StatisticsUseCase interface {
@@ -14,27 +17,33 @@ type StatisticsUseCase interface {
 	GetGeneralStatistics(userID int64) (*GeneralStatistics, error)
 	GetHighestExpenseMonth(userID int64) (*MonthlyAmount, error)
 	GetHighestIncomeMonth(userID int64) (*MonthlyAmount, error)
-	GetSpendingHeatmap(userID int64) (map[string]float64, error)
+	GetSpendingHeatmap(userID int64) (map[string]money.Money, error)
+	GetTagSummaries(userID int64, from, to time.Time) ([]*TagSummary, error)
 }
 
 type statisticsUseCase struct {
 	statisticsRepo StatisticsRepository
+	currencyRepo   currencies.CurrencyRepository
+	rates          currencies.ExchangeRateProvider
 }
 
-func NewStatisticsUseCase(sr StatisticsRepository) StatisticsUseCase {
-	return &statisticsUseCase{statisticsRepo: sr}
+func NewStatisticsUseCase(sr StatisticsRepository, cr currencies.CurrencyRepository, rp currencies.ExchangeRateProvider) StatisticsUseCase {
+	return &statisticsUseCase{statisticsRepo: sr, currencyRepo: cr, rates: rp}
 }
 
 func (uc *statisticsUseCase) GetGeneralStatistics(userID int64) (*GeneralStatistics, error) {
-	totalIncome, err := uc.statisticsRepo.GetTotalIncome(userID)
+	baseCurrency, totals, err := uc.getDailyTotals(userID)
 	if err != nil {
 		return nil, err
 	}
-	totalExpenses, err := uc.statisticsRepo.GetTotalExpenses(userID)
-	if err != nil {
-		return nil, err
+
+	totalIncome := money.Zero(baseCurrency)
+	totalExpenses := money.Zero(baseCurrency)
+	for _, total := range totals {
+		totalIncome = totalIncome.Add(total.Income)
+		totalExpenses = totalExpenses.Add(total.Expenses)
 	}
-	balance := totalIncome + totalExpenses
+	balance := totalIncome.Add(totalExpenses)
 	mostUsedCategory, err := uc.statisticsRepo.GetMostUsedCategory(userID)
 	if err != nil {
 		return nil, err
@@ -44,11 +53,12 @@ func (uc *statisticsUseCase) GetGeneralStatistics(userID int64) (*GeneralStatist
 		TotalExpenses:    totalExpenses,
 		Balance:          balance,
 		MostUsedCategory: mostUsedCategory,
+		Currency:         baseCurrency,
 	}, nil
 }
 
 func (uc *statisticsUseCase) GetHighestExpenseMonth(userID int64) (*MonthlyAmount, error) {
-	expenses, err := uc.statisticsRepo.GetMonthlyExpenses(userID)
+	expenses, err := uc.getMonthlyExpenses(userID)
 	if err != nil {
 		return nil, err
 	}
@@ -59,7 +69,7 @@ func (uc *statisticsUseCase) GetHighestExpenseMonth(userID int64) (*MonthlyAmoun
 }
 
 func (uc *statisticsUseCase) GetHighestIncomeMonth(userID int64) (*MonthlyAmount, error) {
-	income, err := uc.statisticsRepo.GetMonthlyIncome(userID)
+	income, err := uc.getMonthlyIncome(userID)
 	if err != nil {
 		return nil, err
 	}
@@ -79,29 +89,49 @@ func (uc *statisticsUseCase) GetCategoryPercentageChanges(userID int64) ([]*Cate
 		previousYear -= 1
 	}
 
-	currentTotals, err := uc.statisticsRepo.GetCategoryMonthlyTotals(userID, int(currentMonth), currentYear)
+	baseCurrency, err := uc.currencyRepo.GetBaseCurrency(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := uc.statisticsRepo.GetCategoryMonthlyTotals(userID, int(currentMonth), currentYear)
 	if err != nil {
 		return nil, err
 	}
-	previousTotals, err := uc.statisticsRepo.GetCategoryMonthlyTotals(userID, int(previousMonth), previousYear)
+	currentTotals, err := uc.sumByCategory(current, baseCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := uc.statisticsRepo.GetCategoryMonthlyTotals(userID, int(previousMonth), previousYear)
+	if err != nil {
+		return nil, err
+	}
+	previousTotals, err := uc.sumByCategory(previous, baseCurrency)
 	if err != nil {
 		return nil, err
 	}
 
 	var changes []*CategoryPercentageChange
 	for name := range utils.MergeKeys(currentTotals, previousTotals) {
-		currentValue := currentTotals[name]
-		previousValue := previousTotals[name]
+		currentValue, ok := currentTotals[name]
+		if !ok {
+			currentValue = money.Zero(baseCurrency)
+		}
+		previousValue, ok := previousTotals[name]
+		if !ok {
+			previousValue = money.Zero(baseCurrency)
+		}
 		var percentageChange float64
 		var increase bool
 
-		if previousValue != 0 {
-			change := currentValue - previousValue
-			percentageChange = (change / math.Abs(previousValue)) * 100
-			increase = change > 0
+		if !previousValue.IsZero() {
+			change := currentValue.Sub(previousValue)
+			percentageChange = money.Percentage(change, previousValue.Abs())
+			increase = change.IsPositive()
 		} else {
 			percentageChange = 100.0
-			increase = currentValue > 0
+			increase = currentValue.IsPositive()
 		}
 
 		changes = append(changes, &CategoryPercentageChange{
@@ -116,14 +146,214 @@ func (uc *statisticsUseCase) GetCategoryPercentageChanges(userID int64) ([]*Cate
 	return changes, nil
 }
 
-func (uc *statisticsUseCase) GetSpendingHeatmap(userID int64) (map[string]float64, error) {
-	return uc.statisticsRepo.GetSpendingHeatmap(userID)
+func (uc *statisticsUseCase) GetSpendingHeatmap(userID int64) (map[string]money.Money, error) {
+	baseCurrency, err := uc.currencyRepo.GetBaseCurrency(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := uc.statisticsRepo.GetSpendingHeatmap(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	heatmap := make(map[string]money.Money)
+	converter := currencies.NewConverter(uc.rates)
+	for _, total := range totals {
+		expenses, err := converter.Convert(total.Expenses, baseCurrency, total.Date)
+		if err != nil {
+			return nil, err
+		}
+
+		day := total.Date.Format("2006-01-02")
+		if sum, ok := heatmap[day]; ok {
+			expenses = sum.Add(expenses)
+		}
+		heatmap[day] = expenses
+	}
+	return heatmap, nil
 }
 
 func (uc *statisticsUseCase) GetMonthlyExpensesSummary(userID int64) ([]*MonthlyAmount, error) {
-	return uc.statisticsRepo.GetMonthlyExpenses(userID)
+	return uc.getMonthlyExpenses(userID)
 }
 
 func (uc *statisticsUseCase) GetExpensesByCategory(userID int64) ([]*ExpenseCategorySummary, error) {
-	return uc.statisticsRepo.GetExpensesByCategory(userID)
+	baseCurrency, err := uc.currencyRepo.GetBaseCurrency(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	expenses, err := uc.statisticsRepo.GetExpensesByCategory(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := uc.sumByCategory(expenses, baseCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []*ExpenseCategorySummary{}
+	totalSum := money.Zero(baseCurrency)
+	for name, total := range totals {
+		totalSum = totalSum.Add(total)
+		results = append(results, &ExpenseCategorySummary{
+			CategoryName: name,
+			TotalAmount:  total,
+		})
+	}
+
+	for _, result := range results {
+		result.Percentage = money.Percentage(result.TotalAmount, totalSum)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if c := results[i].TotalAmount.Cmp(results[j].TotalAmount); c != 0 {
+			return c > 0
+		}
+		return results[i].CategoryName < results[j].CategoryName
+	})
+	return results, nil
+}
+
+func (uc *statisticsUseCase) GetTagSummaries(userID int64, from, to time.Time) ([]*TagSummary, error) {
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return nil, errors.NewValidationError("to", "to must not be before from")
+	}
+
+	baseCurrency, err := uc.currencyRepo.GetBaseCurrency(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := uc.statisticsRepo.GetTagDailyTotals(userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := []*TagSummary{}
+	byTag := make(map[int64]*TagSummary)
+	converter := currencies.NewConverter(uc.rates)
+	for _, total := range totals {
+		income, err := converter.Convert(total.Income, baseCurrency, total.Date)
+		if err != nil {
+			return nil, err
+		}
+		expenses, err := converter.Convert(total.Expenses, baseCurrency, total.Date)
+		if err != nil {
+			return nil, err
+		}
+
+		summary, ok := byTag[total.TagID]
+		if !ok {
+			summary = &TagSummary{
+				TagID:    total.TagID,
+				TagName:  total.TagName,
+				Income:   money.Zero(baseCurrency),
+				Expenses: money.Zero(baseCurrency),
+				Currency: baseCurrency,
+			}
+			byTag[total.TagID] = summary
+			summaries = append(summaries, summary)
+		}
+		summary.Count += total.Count
+		summary.Income = summary.Income.Add(income)
+		summary.Expenses = summary.Expenses.Add(expenses)
+	}
+
+	for _, summary := range summaries {
+		summary.Balance = summary.Income.Add(summary.Expenses)
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if c := summaries[i].Expenses.Cmp(summaries[j].Expenses); c != 0 {
+			return c < 0
+		}
+		return summaries[i].TagName < summaries[j].TagName
+	})
+	return summaries, nil
+}
+
+func (uc *statisticsUseCase) getDailyTotals(userID int64) (string, []*DailyTotal, error) {
+	baseCurrency, err := uc.currencyRepo.GetBaseCurrency(userID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	totals, err := uc.statisticsRepo.GetDailyTotals(userID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	converter := currencies.NewConverter(uc.rates)
+	for _, total := range totals {
+		if total.Income, err = converter.Convert(total.Income, baseCurrency, total.Date); err != nil {
+			return "", nil, err
+		}
+		if total.Expenses, err = converter.Convert(total.Expenses, baseCurrency, total.Date); err != nil {
+			return "", nil, err
+		}
+		total.Currency = baseCurrency
+	}
+	return baseCurrency, totals, nil
+}
+
+func (uc *statisticsUseCase) sumByCategory(totals []*CategoryTotal, baseCurrency string) (map[string]money.Money, error) {
+	sums := make(map[string]money.Money)
+	converter := currencies.NewConverter(uc.rates)
+	for _, total := range totals {
+		converted, err := converter.Convert(total.Total, baseCurrency, total.Date)
+		if err != nil {
+			return nil, err
+		}
+
+		if sum, ok := sums[total.Category]; ok {
+			converted = sum.Add(converted)
+		}
+		sums[total.Category] = converted
+	}
+	return sums, nil
+}
+
+func (uc *statisticsUseCase) getMonthlyExpenses(userID int64) ([]*MonthlyAmount, error) {
+	return uc.getMonthlyTotals(userID, func(total *DailyTotal) money.Money {
+		return total.Expenses.Abs()
+	})
+}
+
+func (uc *statisticsUseCase) getMonthlyIncome(userID int64) ([]*MonthlyAmount, error) {
+	return uc.getMonthlyTotals(userID, func(total *DailyTotal) money.Money {
+		return total.Income
+	})
+}
+
+func (uc *statisticsUseCase) getMonthlyTotals(userID int64, amount func(*DailyTotal) money.Money) ([]*MonthlyAmount, error) {
+	_, totals, err := uc.getDailyTotals(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*MonthlyAmount
+	months := make(map[[2]int]*MonthlyAmount)
+	for _, total := range totals {
+		value := amount(total)
+		if value.IsZero() {
+			continue
+		}
+
+		key := [2]int{total.Date.Year(), int(total.Date.Month())}
+		month, ok := months[key]
+		if !ok {
+			month = &MonthlyAmount{Year: key[0], Month: key[1], Total: money.Zero(value.Currency)}
+			months[key] = month
+			results = append(results, month)
+		}
+		month.Total = month.Total.Add(value)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Total.Cmp(results[j].Total) > 0
+	})
+	return results, nil
 }
@@ -1,28 +1,68 @@
 package statistics
 
+import (
+	"time"
+
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
 type GeneralStatistics struct {
-	TotalIncome      float64 `json:"totalIncome"`
-	TotalExpenses    float64 `json:"totalExpenses"`
-	Balance          float64 `json:"balance"`
-	MostUsedCategory string  `json:"mostUsedCategory"`
+	TotalIncome      money.Money `json:"totalIncome"`
+	TotalExpenses    money.Money `json:"totalExpenses"`
+	Balance          money.Money `json:"balance"`
+	MostUsedCategory string      `json:"mostUsedCategory"`
+	Currency         string      `json:"currency"`
 }
 
 type MonthlyAmount struct {
-	Year  int     `json:"year"`
-	Month int     `json:"month"`
-	Total float64 `json:"total"`
+	Year  int         `json:"year"`
+	Month int         `json:"month"`
+	Total money.Money `json:"total"`
+}
+
+type DailyTotal struct {
+	Date     time.Time
+	Currency string
+	Income   money.Money
+	Expenses money.Money
+}
+
+// CategoryTotal is what one category added up to on one day in one currency.
+type CategoryTotal struct {
+	Category string
+	Date     time.Time
+	Currency string
+	Total    money.Money
 }
 
 type CategoryPercentageChange struct {
-	CategoryName     string  `json:"categoryName"`
-	PreviousValue    float64 `json:"previousValue"`
-	CurrentValue     float64 `json:"currentValue"`
-	PercentageChange float64 `json:"percentageChange"`
-	Increase         bool    `json:"increase"`
+	CategoryName     string      `json:"categoryName"`
+	PreviousValue    money.Money `json:"previousValue"`
+	CurrentValue     money.Money `json:"currentValue"`
+	PercentageChange float64     `json:"percentageChange"`
+	Increase         bool        `json:"increase"`
 }
 
 type ExpenseCategorySummary struct {
-	CategoryName string  `json:"categoryName"`
-	TotalAmount  float64 `json:"totalAmount"`
-	Percentage   float64 `json:"percentage"`
+	CategoryName string      `json:"categoryName"`
+	TotalAmount  money.Money `json:"totalAmount"`
+	Percentage   float64     `json:"percentage"`
+}
+
+// TagDailyTotal is a DailyTotal for the transactions carrying one tag.
+type TagDailyTotal struct {
+	DailyTotal
+	TagID   int64
+	TagName string
+	Count   int
+}
+
+type TagSummary struct {
+	TagID    int64       `json:"tagId"`
+	TagName  string      `json:"tagName"`
+	Count    int         `json:"count"`
+	Income   money.Money `json:"income"`
+	Expenses money.Money `json:"expenses"`
+	Balance  money.Money `json:"balance"`
+	Currency string      `json:"currency"`
 }
@@ -2,7 +2,9 @@ package statistics
 
 import (
 	"net/http"
+	"time"
 
+	"github.com/Renan-Parise/finances/internal/errors"
 	"github.com/Renan-Parise/finances/internal/middlewares"
 
 	"github.com/gin-gonic/gin"
@@ -28,6 +30,7 @@ func NewStatisticsHandler(router *gin.RouterGroup, su StatisticsUseCase) {
 		statistics.GET("/highest-incomes", handler.GetHighestIncomeMonth)
 		statistics.GET("/spending-heatmap", handler.GetSpendingHeatmap)
 		statistics.GET("/general", handler.GetGeneralStatistics)
+		statistics.GET("/tags", handler.GetTagSummaries)
 	}
 }
 
@@ -152,3 +155,40 @@ func (h *StatisticsHandler) GetExpensesByCategory(c *gin.Context) {
 
 	c.JSON(http.StatusOK, summary)
 }
+
+// GetTagSummaries takes an optional from and to, both YYYY-MM-DD and
+// inclusive.
+func (h *StatisticsHandler) GetTagSummaries(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	var from, to time.Time
+	var err error
+	if value := c.Query("from"); value != "" {
+		if from, err = time.Parse("2006-01-02", value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date, expected YYYY-MM-DD"})
+			return
+		}
+	}
+	if value := c.Query("to"); value != "" {
+		if to, err = time.Parse("2006-01-02", value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date, expected YYYY-MM-DD"})
+			return
+		}
+	}
+
+	summaries, err := h.statisticsUseCase.GetTagSummaries(userID.(int64), from, to)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, summaries)
+}
@@ -2,22 +2,29 @@ package statistics
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/pkg/money"
 )
 
 type StatisticsRepository interface {
-	GetCategoryMonthlyTotals(userID int64, month, year int) (map[string]float64, error)
-	GetExpensesByCategory(userID int64) ([]*ExpenseCategorySummary, error)
-	GetMonthlyExpensesSummary(userID int64) ([]*MonthlyAmount, error)
-	GetMonthlyExpenses(userID int64) ([]*MonthlyAmount, error)
-	GetMonthlyIncome(userID int64) ([]*MonthlyAmount, error)
-	GetSpendingHeatmap(userID int64) (map[string]float64, error)
+	GetCategoryMonthlyTotals(userID int64, month, year int) ([]*CategoryTotal, error)
+	GetExpensesByCategory(userID int64) ([]*CategoryTotal, error)
+	GetSpendingHeatmap(userID int64) ([]*DailyTotal, error)
 	GetMostUsedCategory(userID int64) (string, error)
-	GetTotalExpenses(userID int64) (float64, error)
-	GetTotalIncome(userID int64) (float64, error)
+	GetDailyTotals(userID int64) ([]*DailyTotal, error)
+	GetTagDailyTotals(userID int64, from, to time.Time) ([]*TagDailyTotal, error)
 }
 
+const categoryLines = `(
+		SELECT tx.userId, tx.occurredAt, tx.transferId, tx.currency,
+			COALESCE(s.category, tx.category) AS category,
+			COALESCE(s.amount, tx.amount) AS amount
+		FROM transactions tx
+		LEFT JOIN transaction_splits s ON s.transactionId = tx.id
+	)`
+
 type statisticsRepository struct {
 	db *sql.DB
 }
@@ -26,178 +33,152 @@ func NewStatisticsRepository(db *sql.DB) StatisticsRepository {
 	return &statisticsRepository{db: db}
 }
 
-func (r *statisticsRepository) GetTotalIncome(userID int64) (float64, error) {
-	query := `SELECT COALESCE(SUM(amount), 0) FROM transactions WHERE userId = ? AND amount > 0`
-	var totalIncome float64
-	err := r.db.QueryRow(query, userID).Scan(&totalIncome)
-	return totalIncome, err
-}
-
-func (r *statisticsRepository) GetTotalExpenses(userID int64) (float64, error) {
-	query := `SELECT COALESCE(SUM(amount), 0) FROM transactions WHERE userId = ? AND amount < 0`
-	var totalExpenses float64
-	err := r.db.QueryRow(query, userID).Scan(&totalExpenses)
-	return totalExpenses, err
-}
-
-func (r *statisticsRepository) GetMostUsedCategory(userID int64) (string, error) {
+func (r *statisticsRepository) GetDailyTotals(userID int64) ([]*DailyTotal, error) {
 	query := `
-		SELECT c.name, COUNT(*) AS usage_count
-		FROM transactions t
-		JOIN categories c ON t.category = c.id
-		WHERE t.userId = ?
-		GROUP BY t.category
-		ORDER BY usage_count DESC
-		LIMIT 1
-	`
-	var categoryName string
-	var usageCount int
-
-	err := r.db.QueryRow(query, userID).Scan(&categoryName, &usageCount)
-	return categoryName, err
-}
-
-func (r *statisticsRepository) GetMonthlyExpenses(userID int64) ([]*MonthlyAmount, error) {
-	query := `
-		SELECT YEAR(createdAt) as year, MONTH(createdAt) as month, ABS(SUM(amount)) as total
+		SELECT DATE(occurredAt) as day, currency,
+			COALESCE(SUM(CASE WHEN amount > 0 THEN amount END), 0) as income,
+			COALESCE(SUM(CASE WHEN amount < 0 THEN amount END), 0) as expenses
 		FROM transactions
-		WHERE userId = ? AND amount < 0
-		GROUP BY YEAR(createdAt), MONTH(createdAt)
-		ORDER BY total DESC
+		WHERE userId = ? AND transferId IS NULL
+		GROUP BY DATE(occurredAt), currency
+		ORDER BY day ASC
 	`
 	rows, err := r.db.Query(query, userID)
 	if err != nil {
-		return nil, errors.NewQueryError("Failed to get monthly expenses: " + err.Error())
+		return nil, errors.NewQueryError("Failed to get daily totals: " + err.Error())
 	}
 	defer rows.Close()
 
-	var results []*MonthlyAmount
+	var results []*DailyTotal
 	for rows.Next() {
-		var ma MonthlyAmount
-		err := rows.Scan(&ma.Year, &ma.Month, &ma.Total)
+		var dt DailyTotal
+		err := rows.Scan(&dt.Date, &dt.Currency, &dt.Income, &dt.Expenses)
 		if err != nil {
-			return nil, errors.NewQueryError("Failed to scan monthly expenses: " + err.Error())
+			return nil, errors.NewQueryError("Failed to scan daily totals: " + err.Error())
 		}
-		results = append(results, &ma)
+		dt.Income.Currency = dt.Currency
+		dt.Expenses.Currency = dt.Currency
+		results = append(results, &dt)
 	}
 	return results, nil
 }
 
-func (r *statisticsRepository) GetMonthlyIncome(userID int64) ([]*MonthlyAmount, error) {
+func (r *statisticsRepository) GetTagDailyTotals(userID int64, from, to time.Time) ([]*TagDailyTotal, error) {
 	query := `
-		SELECT YEAR(createdAt) as year, MONTH(createdAt) as month, SUM(amount) as total
-		FROM transactions
-		WHERE userId = ? AND amount > 0
-		GROUP BY YEAR(createdAt), MONTH(createdAt)
-		ORDER BY total DESC
+		SELECT tg.id, tg.name, DATE(t.occurredAt) as day, t.currency, COUNT(*),
+			COALESCE(SUM(CASE WHEN t.amount > 0 THEN t.amount END), 0) as income,
+			COALESCE(SUM(CASE WHEN t.amount < 0 THEN t.amount END), 0) as expenses
+		FROM transactions t
+		JOIN transaction_tags tt ON tt.transactionId = t.id
+		JOIN tags tg ON tg.id = tt.tagId
+		WHERE t.userId = ? AND t.transferId IS NULL`
+	args := []interface{}{userID}
+	if !from.IsZero() {
+		query += ` AND t.occurredAt >= ?`
+		args = append(args, from.Format("2006-01-02"))
+	}
+	if !to.IsZero() {
+		query += ` AND t.occurredAt < ?`
+		args = append(args, to.AddDate(0, 0, 1).Format("2006-01-02"))
+	}
+	query += `
+		GROUP BY tg.id, tg.name, DATE(t.occurredAt), t.currency
+		ORDER BY day ASC
 	`
-	rows, err := r.db.Query(query, userID)
+
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
-		return nil, errors.NewQueryError("Failed to get monthly income: " + err.Error())
+		return nil, errors.NewQueryError("Failed to get tag totals: " + err.Error())
 	}
 	defer rows.Close()
 
-	var results []*MonthlyAmount
+	var results []*TagDailyTotal
 	for rows.Next() {
-		var ma MonthlyAmount
-		err := rows.Scan(&ma.Year, &ma.Month, &ma.Total)
+		var tt TagDailyTotal
+		err := rows.Scan(&tt.TagID, &tt.TagName, &tt.Date, &tt.Currency, &tt.Count, &tt.Income, &tt.Expenses)
 		if err != nil {
-			return nil, errors.NewQueryError("Failed to scan monthly income: " + err.Error())
+			return nil, errors.NewQueryError("Failed to scan tag totals: " + err.Error())
 		}
-		results = append(results, &ma)
+		tt.Income.Currency = tt.Currency
+		tt.Expenses.Currency = tt.Currency
+		results = append(results, &tt)
 	}
 	return results, nil
 }
 
-func (r *statisticsRepository) GetCategoryMonthlyTotals(userID int64, month, year int) (map[string]float64, error) {
+func (r *statisticsRepository) GetMostUsedCategory(userID int64) (string, error) {
 	query := `
-		SELECT c.name, SUM(t.amount) as total
-		FROM transactions t
+		SELECT c.name, COUNT(*) AS usage_count
+		FROM ` + categoryLines + ` t
 		JOIN categories c ON t.category = c.id
-		WHERE t.userId = ? AND MONTH(t.createdAt) = ? AND YEAR(t.createdAt) = ?
-		GROUP BY c.name
+		WHERE t.userId = ? AND t.transferId IS NULL
+		GROUP BY t.category
+		ORDER BY usage_count DESC
+		LIMIT 1
 	`
-	rows, err := r.db.Query(query, userID, month, year)
-	if err != nil {
-		return nil, errors.NewQueryError("Failed to get category monthly totals: " + err.Error())
-	}
-	defer rows.Close()
+	var categoryName string
+	var usageCount int
 
-	totals := make(map[string]float64)
-	for rows.Next() {
-		var categoryName string
-		var total float64
-		err := rows.Scan(&categoryName, &total)
-		if err != nil {
-			return nil, errors.NewQueryError("Failed to scan category monthly totals: " + err.Error())
-		}
-		totals[categoryName] = total
-	}
-	return totals, nil
+	err := r.db.QueryRow(query, userID).Scan(&categoryName, &usageCount)
+	return categoryName, err
 }
 
-func (r *statisticsRepository) GetSpendingHeatmap(userID int64) (map[string]float64, error) {
+func (r *statisticsRepository) GetCategoryMonthlyTotals(userID int64, month, year int) ([]*CategoryTotal, error) {
 	query := `
-		SELECT DATE(createdAt) as day, ABS(SUM(amount)) as total
-		FROM transactions
-		WHERE userId = ? AND amount < 0 AND createdAt >= DATE_SUB(CURRENT_DATE, INTERVAL 11 MONTH)
-		GROUP BY DATE(createdAt)
-		ORDER BY day ASC
+		SELECT c.name, DATE(t.occurredAt) as day, t.currency, SUM(t.amount) as total
+		FROM ` + categoryLines + ` t
+		JOIN categories c ON t.category = c.id
+		WHERE t.userId = ? AND t.transferId IS NULL AND MONTH(t.occurredAt) = ? AND YEAR(t.occurredAt) = ?
+		GROUP BY c.name, DATE(t.occurredAt), t.currency
 	`
-	rows, err := r.db.Query(query, userID)
+	rows, err := r.db.Query(query, userID, month, year)
 	if err != nil {
-		return nil, errors.NewQueryError("Failed to get spending heatmap: " + err.Error())
+		return nil, errors.NewQueryError("Failed to get category monthly totals: " + err.Error())
 	}
 	defer rows.Close()
 
-	heatmap := make(map[string]float64)
-	for rows.Next() {
-		var day string
-		var total float64
-		err := rows.Scan(&day, &total)
-		if err != nil {
-			return nil, errors.NewQueryError("Failed to scan heatmap data: " + err.Error())
-		}
-		heatmap[day] = total
+	results, err := scanCategoryTotals(rows)
+	if err != nil {
+		return nil, errors.NewQueryError("Failed to scan category monthly totals: " + err.Error())
 	}
-	return heatmap, nil
+	return results, nil
 }
 
-func (r *statisticsRepository) GetMonthlyExpensesSummary(userID int64) ([]*MonthlyAmount, error) {
+func (r *statisticsRepository) GetSpendingHeatmap(userID int64) ([]*DailyTotal, error) {
 	query := `
-		SELECT YEAR(createdAt) as year, MONTH(createdAt) as month, ABS(SUM(amount)) as total
+		SELECT DATE(occurredAt) as day, currency, ABS(SUM(amount)) as total
 		FROM transactions
-		WHERE userId = ? AND amount < 0
-		GROUP BY YEAR(createdAt), MONTH(createdAt)
-		ORDER BY year DESC, month DESC
-		LIMIT 12
+		WHERE userId = ? AND amount < 0 AND transferId IS NULL AND occurredAt >= DATE_SUB(CURRENT_DATE, INTERVAL 11 MONTH)
+		GROUP BY DATE(occurredAt), currency
+		ORDER BY day ASC
 	`
 	rows, err := r.db.Query(query, userID)
 	if err != nil {
-		return nil, errors.NewQueryError("Failed to get monthly expenses summary: " + err.Error())
+		return nil, errors.NewQueryError("Failed to get spending heatmap: " + err.Error())
 	}
 	defer rows.Close()
 
-	var results []*MonthlyAmount
+	var results []*DailyTotal
 	for rows.Next() {
-		var ma MonthlyAmount
-		err := rows.Scan(&ma.Year, &ma.Month, &ma.Total)
+		var dt DailyTotal
+		err := rows.Scan(&dt.Date, &dt.Currency, &dt.Expenses)
 		if err != nil {
-			return nil, errors.NewQueryError("Failed to scan monthly expenses summary: " + err.Error())
+			return nil, errors.NewQueryError("Failed to scan heatmap data: " + err.Error())
 		}
-		results = append(results, &ma)
+		dt.Income = money.Zero(dt.Currency)
+		dt.Expenses.Currency = dt.Currency
+		results = append(results, &dt)
 	}
 	return results, nil
 }
 
-func (r *statisticsRepository) GetExpensesByCategory(userID int64) ([]*ExpenseCategorySummary, error) {
+func (r *statisticsRepository) GetExpensesByCategory(userID int64) ([]*CategoryTotal, error) {
 	query := `
-		SELECT c.name, ABS(SUM(t.amount)) as total
-		FROM transactions t
+		SELECT c.name, DATE(t.occurredAt) as day, t.currency, ABS(SUM(t.amount)) as total
+		FROM ` + categoryLines + ` t
 		JOIN categories c ON t.category = c.id
-		WHERE t.userId = ? AND t.amount < 0
-		GROUP BY c.name
-		ORDER BY total DESC
+		WHERE t.userId = ? AND t.amount < 0 AND t.transferId IS NULL
+		GROUP BY c.name, DATE(t.occurredAt), t.currency
 	`
 	rows, err := r.db.Query(query, userID)
 	if err != nil {
@@ -205,26 +186,22 @@ func (r *statisticsRepository) GetExpensesByCategory(userID int64) ([]*ExpenseCa
 	}
 	defer rows.Close()
 
-	var results []*ExpenseCategorySummary
-	var totalSum float64
+	results, err := scanCategoryTotals(rows)
+	if err != nil {
+		return nil, errors.NewQueryError("Failed to scan category expenses: " + err.Error())
+	}
+	return results, nil
+}
 
+func scanCategoryTotals(rows *sql.Rows) ([]*CategoryTotal, error) {
+	var results []*CategoryTotal
 	for rows.Next() {
-		var category string
-		var total float64
-		err := rows.Scan(&category, &total)
-		if err != nil {
-			return nil, errors.NewQueryError("Failed to scan category expenses: " + err.Error())
+		var ct CategoryTotal
+		if err := rows.Scan(&ct.Category, &ct.Date, &ct.Currency, &ct.Total); err != nil {
+			return nil, err
 		}
-		totalSum += total
-		results = append(results, &ExpenseCategorySummary{
-			CategoryName: category,
-			TotalAmount:  total,
-		})
+		ct.Total.Currency = ct.Currency
+		results = append(results, &ct)
 	}
-
-	for _, result := range results {
-		result.Percentage = (result.TotalAmount / totalSum) * 100
-	}
-
-	return results, nil
+	return results, rows.Err()
 }
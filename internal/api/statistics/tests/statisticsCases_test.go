@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/currencies"
+	"github.com/Renan-Parise/finances/internal/api/statistics"
+	"github.com/Renan-Parise/finances/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryStatisticsRepository struct {
+	statistics.StatisticsRepository
+	monthly  map[time.Month][]*statistics.CategoryTotal
+	expenses []*statistics.CategoryTotal
+	heatmap  []*statistics.DailyTotal
+}
+
+func (r *memoryStatisticsRepository) GetCategoryMonthlyTotals(userID int64, month, year int) ([]*statistics.CategoryTotal, error) {
+	return r.monthly[time.Month(month)], nil
+}
+
+func (r *memoryStatisticsRepository) GetExpensesByCategory(userID int64) ([]*statistics.CategoryTotal, error) {
+	return r.expenses, nil
+}
+
+func (r *memoryStatisticsRepository) GetSpendingHeatmap(userID int64) ([]*statistics.DailyTotal, error) {
+	return r.heatmap, nil
+}
+
+type baseCurrencyRepository struct {
+	currencies.CurrencyRepository
+}
+
+func (r *baseCurrencyRepository) GetBaseCurrency(userID int64) (string, error) {
+	return "BRL", nil
+}
+
+type fixedRates struct{}
+
+func (fixedRates) Rate(from, to string, date time.Time) (*big.Rat, error) {
+	return big.NewRat(5, 1), nil
+}
+
+func amount(t *testing.T, value, currency string) money.Money {
+	m, err := money.Parse(value, currency)
+	require.NoError(t, err)
+	return m
+}
+
+func categoryTotal(t *testing.T, category, value, currency string, date time.Time) *statistics.CategoryTotal {
+	return &statistics.CategoryTotal{Category: category, Date: date, Currency: currency, Total: amount(t, value, currency)}
+}
+
+func TestGetExpensesByCategoryConvertsCurrencies(t *testing.T) {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	repo := &memoryStatisticsRepository{expenses: []*statistics.CategoryTotal{
+		categoryTotal(t, "Food", "100.00", "BRL", day),
+		categoryTotal(t, "Food", "10.00", "USD", day),
+		categoryTotal(t, "Travel", "50.00", "USD", day.AddDate(0, 0, 1)),
+	}}
+	uc := statistics.NewStatisticsUseCase(repo, &baseCurrencyRepository{}, fixedRates{})
+
+	summaries, err := uc.GetExpensesByCategory(1)
+	require.NoError(t, err)
+	require.Len(t, summaries, 2)
+
+	assert.Equal(t, "Travel", summaries[0].CategoryName)
+	assert.Equal(t, amount(t, "250.00", "BRL"), summaries[0].TotalAmount)
+	assert.Equal(t, "Food", summaries[1].CategoryName)
+	assert.Equal(t, amount(t, "150.00", "BRL"), summaries[1].TotalAmount)
+	assert.InDelta(t, 62.5, summaries[0].Percentage, 0.01)
+}
+
+func TestGetCategoryPercentageChangesConvertsCurrencies(t *testing.T) {
+	now := time.Now()
+	previous := now.AddDate(0, 0, -now.Day())
+	repo := &memoryStatisticsRepository{monthly: map[time.Month][]*statistics.CategoryTotal{
+		now.Month(): {
+			categoryTotal(t, "Food", "-10.00", "USD", now),
+			categoryTotal(t, "Rent", "-1000.00", "BRL", now),
+		},
+		previous.Month(): {
+			categoryTotal(t, "Food", "-25.00", "BRL", previous),
+		},
+	}}
+	uc := statistics.NewStatisticsUseCase(repo, &baseCurrencyRepository{}, fixedRates{})
+
+	changes, err := uc.GetCategoryPercentageChanges(1)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+
+	byName := map[string]*statistics.CategoryPercentageChange{}
+	for _, change := range changes {
+		byName[change.CategoryName] = change
+	}
+	assert.Equal(t, amount(t, "-50.00", "BRL"), byName["Food"].CurrentValue)
+	assert.Equal(t, amount(t, "-25.00", "BRL"), byName["Food"].PreviousValue)
+	assert.InDelta(t, -100.0, byName["Food"].PercentageChange, 0.01)
+	assert.Equal(t, money.Zero("BRL"), byName["Rent"].PreviousValue)
+}
+
+func TestGetSpendingHeatmapConvertsCurrencies(t *testing.T) {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	repo := &memoryStatisticsRepository{heatmap: []*statistics.DailyTotal{
+		{Date: day, Currency: "BRL", Expenses: amount(t, "30.00", "BRL")},
+		{Date: day, Currency: "USD", Expenses: amount(t, "2.00", "USD")},
+	}}
+	uc := statistics.NewStatisticsUseCase(repo, &baseCurrencyRepository{}, fixedRates{})
+
+	heatmap, err := uc.GetSpendingHeatmap(1)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]money.Money{"2026-03-02": amount(t, "40.00", "BRL")}, heatmap)
+}
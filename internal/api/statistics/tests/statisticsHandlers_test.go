@@ -2,8 +2,10 @@ package tests
 
 import (
 	"testing"
+	"time"
 
 	"github.com/Renan-Parise/finances/internal/api/statistics"
+	"github.com/Renan-Parise/finances/pkg/money"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -32,6 +34,7 @@ func TestNewStatisticsHandler(t *testing.T) {
 		{"GET", "/api/statistics/highest-incomes"},
 		{"GET", "/api/statistics/spending-heatmap"},
 		{"GET", "/api/statistics/general"},
+		{"GET", "/api/statistics/tags"},
 	}
 
 	for _, route := range routes {
@@ -78,7 +81,12 @@ func (m *MockStatisticsUseCase) GetMonthlyExpensesSummary(userID int64) ([]*stat
 	return args.Get(0).([]*statistics.MonthlyAmount), args.Error(1)
 }
 
-func (m *MockStatisticsUseCase) GetSpendingHeatmap(userID int64) (map[string]float64, error) {
+func (m *MockStatisticsUseCase) GetSpendingHeatmap(userID int64) (map[string]money.Money, error) {
 	args := m.Called(userID)
-	return args.Get(0).(map[string]float64), args.Error(1)
+	return args.Get(0).(map[string]money.Money), args.Error(1)
+}
+
+func (m *MockStatisticsUseCase) GetTagSummaries(userID int64, from, to time.Time) ([]*statistics.TagSummary, error) {
+	args := m.Called(userID, from, to)
+	return args.Get(0).([]*statistics.TagSummary), args.Error(1)
 }
@@ -0,0 +1,132 @@
+package suggestions
+
+import (
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/errors"
+)
+
+const defaultLimit = 3
+
+type SuggestionUseCase interface {
+	Suggest(userID int64, description string, limit int) ([]*Suggestion, error)
+	SuggestBest(userID int64, descriptions []string) ([]*Suggestion, error)
+	Retrain(userID int64) (int, error)
+	Learn(userID int64, batch []*transactions.Transaction) error
+	Forget(userID int64, batch []*transactions.Transaction) error
+}
+
+type suggestionUseCase struct {
+	suggestionRepo  SuggestionRepository
+	transactionRepo transactions.TransactionRepositories
+}
+
+func NewSuggestionUseCase(sr SuggestionRepository, tr transactions.TransactionRepositories) SuggestionUseCase {
+	return &suggestionUseCase{
+		suggestionRepo:  sr,
+		transactionRepo: tr,
+	}
+}
+
+func (uc *suggestionUseCase) Suggest(userID int64, description string, limit int) ([]*Suggestion, error) {
+	if description == "" {
+		return nil, errors.NewValidationError("description", "description is required")
+	}
+
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	tokens := Tokenize(description)
+	if len(tokens) == 0 {
+		return []*Suggestion{}, nil
+	}
+
+	model, err := uc.getModel(userID, tokens)
+	if err != nil {
+		return nil, err
+	}
+	return Rank(model, tokens, limit), nil
+}
+
+func (uc *suggestionUseCase) SuggestBest(userID int64, descriptions []string) ([]*Suggestion, error) {
+	tokenized := make([][]string, len(descriptions))
+	seen := make(map[string]bool)
+	var tokens []string
+	for i, description := range descriptions {
+		tokenized[i] = Tokenize(description)
+		for _, token := range tokenized[i] {
+			if !seen[token] {
+				seen[token] = true
+				tokens = append(tokens, token)
+			}
+		}
+	}
+
+	best := make([]*Suggestion, len(descriptions))
+	if len(tokens) == 0 {
+		return best, nil
+	}
+
+	model, err := uc.getModel(userID, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range descriptions {
+		if ranked := Rank(model, tokenized[i], 1); len(ranked) > 0 {
+			best[i] = ranked[0]
+		}
+	}
+	return best, nil
+}
+
+func (uc *suggestionUseCase) Retrain(userID int64) (int, error) {
+	history, err := uc.transactionRepo.Filter(userID, &transactions.Filter{})
+	if err != nil {
+		return 0, err
+	}
+
+	training := NewTraining(history)
+	if err := uc.suggestionRepo.Replace(userID, training); err != nil {
+		return 0, err
+	}
+
+	documents := 0
+	for _, count := range training.Documents {
+		documents += count
+	}
+	return documents, nil
+}
+
+func (uc *suggestionUseCase) Learn(userID int64, batch []*transactions.Transaction) error {
+	return uc.adjust(userID, NewTraining(batch))
+}
+
+func (uc *suggestionUseCase) Forget(userID int64, batch []*transactions.Transaction) error {
+	return uc.adjust(userID, NewTraining(batch).Negate())
+}
+
+func (uc *suggestionUseCase) adjust(userID int64, training *Training) error {
+	if training.IsEmpty() {
+		return nil
+	}
+
+	trained, err := uc.suggestionRepo.IsTrained(userID)
+	if err != nil || !trained {
+		return err
+	}
+	return uc.suggestionRepo.Adjust(userID, training)
+}
+
+func (uc *suggestionUseCase) getModel(userID int64, tokens []string) (*Model, error) {
+	model, err := uc.suggestionRepo.GetModel(userID, tokens)
+	if err != nil || len(model.Categories) > 0 {
+		return model, err
+	}
+
+	documents, err := uc.Retrain(userID)
+	if err != nil || documents == 0 {
+		return model, err
+	}
+	return uc.suggestionRepo.GetModel(userID, tokens)
+}
@@ -0,0 +1,29 @@
+package suggestions
+
+type Suggestion struct {
+	Category   int     `json:"category"`
+	Confidence float64 `json:"confidence"`
+}
+
+// CategoryStats records how many transactions, and how many description tokens
+// in total, a category was trained on.
+type CategoryStats struct {
+	Category  int
+	Documents int
+	Tokens    int
+}
+
+// Model is the part of a user's trained counts needed to classify a set of
+// tokens: every category, the vocabulary size and the counts of those tokens.
+type Model struct {
+	Categories []*CategoryStats
+	Vocabulary int
+	Counts     map[int]map[string]int
+}
+
+// Training is a change to the counts, built from transactions being learned or
+// forgotten.
+type Training struct {
+	Documents map[int]int
+	Counts    map[int]map[string]int
+}
@@ -0,0 +1,142 @@
+package suggestions
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/utils"
+)
+
+const maxTokenLength = 64
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "at": true, "by": true, "for": true, "in": true, "of": true,
+	"on": true, "the": true, "to": true, "com": true, "da": true, "das": true, "de": true, "do": true,
+	"dos": true, "e": true, "em": true, "na": true, "no": true, "o": true, "os": true, "para": true,
+}
+
+// Tokenize reduces a description to the distinct words worth learning from.
+func Tokenize(description string) []string {
+	words := strings.FieldsFunc(strings.ToLower(utils.RemoveAccents(description)), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	seen := make(map[string]bool, len(words))
+	var tokens []string
+	for _, word := range words {
+		if runes := []rune(word); len(runes) > maxTokenLength {
+			word = string(runes[:maxTokenLength])
+		}
+		if len(word) < 2 || stopWords[word] || seen[word] {
+			continue
+		}
+		seen[word] = true
+		tokens = append(tokens, word)
+	}
+	return tokens
+}
+
+// NewTraining counts the tokens of categorized transactions.
+func NewTraining(batch []*transactions.Transaction) *Training {
+	training := &Training{Documents: make(map[int]int), Counts: make(map[int]map[string]int)}
+	for _, transaction := range batch {
+		if transaction.TransferID != 0 || transaction.Category == 0 {
+			continue
+		}
+
+		tokens := Tokenize(transaction.Description)
+		if len(tokens) == 0 {
+			continue
+		}
+
+		training.Documents[transaction.Category]++
+		counts := training.Counts[transaction.Category]
+		if counts == nil {
+			counts = make(map[string]int)
+			training.Counts[transaction.Category] = counts
+		}
+		for _, token := range tokens {
+			counts[token]++
+		}
+	}
+	return training
+}
+
+// Negate turns a training into the change that undoes it.
+func (t *Training) Negate() *Training {
+	negated := &Training{Documents: make(map[int]int), Counts: make(map[int]map[string]int)}
+	for category, documents := range t.Documents {
+		negated.Documents[category] = -documents
+	}
+	for category, counts := range t.Counts {
+		negated.Counts[category] = make(map[string]int, len(counts))
+		for token, count := range counts {
+			negated.Counts[category][token] = -count
+		}
+	}
+	return negated
+}
+
+func (t *Training) IsEmpty() bool {
+	return len(t.Documents) == 0
+}
+
+// Rank classifies tokens with a multinomial naive Bayes model using Laplace
+// smoothing.
+func Rank(model *Model, tokens []string, limit int) []*Suggestion {
+	known := tokens[:0:0]
+	for _, token := range tokens {
+		for _, counts := range model.Counts {
+			if counts[token] > 0 {
+				known = append(known, token)
+				break
+			}
+		}
+	}
+
+	if len(known) == 0 || len(model.Categories) == 0 {
+		return []*Suggestion{}
+	}
+
+	documents := 0
+	for _, stats := range model.Categories {
+		documents += stats.Documents
+	}
+
+	scores := make([]float64, len(model.Categories))
+	best := math.Inf(-1)
+	for i, stats := range model.Categories {
+		score := math.Log(float64(stats.Documents) / float64(documents))
+		denominator := float64(stats.Tokens + model.Vocabulary)
+		for _, token := range known {
+			score += math.Log(float64(model.Counts[stats.Category][token]+1) / denominator)
+		}
+		scores[i] = score
+		best = math.Max(best, score)
+	}
+
+	total := 0.0
+	for i := range scores {
+		scores[i] = math.Exp(scores[i] - best)
+		total += scores[i]
+	}
+
+	suggestions := make([]*Suggestion, len(model.Categories))
+	for i, stats := range model.Categories {
+		suggestions[i] = &Suggestion{
+			Category:   stats.Category,
+			Confidence: math.Round(scores[i]/total*10000) / 10000,
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Confidence > suggestions[j].Confidence
+	})
+	if limit > 0 && len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
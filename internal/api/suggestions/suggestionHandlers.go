@@ -0,0 +1,73 @@
+package suggestions
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SuggestionHandler struct {
+	suggestionUseCase SuggestionUseCase
+}
+
+func NewSuggestionHandler(router *gin.RouterGroup, su SuggestionUseCase) {
+	handler := &SuggestionHandler{
+		suggestionUseCase: su,
+	}
+
+	suggestions := router.Group("/suggestions")
+	suggestions.Use(middlewares.JWTAuthMiddleware())
+	{
+		suggestions.GET("/category", handler.SuggestCategory)
+		suggestions.POST("/retrain", handler.Retrain)
+	}
+}
+
+func (h *SuggestionHandler) SuggestCategory(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	limit := 0
+	if value := c.Query("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+	}
+
+	suggestions, err := h.suggestionUseCase.Suggest(userID.(int64), c.Query("description"), limit)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestions)
+}
+
+func (h *SuggestionHandler) Retrain(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	documents, err := h.suggestionUseCase.Retrain(userID.(int64))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"trainedOn": documents})
+}
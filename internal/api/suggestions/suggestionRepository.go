@@ -0,0 +1,173 @@
+package suggestions
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+)
+
+type SuggestionRepository interface {
+	GetModel(userID int64, tokens []string) (*Model, error)
+	IsTrained(userID int64) (bool, error)
+	Adjust(userID int64, training *Training) error
+	Replace(userID int64, training *Training) error
+}
+
+type suggestionRepository struct {
+	db *sql.DB
+}
+
+func NewSuggestionRepository(db *sql.DB) SuggestionRepository {
+	return &suggestionRepository{db: db}
+}
+
+func (r *suggestionRepository) GetModel(userID int64, tokens []string) (*Model, error) {
+	model := &Model{Counts: make(map[int]map[string]int)}
+
+	rows, err := r.db.Query(`SELECT category, documents, tokens FROM suggestion_categories
+              WHERE userId = ? AND documents > 0 ORDER BY category`, userID)
+	if err != nil {
+		return nil, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var stats CategoryStats
+		if err := rows.Scan(&stats.Category, &stats.Documents, &stats.Tokens); err != nil {
+			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		model.Categories = append(model.Categories, &stats)
+	}
+
+	if len(model.Categories) == 0 {
+		return model, nil
+	}
+
+	query := `SELECT COUNT(DISTINCT token) FROM suggestion_tokens WHERE userId = ? AND count > 0`
+	if err := r.db.QueryRow(query, userID).Scan(&model.Vocabulary); err != nil {
+		return nil, errors.NewQueryError("error scanning row: " + err.Error())
+	}
+
+	if len(tokens) == 0 {
+		return model, nil
+	}
+
+	placeholders := make([]string, len(tokens))
+	args := []interface{}{userID}
+	for i, token := range tokens {
+		placeholders[i] = "?"
+		args = append(args, token)
+	}
+
+	query = `SELECT category, token, count FROM suggestion_tokens
+              WHERE userId = ? AND count > 0 AND token IN (` + strings.Join(placeholders, ", ") + `)`
+	tokenRows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer tokenRows.Close()
+
+	for tokenRows.Next() {
+		var category, count int
+		var token string
+		if err := tokenRows.Scan(&category, &token, &count); err != nil {
+			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		if model.Counts[category] == nil {
+			model.Counts[category] = make(map[string]int)
+		}
+		model.Counts[category][token] = count
+	}
+	return model, nil
+}
+
+func (r *suggestionRepository) IsTrained(userID int64) (bool, error) {
+	var trained bool
+	query := `SELECT EXISTS(SELECT 1 FROM suggestion_categories WHERE userId = ?)`
+	if err := r.db.QueryRow(query, userID).Scan(&trained); err != nil {
+		return false, errors.NewQueryError("error scanning row: " + err.Error())
+	}
+	return trained, nil
+}
+
+func (r *suggestionRepository) Adjust(userID int64, training *Training) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return errors.NewDatabaseError("error starting transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	if err := addTraining(tx, userID, training); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM suggestion_tokens WHERE userId = ? AND count <= 0`, userID); err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+
+	if _, err := tx.Exec(`DELETE FROM suggestion_categories WHERE userId = ? AND documents <= 0`, userID); err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+	return nil
+}
+
+func (r *suggestionRepository) Replace(userID int64, training *Training) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return errors.NewDatabaseError("error starting transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM suggestion_tokens WHERE userId = ?`, userID); err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+
+	if _, err := tx.Exec(`DELETE FROM suggestion_categories WHERE userId = ?`, userID); err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+
+	if err := addTraining(tx, userID, training); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+	return nil
+}
+
+func addTraining(tx *sql.Tx, userID int64, training *Training) error {
+	categoryStmt, err := tx.Prepare(`INSERT INTO suggestion_categories (userId, category, documents, tokens) VALUES (?, ?, ?, ?)
+              ON DUPLICATE KEY UPDATE documents = documents + VALUES(documents), tokens = tokens + VALUES(tokens)`)
+	if err != nil {
+		return errors.NewQueryError("error preparing query: " + err.Error())
+	}
+	defer categoryStmt.Close()
+
+	tokenStmt, err := tx.Prepare(`INSERT INTO suggestion_tokens (userId, category, token, count) VALUES (?, ?, ?, ?)
+              ON DUPLICATE KEY UPDATE count = count + VALUES(count)`)
+	if err != nil {
+		return errors.NewQueryError("error preparing query: " + err.Error())
+	}
+	defer tokenStmt.Close()
+
+	for category, documents := range training.Documents {
+		tokens := 0
+		for token, count := range training.Counts[category] {
+			if _, err := tokenStmt.Exec(userID, category, token, count); err != nil {
+				return errors.NewQueryError("error updating token count: " + err.Error())
+			}
+			tokens += count
+		}
+
+		if _, err := categoryStmt.Exec(userID, category, documents, tokens); err != nil {
+			return errors.NewQueryError("error updating category count: " + err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Renan-Parise/finances/internal/api/suggestions"
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	groceries = 1
+	transport = 2
+	dining    = 3
+)
+
+func history() []*transactions.Transaction {
+	entries := []struct {
+		description string
+		category    int
+	}{
+		{"Supermercado Pão de Açúcar", groceries},
+		{"SUPERMERCADO EXTRA 1234", groceries},
+		{"Carrefour supermercado", groceries},
+		{"Uber trip", transport},
+		{"UBER *TRIP 28/02", transport},
+		{"99 Taxi corrida", transport},
+		{"iFood restaurante", dining},
+		{"Restaurante do Zé", dining},
+	}
+
+	batch := make([]*transactions.Transaction, len(entries))
+	for i, entry := range entries {
+		batch[i] = transactions.NewTransaction(1, 0, entry.description, entry.category, money.MustParse("-10", "BRL"))
+	}
+	return batch
+}
+
+func modelFrom(training *suggestions.Training) *suggestions.Model {
+	model := &suggestions.Model{Counts: training.Counts}
+	vocabulary := make(map[string]bool)
+	for category, documents := range training.Documents {
+		stats := &suggestions.CategoryStats{Category: category, Documents: documents}
+		for token, count := range training.Counts[category] {
+			stats.Tokens += count
+			vocabulary[token] = true
+		}
+		model.Categories = append(model.Categories, stats)
+	}
+	model.Vocabulary = len(vocabulary)
+	return model
+}
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"padaria", "sao", "joao"}, suggestions.Tokenize("PADARIA SÃO JOÃO 0042 de"))
+	assert.Equal(t, []string{"uber", "trip"}, suggestions.Tokenize("UBER *TRIP uber 28/02"))
+	assert.Empty(t, suggestions.Tokenize("12/03 - 4432"))
+}
+
+func TestNewTrainingSkipsUnusableTransactions(t *testing.T) {
+	transfer := transactions.NewTransaction(1, 0, "Transfer to savings", 4, money.MustParse("-10", "BRL"))
+	transfer.TransferID = 9
+	uncategorized := transactions.NewTransaction(1, 0, "Mystery", 0, money.MustParse("-10", "BRL"))
+	numeric := transactions.NewTransaction(1, 0, "0042", 4, money.MustParse("-10", "BRL"))
+
+	training := suggestions.NewTraining([]*transactions.Transaction{transfer, uncategorized, numeric})
+	assert.True(t, training.IsEmpty())
+
+	training = suggestions.NewTraining(history())
+	assert.Equal(t, 3, training.Documents[groceries])
+	assert.Equal(t, 3, training.Counts[groceries]["supermercado"])
+	assert.Equal(t, -3, training.Negate().Counts[groceries]["supermercado"])
+}
+
+func TestRankSuggestsFromSimilarDescriptions(t *testing.T) {
+	model := modelFrom(suggestions.NewTraining(history()))
+
+	ranked := suggestions.Rank(model, suggestions.Tokenize("Supermercado Dia"), 2)
+	require.Len(t, ranked, 2)
+	assert.Equal(t, groceries, ranked[0].Category)
+	assert.Greater(t, ranked[0].Confidence, 0.5)
+	assert.GreaterOrEqual(t, ranked[0].Confidence, ranked[1].Confidence)
+
+	ranked = suggestions.Rank(model, suggestions.Tokenize("UBER EATS restaurante"), 0)
+	require.Len(t, ranked, 3)
+	total := 0.0
+	for _, suggestion := range ranked {
+		total += suggestion.Confidence
+	}
+	assert.InDelta(t, 1, total, 0.001)
+}
+
+func TestRankIgnoresUnknownWords(t *testing.T) {
+	model := modelFrom(suggestions.NewTraining(history()))
+	assert.Empty(t, suggestions.Rank(model, suggestions.Tokenize("Netflix"), 3))
+}
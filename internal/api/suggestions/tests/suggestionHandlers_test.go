@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Renan-Parise/finances/internal/api/suggestions"
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSuggestionUseCase struct {
+	mock.Mock
+}
+
+func TestNewSuggestionHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.Default()
+	group := router.Group("/api")
+
+	mockUseCase := new(MockSuggestionUseCase)
+	suggestions.NewSuggestionHandler(group, mockUseCase)
+
+	routes := router.Routes()
+
+	expectedRoutes := []struct {
+		method string
+		path   string
+	}{
+		{"GET", "/api/suggestions/category"},
+		{"POST", "/api/suggestions/retrain"},
+	}
+
+	for _, expected := range expectedRoutes {
+		found := false
+		for _, route := range routes {
+			if route.Method == expected.method && route.Path == expected.path {
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, "Route %s %s not registered", expected.method, expected.path)
+	}
+}
+
+func (m *MockSuggestionUseCase) Suggest(userID int64, description string, limit int) ([]*suggestions.Suggestion, error) {
+	args := m.Called(userID, description, limit)
+	return args.Get(0).([]*suggestions.Suggestion), args.Error(1)
+}
+
+func (m *MockSuggestionUseCase) SuggestBest(userID int64, descriptions []string) ([]*suggestions.Suggestion, error) {
+	args := m.Called(userID, descriptions)
+	return args.Get(0).([]*suggestions.Suggestion), args.Error(1)
+}
+
+func (m *MockSuggestionUseCase) Retrain(userID int64) (int, error) {
+	args := m.Called(userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockSuggestionUseCase) Learn(userID int64, batch []*transactions.Transaction) error {
+	args := m.Called(userID, batch)
+	return args.Error(0)
+}
+
+func (m *MockSuggestionUseCase) Forget(userID int64, batch []*transactions.Transaction) error {
+	args := m.Called(userID, batch)
+	return args.Error(0)
+}
@@ -0,0 +1,95 @@
+package tags
+
+import (
+	"github.com/Renan-Parise/finances/internal/cache"
+	"github.com/Renan-Parise/finances/internal/errors"
+)
+
+type TagUseCase interface {
+	CreateTag(tag *Tag) error
+	GetTags(userID int64) ([]*Tag, error)
+	UpdateTag(tag *Tag) error
+	DeleteTag(userID int64, id int64) error
+}
+
+type tagUseCase struct {
+	tagRepo TagRepository
+	cache   cache.Invalidator
+}
+
+func NewTagUseCase(tr TagRepository, ci cache.Invalidator) TagUseCase {
+	return &tagUseCase{tagRepo: tr, cache: ci}
+}
+
+func (uc *tagUseCase) CreateTag(tag *Tag) error {
+	if err := uc.validateTag(tag); err != nil {
+		return err
+	}
+	return uc.tagRepo.Create(tag)
+}
+
+func (uc *tagUseCase) GetTags(userID int64) ([]*Tag, error) {
+	return uc.tagRepo.GetAll(userID)
+}
+
+func (uc *tagUseCase) UpdateTag(tag *Tag) error {
+	existing, err := uc.getTag(tag.UserID, tag.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.validateTag(tag); err != nil {
+		return err
+	}
+
+	tag.CreatedAt = existing.CreatedAt
+	if err := uc.tagRepo.Update(tag); err != nil {
+		return err
+	}
+	cache.InvalidateUserOrLog(uc.cache, tag.UserID)
+	return nil
+}
+
+func (uc *tagUseCase) DeleteTag(userID int64, id int64) error {
+	if _, err := uc.getTag(userID, id); err != nil {
+		return err
+	}
+
+	if err := uc.tagRepo.Delete(userID, id); err != nil {
+		return err
+	}
+	cache.InvalidateUserOrLog(uc.cache, userID)
+	return nil
+}
+
+func (uc *tagUseCase) validateTag(tag *Tag) error {
+	name, err := NormalizeName(tag.Name)
+	if err != nil {
+		return err
+	}
+	tag.Name = name
+
+	existing, err := uc.tagRepo.GetAll(tag.UserID)
+	if err != nil {
+		return err
+	}
+
+	for _, other := range existing {
+		if other.ID != tag.ID && other.Name == tag.Name {
+			return errors.NewValidationError("name", "a tag named '"+tag.Name+"' already exists")
+		}
+	}
+	return nil
+}
+
+func (uc *tagUseCase) getTag(userID int64, id int64) (*Tag, error) {
+	tag, err := uc.tagRepo.GetByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if tag == nil {
+		return nil, errors.NewValidationError("id", "tag not found")
+	}
+	return tag, nil
+}
@@ -0,0 +1,11 @@
+package tags
+
+import "time"
+
+// Tag is a free label on transactions, alongside their single category.
+type Tag struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"userId"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
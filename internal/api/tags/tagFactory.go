@@ -0,0 +1,39 @@
+package tags
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+)
+
+const maxNameLength = 50
+
+func NewTag(userID int64, name string) *Tag {
+	return &Tag{
+		UserID:    userID,
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+}
+
+// NormalizeName turns a tag name into its stored form: lower case, with words
+// joined by hyphens, such as "vacation-2026" for "Vacation 2026".
+func NormalizeName(name string) (string, error) {
+	normalized := strings.Join(strings.Fields(strings.ToLower(name)), "-")
+	if normalized == "" {
+		return "", errors.NewValidationError("name", "name is required")
+	}
+
+	if len([]rune(normalized)) > maxNameLength {
+		return "", errors.NewValidationError("name", "name must be at most 50 characters")
+	}
+
+	for _, r := range normalized {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' {
+			return "", errors.NewValidationError("name", "name may only contain letters, digits, hyphens and underscores")
+		}
+	}
+	return normalized, nil
+}
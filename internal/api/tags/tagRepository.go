@@ -0,0 +1,83 @@
+package tags
+
+import (
+	"database/sql"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+)
+
+type TagRepository interface {
+	Create(tag *Tag) error
+	GetAll(userID int64) ([]*Tag, error)
+	GetByID(userID int64, id int64) (*Tag, error)
+	Update(tag *Tag) error
+	Delete(userID int64, id int64) error
+}
+
+type tagRepository struct {
+	db *sql.DB
+}
+
+func NewTagRepository(db *sql.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+func (r *tagRepository) Create(tag *Tag) error {
+	query := `INSERT INTO tags (userId, name, createdAt) VALUES (?, ?, ?)`
+	res, err := r.db.Exec(query, tag.UserID, tag.Name, tag.CreatedAt)
+	if err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+
+	if tag.ID, err = res.LastInsertId(); err != nil {
+		return errors.NewQueryError("error getting last insert ID: " + err.Error())
+	}
+	return nil
+}
+
+func (r *tagRepository) GetAll(userID int64) ([]*Tag, error) {
+	query := `SELECT id, userId, name, createdAt FROM tags WHERE userId = ? ORDER BY name`
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	var tags []*Tag
+	for rows.Next() {
+		var tag Tag
+		if err := rows.Scan(&tag.ID, &tag.UserID, &tag.Name, &tag.CreatedAt); err != nil {
+			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		tags = append(tags, &tag)
+	}
+	return tags, nil
+}
+
+func (r *tagRepository) GetByID(userID int64, id int64) (*Tag, error) {
+	query := `SELECT id, userId, name, createdAt FROM tags WHERE id = ? AND userId = ?`
+	var tag Tag
+	if err := r.db.QueryRow(query, id, userID).Scan(&tag.ID, &tag.UserID, &tag.Name, &tag.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.NewQueryError("error scanning row: " + err.Error())
+	}
+	return &tag, nil
+}
+
+func (r *tagRepository) Update(tag *Tag) error {
+	query := `UPDATE tags SET name = ? WHERE id = ? AND userId = ?`
+	if _, err := r.db.Exec(query, tag.Name, tag.ID, tag.UserID); err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+	return nil
+}
+
+func (r *tagRepository) Delete(userID int64, id int64) error {
+	query := `DELETE FROM tags WHERE id = ? AND userId = ?`
+	if _, err := r.db.Exec(query, id, userID); err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Renan-Parise/finances/internal/api/tags"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeName(t *testing.T) {
+	name, err := tags.NormalizeName("  Vacation   2026 ")
+	require.NoError(t, err)
+	assert.Equal(t, "vacation-2026", name)
+
+	name, err = tags.NormalizeName("Férias_SP")
+	require.NoError(t, err)
+	assert.Equal(t, "férias_sp", name)
+
+	for _, invalid := range []string{"   ", "tax/deductible", "#reimbursable"} {
+		_, err := tags.NormalizeName(invalid)
+		assert.True(t, errors.IsValidationError(err), "expected validation error for %q, got %v", invalid, err)
+	}
+}
@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Renan-Parise/finances/internal/api/tags"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTagUseCase struct {
+	mock.Mock
+}
+
+func TestNewTagHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.Default()
+	group := router.Group("/api")
+
+	mockUseCase := new(MockTagUseCase)
+	tags.NewTagHandler(group, mockUseCase)
+
+	routes := router.Routes()
+
+	expectedRoutes := []struct {
+		method string
+		path   string
+	}{
+		{"POST", "/api/tags/"},
+		{"GET", "/api/tags/"},
+		{"PUT", "/api/tags/:id"},
+		{"DELETE", "/api/tags/:id"},
+	}
+
+	for _, expected := range expectedRoutes {
+		found := false
+		for _, route := range routes {
+			if route.Method == expected.method && route.Path == expected.path {
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, "Route %s %s not registered", expected.method, expected.path)
+	}
+}
+
+func (m *MockTagUseCase) CreateTag(tag *tags.Tag) error {
+	args := m.Called(tag)
+	return args.Error(0)
+}
+
+func (m *MockTagUseCase) GetTags(userID int64) ([]*tags.Tag, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]*tags.Tag), args.Error(1)
+}
+
+func (m *MockTagUseCase) UpdateTag(tag *tags.Tag) error {
+	args := m.Called(tag)
+	return args.Error(0)
+}
+
+func (m *MockTagUseCase) DeleteTag(userID int64, id int64) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
@@ -0,0 +1,443 @@
+package transactions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+const (
+	maxQueryLength = 2000
+	maxQueryDepth  = 32
+)
+
+type fieldKind int
+
+const (
+	kindID fieldKind = iota
+	kindMoney
+	kindText
+	kindTime
+)
+
+type queryField struct {
+	column string
+	kind   fieldKind
+}
+
+var queryFields = map[string]queryField{
+	"amount":      {"amount", kindMoney},
+	"category":    {"category", kindID},
+	"account":     {"accountId", kindID},
+	"accountid":   {"accountId", kindID},
+	"description": {"description", kindText},
+	"status":      {"status", kindText},
+	"currency":    {"currency", kindText},
+	"occurred":    {"occurredAt", kindTime},
+	"occurredat":  {"occurredAt", kindTime},
+	"tag":         {"tag", kindID},
+	"created":     {"createdAt", kindTime},
+	"createdat":   {"createdAt", kindTime},
+}
+
+var kindOperators = map[fieldKind]map[string]bool{
+	kindID:    {"=": true, "!=": true, "IN": true},
+	kindMoney: {"=": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true, "IN": true},
+	kindText:  {"=": true, "!=": true, "~": true, "IN": true},
+	kindTime:  {"=": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true, "IN": true},
+}
+
+// Expr is a node of a parsed query.
+type Expr interface {
+	String() string
+}
+
+// Logical joins two expressions with AND or OR.
+type Logical struct {
+	Op    string
+	Left  Expr
+	Right Expr
+}
+
+type Not struct {
+	Expr Expr
+}
+
+// Comparison tests a field against one value, or a list of values for IN.
+type Comparison struct {
+	Field  string
+	Op     string
+	Values []interface{}
+	Day    bool
+}
+
+func (e *Logical) String() string {
+	return "(" + e.Left.String() + " " + e.Op + " " + e.Right.String() + ")"
+}
+
+func (e *Not) String() string {
+	return "NOT " + e.Expr.String()
+}
+
+func (e *Comparison) String() string {
+	values := make([]string, len(e.Values))
+	for i, value := range e.Values {
+		switch v := value.(type) {
+		case money.Money:
+			values[i] = v.String()
+		case int64:
+			values[i] = strconv.FormatInt(v, 10)
+		case string:
+			values[i] = strconv.Quote(v)
+		case time.Time:
+			if e.Day {
+				values[i] = v.Format("2006-01-02")
+			} else {
+				values[i] = v.Format(time.RFC3339)
+			}
+		}
+	}
+
+	if e.Op == "IN" {
+		return e.Field + " IN (" + strings.Join(values, ", ") + ")"
+	}
+	return e.Field + " " + e.Op + " " + values[0]
+}
+
+// ParseQuery parses a filter query.
+func ParseQuery(input string) (Expr, error) {
+	if len([]rune(input)) > maxQueryLength {
+		return nil, errors.NewValidationError("query", fmt.Sprintf("query must be at most %d characters", maxQueryLength))
+	}
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &queryParser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, errors.NewPositionedValidationError("query", "query is empty", 1)
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, p.fail(next, "unexpected '"+next.text+"' after a complete expression")
+	}
+	return expr, nil
+}
+
+// ParseSort reads a comma-separated list of fields, each optionally followed
+// by asc or desc, such as "amount desc, occurred".
+func ParseSort(input string) ([]SortKey, error) {
+	var keys []SortKey
+	seen := make(map[string]bool)
+	position := 1
+	for _, part := range strings.Split(input, ",") {
+		words := strings.Fields(part)
+		start := position + len([]rune(part)) - len([]rune(strings.TrimLeftFunc(part, unicode.IsSpace)))
+		position += len([]rune(part)) + 1
+
+		if len(words) == 0 || len(words) > 2 {
+			return nil, errors.NewPositionedValidationError("sort", "expected a field optionally followed by asc or desc", start)
+		}
+
+		field, ok := queryFields[strings.ToLower(words[0])]
+		if !ok || !SortableFields[field.column] {
+			return nil, errors.NewPositionedValidationError("sort", "cannot sort by '"+words[0]+"'", start)
+		}
+
+		if seen[field.column] {
+			return nil, errors.NewPositionedValidationError("sort", "'"+words[0]+"' is listed more than once", start)
+		}
+		seen[field.column] = true
+
+		key := SortKey{Field: field.column}
+		if len(words) == 2 {
+			switch strings.ToUpper(words[1]) {
+			case "ASC":
+			case "DESC":
+				key.Desc = true
+			default:
+				return nil, errors.NewPositionedValidationError("sort", "expected asc or desc after '"+words[0]+"'", start)
+			}
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenComma
+	tokenOpen
+	tokenClose
+)
+
+type token struct {
+	kind     tokenKind
+	text     string
+	position int
+}
+
+func tokenize(input string) ([]token, error) {
+	runes := []rune(input)
+	var tokens []token
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i + 1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokenOpen, "(", start})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokenClose, ")", start})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{tokenComma, ",", start})
+			i++
+		case r == '=' || r == '~':
+			tokens = append(tokens, token{tokenOperator, string(r), start})
+			i++
+		case r == '<' || r == '>' || r == '!':
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, errors.NewPositionedValidationError("query", "expected '!='", start)
+			}
+			tokens = append(tokens, token{tokenOperator, op, start})
+			i += len(op)
+		case r == '"':
+			var text strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				text.WriteRune(runes[i])
+			}
+			if i == len(runes) {
+				return nil, errors.NewPositionedValidationError("query", "unterminated string", start)
+			}
+			tokens = append(tokens, token{tokenString, text.String(), start})
+			i++
+		case isWordRune(r):
+			end := i
+			for end < len(runes) && isWordRune(runes[end]) {
+				end++
+			}
+			tokens = append(tokens, token{tokenWord, string(runes[i:end]), start})
+			i = end
+		default:
+			return nil, errors.NewPositionedValidationError("query", fmt.Sprintf("unexpected character '%c'", r), start)
+		}
+	}
+	return append(tokens, token{tokenEOF, "end of query", len(runes) + 1}), nil
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_.-+:", r)
+}
+
+type queryParser struct {
+	tokens []token
+	next   int
+	depth  int
+}
+
+func (p *queryParser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *queryParser) advance() token {
+	t := p.tokens[p.next]
+	if t.kind != tokenEOF {
+		p.next++
+	}
+	return t
+}
+
+func (p *queryParser) keyword(word string) bool {
+	t := p.peek()
+	if t.kind == tokenWord && strings.EqualFold(t.text, word) {
+		p.next++
+		return true
+	}
+	return false
+}
+
+func (p *queryParser) fail(t token, message string) error {
+	return errors.NewPositionedValidationError("query", message, t.position)
+}
+
+func (p *queryParser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Logical{Op: "OR", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("AND") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &Logical{Op: "AND", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseNot() (Expr, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxQueryDepth {
+		return nil, p.fail(p.peek(), "query is nested too deeply")
+	}
+
+	if p.keyword("NOT") {
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Expr: expr}, nil
+	}
+
+	if p.peek().kind == tokenOpen {
+		p.advance()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if t := p.advance(); t.kind != tokenClose {
+			return nil, p.fail(t, "expected ')' but found '"+t.text+"'")
+		}
+		return expr, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *queryParser) parseComparison() (Expr, error) {
+	name := p.advance()
+	if name.kind != tokenWord {
+		return nil, p.fail(name, "expected a field name but found '"+name.text+"'")
+	}
+
+	field, ok := queryFields[strings.ToLower(name.text)]
+	if !ok {
+		return nil, p.fail(name, "unknown field '"+name.text+"'")
+	}
+
+	comparison := &Comparison{Field: strings.ToLower(name.text)}
+	op := p.advance()
+	switch {
+	case op.kind == tokenOperator:
+		comparison.Op = op.text
+	case op.kind == tokenWord && strings.EqualFold(op.text, "IN"):
+		comparison.Op = "IN"
+	default:
+		return nil, p.fail(op, "expected an operator after '"+name.text+"' but found '"+op.text+"'")
+	}
+
+	if !kindOperators[field.kind][comparison.Op] {
+		return nil, p.fail(op, "operator '"+comparison.Op+"' cannot be used with '"+name.text+"'")
+	}
+
+	if comparison.Op != "IN" {
+		value, day, err := p.parseValue(field)
+		if err != nil {
+			return nil, err
+		}
+		comparison.Values, comparison.Day = []interface{}{value}, day
+		return comparison, nil
+	}
+
+	if t := p.advance(); t.kind != tokenOpen {
+		return nil, p.fail(t, "expected '(' after IN but found '"+t.text+"'")
+	}
+
+	for {
+		t := p.peek()
+		value, day, err := p.parseValue(field)
+		if err != nil {
+			return nil, err
+		}
+
+		if field.kind == kindTime && !day {
+			return nil, p.fail(t, "IN only takes dates, without a time")
+		}
+		comparison.Values, comparison.Day = append(comparison.Values, value), day
+
+		if p.peek().kind == tokenComma {
+			p.advance()
+			continue
+		}
+
+		if t := p.advance(); t.kind != tokenClose {
+			return nil, p.fail(t, "expected ',' or ')' but found '"+t.text+"'")
+		}
+		return comparison, nil
+	}
+}
+
+func (p *queryParser) parseValue(field queryField) (interface{}, bool, error) {
+	t := p.advance()
+	if t.kind != tokenWord && t.kind != tokenString {
+		return nil, false, p.fail(t, "expected a value but found '"+t.text+"'")
+	}
+
+	switch field.kind {
+	case kindMoney:
+		amount, err := money.Parse(t.text, "")
+		if err != nil || t.kind == tokenString {
+			return nil, false, p.fail(t, "invalid amount '"+t.text+"'")
+		}
+		return amount, false, nil
+	case kindID:
+		id, err := strconv.ParseInt(t.text, 10, 64)
+		if err != nil || t.kind == tokenString {
+			return nil, false, p.fail(t, "invalid id '"+t.text+"'")
+		}
+		return id, false, nil
+	case kindTime:
+		value, err := ParseOccurredAt(t.text)
+		if err != nil {
+			return nil, false, p.fail(t, "invalid date '"+t.text+"', expected YYYY-MM-DD or an RFC 3339 timestamp")
+		}
+		return value, len(t.text) == len("2006-01-02"), nil
+	}
+	return t.text, false, nil
+}
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery(t *testing.T) {
+	expr, err := transactions.ParseQuery(`amount < -100 AND (category IN (1,3) OR description ~ "uber") AND occurred >= 2026-01-01`)
+	require.NoError(t, err)
+	assert.Equal(t, `((amount < -100.00 AND (category IN (1, 3) OR description ~ "uber")) AND occurred >= 2026-01-01)`, expr.String())
+
+	expr, err = transactions.ParseQuery(`not status = pending or a = b and currency != "BRL"`)
+	assert.Nil(t, expr)
+	assertPosition(t, err, 25)
+
+	expr, err = transactions.ParseQuery(`NOT status = pending OR description = "say \"hi\"" AND currency != BRL`)
+	require.NoError(t, err)
+	assert.Equal(t, `(NOT status = "pending" OR (description = "say \"hi\"" AND currency != "BRL"))`, expr.String())
+
+	expr, err = transactions.ParseQuery(`created <= 2026-03-01T10:00:00-03:00`)
+	require.NoError(t, err)
+	assert.Equal(t, `created <= 2026-03-01T10:00:00-03:00`, expr.String())
+
+	expr, err = transactions.ParseQuery(`tag IN (4,2) AND NOT tag = 7`)
+	require.NoError(t, err)
+	assert.Equal(t, `(tag IN (4, 2) AND NOT tag = 7)`, expr.String())
+}
+
+func TestParseQueryReportsPosition(t *testing.T) {
+	cases := map[string]int{
+		`amount ~ 10`:                    8,
+		`amount < ten`:                   10,
+		`payee = "uber"`:                 1,
+		`amount < -100 AND`:              18,
+		`(amount < -100`:                 15,
+		`category IN (1, 2`:              18,
+		`description = "uber`:            15,
+		`occurred IN (2026-01-01T10:00)`: 14,
+		`amount < 1 amount > 2`:          12,
+		`amount ! 1`:                     8,
+		`amount < 1 # 2`:                 12,
+	}
+
+	for query, position := range cases {
+		_, err := transactions.ParseQuery(query)
+		assertPosition(t, err, position, query)
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	keys, err := transactions.ParseSort("amount desc, occurred")
+	require.NoError(t, err)
+	assert.Equal(t, []transactions.SortKey{{Field: "amount", Desc: true}, {Field: "occurredAt"}}, keys)
+
+	_, err = transactions.ParseSort("amount, category")
+	assertPosition(t, err, 9)
+
+	_, err = transactions.ParseSort("amount, amount desc")
+	assertPosition(t, err, 9)
+
+	_, err = transactions.ParseSort("amount sideways")
+	assertPosition(t, err, 1)
+}
+
+func assertPosition(t *testing.T, err error, position int, msgAndArgs ...interface{}) {
+	t.Helper()
+	validation, ok := err.(*errors.ValidationError)
+	if assert.True(t, ok, "expected validation error, got %v", err) {
+		assert.Equal(t, position, validation.Position, msgAndArgs...)
+	}
+}
@@ -0,0 +1,200 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Renan-Parise/finances/internal/api/accounts"
+	"github.com/Renan-Parise/finances/internal/api/currencies"
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryTransactionRepository struct {
+	transactions.TransactionRepositories
+	stored  *transactions.Transaction
+	updated *transactions.Transaction
+}
+
+func (r *memoryTransactionRepository) GetByID(userID int64, id int64) (*transactions.Transaction, error) {
+	if r.stored == nil || r.stored.UserID != userID || r.stored.ID != id {
+		return nil, nil
+	}
+	stored := *r.stored
+	return &stored, nil
+}
+
+func (r *memoryTransactionRepository) Update(transaction *transactions.Transaction) error {
+	updated := *transaction
+	r.updated = &updated
+	return nil
+}
+
+type memoryAccountRepository struct {
+	accounts.AccountRepository
+	accounts map[int64]*accounts.Account
+}
+
+func (r *memoryAccountRepository) GetByID(userID int64, id int64) (*accounts.Account, error) {
+	account := r.accounts[id]
+	if account == nil || account.UserID != userID {
+		return nil, nil
+	}
+	return account, nil
+}
+
+type baseCurrencyRepository struct {
+	currencies.CurrencyRepository
+}
+
+func (r *baseCurrencyRepository) GetBaseCurrency(userID int64) (string, error) {
+	return "BRL", nil
+}
+
+type noopEnricher struct{}
+
+func (noopEnricher) Enrich(userID int64, batch []*transactions.Transaction) error {
+	return nil
+}
+
+type noopLearner struct{}
+
+func (noopLearner) Learn(userID int64, batch []*transactions.Transaction) error {
+	return nil
+}
+
+func (noopLearner) Forget(userID int64, batch []*transactions.Transaction) error {
+	return nil
+}
+
+type noopInvalidator struct{}
+
+func (noopInvalidator) InvalidateUser(userID int64) error {
+	return nil
+}
+
+func (noopInvalidator) InvalidateAll() error {
+	return nil
+}
+
+func newUpdateUseCase(stored *transactions.Transaction) (transactions.TransactionUseCase, *memoryTransactionRepository) {
+	repo := &memoryTransactionRepository{stored: stored}
+	accountRepo := &memoryAccountRepository{accounts: map[int64]*accounts.Account{
+		3: {ID: 3, UserID: 1, Name: "Checking", Currency: "USD"},
+	}}
+	return transactions.NewTransactionUseCase(repo, accountRepo, &baseCurrencyRepository{},
+		noopEnricher{}, noopLearner{}, noopInvalidator{}), repo
+}
+
+func storedTransaction() *transactions.Transaction {
+	stored := transactions.NewTransaction(1, 3, "Supermarket", 1, money.MustParse("-100.00", "USD"))
+	stored.ID = 7
+	stored.Currency = "USD"
+	return stored
+}
+
+func TestCreateTransactionRejectsInvalidSplits(t *testing.T) {
+	uc := transactions.NewTransactionUseCase(nil, nil, nil, nil, nil, nil)
+
+	cases := []struct {
+		name   string
+		splits []*transactions.Split
+	}{
+		{"single line", []*transactions.Split{
+			{Category: 1, Amount: money.MustParse("-100.00", "BRL")},
+		}},
+		{"sum mismatch", []*transactions.Split{
+			{Category: 1, Amount: money.MustParse("-60.00", "BRL")},
+			{Category: 2, Amount: money.MustParse("-30.00", "BRL")},
+		}},
+		{"missing category", []*transactions.Split{
+			{Category: 1, Amount: money.MustParse("-60.00", "BRL")},
+			{Amount: money.MustParse("-40.00", "BRL")},
+		}},
+		{"sign mismatch", []*transactions.Split{
+			{Category: 1, Amount: money.MustParse("-120.00", "BRL")},
+			{Category: 2, Amount: money.MustParse("20.00", "BRL")},
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			transaction := transactions.NewTransaction(1, 0, "Supermarket", 0, money.MustParse("-100.00", "BRL"))
+			transaction.Splits = tc.splits
+
+			err := uc.CreateTransaction(transaction)
+			assert.True(t, errors.IsValidationError(err), "expected validation error, got %v", err)
+		})
+	}
+}
+
+func TestTransactionsCannotBeReconciledDirectly(t *testing.T) {
+	uc := transactions.NewTransactionUseCase(nil, nil, nil, nil, nil, nil)
+
+	transaction := transactions.NewTransaction(1, 0, "Supermarket", 1, money.MustParse("-100.00", "BRL"))
+	transaction.Status = transactions.StatusReconciled
+
+	err := uc.CreateTransaction(transaction)
+	assert.True(t, errors.IsValidationError(err), "expected validation error, got %v", err)
+
+	err = uc.SetStatus(1, 1, transactions.StatusReconciled)
+	assert.True(t, errors.IsValidationError(err), "expected validation error, got %v", err)
+
+	err = uc.SetStatus(1, 1, "confirmed")
+	assert.True(t, errors.IsValidationError(err), "expected validation error, got %v", err)
+}
+
+func TestListTransactionsValidatesPage(t *testing.T) {
+	uc := transactions.NewTransactionUseCase(nil, nil, nil, nil, nil, nil)
+
+	_, err := uc.ListTransactions(1, &transactions.Filter{}, &transactions.Page{Limit: transactions.MaxPageSize + 1})
+	assert.True(t, errors.IsValidationError(err), "expected validation error, got %v", err)
+
+	_, err = uc.ListTransactions(1, &transactions.Filter{Field: "userId"}, &transactions.Page{})
+	assert.True(t, errors.IsValidationError(err), "expected validation error, got %v", err)
+
+	transaction := transactions.NewTransaction(1, 0, "Supermarket", 1, money.MustParse("-100.00", "BRL"))
+	transaction.ID = 7
+	cursor := transactions.EncodeCursor(transactions.NewCursor(transaction, []transactions.SortKey{{Field: "amount"}}))
+
+	_, err = uc.ListTransactions(1, &transactions.Filter{}, &transactions.Page{Cursor: cursor})
+	assert.True(t, errors.IsValidationError(err), "expected validation error, got %v", err)
+}
+
+func TestUpdateTransactionKeepsAccountWhenOmitted(t *testing.T) {
+	uc, repo := newUpdateUseCase(storedTransaction())
+
+	update := transactions.NewTransaction(1, 0, "Groceries", 1, money.MustParse("-80.00", "BRL"))
+	update.ID = 7
+
+	require.NoError(t, uc.UpdateTransaction(update))
+	require.NotNil(t, repo.updated)
+	assert.Equal(t, int64(3), repo.updated.AccountID)
+	assert.Equal(t, "USD", repo.updated.Currency)
+	assert.Equal(t, "Groceries", repo.updated.Description)
+}
+
+func TestUpdateTransactionKeepsSplitsWhenOmitted(t *testing.T) {
+	stored := storedTransaction()
+	stored.Splits = []*transactions.Split{
+		{Category: 1, Amount: money.MustParse("-60.00", "USD")},
+		{Category: 2, Amount: money.MustParse("-40.00", "USD")},
+	}
+	uc, repo := newUpdateUseCase(stored)
+
+	update := transactions.NewTransaction(1, 3, "Groceries", 1, money.MustParse("-100.00", "USD"))
+	update.ID = 7
+
+	require.NoError(t, uc.UpdateTransaction(update))
+	require.NotNil(t, repo.updated)
+	assert.Len(t, repo.updated.Splits, 2)
+
+	update = transactions.NewTransaction(1, 3, "Groceries", 1, money.MustParse("-100.00", "USD"))
+	update.ID = 7
+	update.Splits = []*transactions.Split{}
+
+	require.NoError(t, uc.UpdateTransaction(update))
+	assert.Empty(t, repo.updated.Splits)
+}
@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOccurredAt(t *testing.T) {
+	date, err := transactions.ParseOccurredAt("2026-03-01")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), date)
+
+	local, err := transactions.ParseOccurredAt("2026-03-01T23:40:00")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 1, 23, 40, 0, 0, time.UTC), local)
+
+	zoned, err := transactions.ParseOccurredAt("2026-03-01T23:40:00-03:00")
+	require.NoError(t, err)
+	assert.Equal(t, 1, zoned.Day())
+	_, offset := zoned.Zone()
+	assert.Equal(t, -3*60*60, offset)
+
+	_, err = transactions.ParseOccurredAt("01/03/2026")
+	assert.Error(t, err)
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	transaction := transactions.NewTransaction(1, 0, "Padaria", 1, money.MustParse("-12.50", "BRL"))
+	transaction.ID = 42
+	transaction.OccurredAt = time.Date(2026, 3, 1, 23, 40, 0, 0, time.FixedZone("", -3*60*60))
+
+	keys, err := transactions.SortKeys(&transactions.Filter{})
+	require.NoError(t, err)
+	assert.Equal(t, []transactions.SortKey{{Field: "occurredAt", Desc: true}}, keys)
+
+	encoded := transactions.EncodeCursor(transactions.NewCursor(transaction, keys))
+	cursor, err := transactions.DecodeCursor(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, &transactions.Cursor{Sort: "occurredAt DESC", Values: []string{"2026-03-01 23:40:00"}, ID: 42}, cursor)
+
+	keys, err = transactions.SortKeys(&transactions.Filter{Sort: "amount, description desc"})
+	require.NoError(t, err)
+	multi := transactions.NewCursor(transaction, keys)
+	assert.Equal(t, "amount ASC,description DESC", multi.Sort)
+	assert.Equal(t, []string{"-12.50", "Padaria"}, multi.Values)
+
+	_, err = transactions.DecodeCursor("not a cursor")
+	assert.True(t, errors.IsValidationError(err))
+}
+
+func TestSelectFields(t *testing.T) {
+	transaction := transactions.NewTransaction(1, 0, "Padaria", 1, money.MustParse("-12.50", "BRL"))
+	transaction.ID = 42
+
+	selected, err := transactions.SelectFields([]*transactions.Transaction{transaction}, []string{"id", "amount", "description"})
+	require.NoError(t, err)
+	require.Len(t, selected, 1)
+	assert.Len(t, selected[0], 3)
+	assert.JSONEq(t, `"-12.50"`, string(selected[0]["amount"]))
+	assert.JSONEq(t, `42`, string(selected[0]["id"]))
+
+	_, err = transactions.SelectFields(nil, []string{"id", "password"})
+	assert.True(t, errors.IsValidationError(err))
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := transactions.NormalizeTags([]int64{5, 2, 5, 9, 2})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 5, 9}, tags)
+
+	tags, err = transactions.NormalizeTags(nil)
+	require.NoError(t, err)
+	assert.Nil(t, tags)
+
+	_, err = transactions.NormalizeTags([]int64{3, 0})
+	assert.True(t, errors.IsValidationError(err))
+}
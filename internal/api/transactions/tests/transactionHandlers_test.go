@@ -2,6 +2,7 @@ package tests
 
 import (
 	"testing"
+	"time"
 
 	"github.com/Renan-Parise/finances/internal/api/transactions"
 	"github.com/gin-gonic/gin"
@@ -29,8 +30,13 @@ func TestNewTransactionHandler(t *testing.T) {
 		path   string
 	}{
 		{"POST", "/api/transactions/filter"},
+		{"GET", "/api/transactions/search"},
+		{"POST", "/api/transactions/tags"},
 		{"DELETE", "/api/transactions/:id"},
 		{"PUT", "/api/transactions/:id"},
+		{"PUT", "/api/transactions/:id/status"},
+		{"POST", "/api/transactions/:id/lock"},
+		{"POST", "/api/transactions/:id/unlock"},
 		{"POST", "/api/transactions/"},
 		{"GET", "/api/transactions/"},
 	}
@@ -57,8 +63,8 @@ func (m *MockTransactionUseCase) FilterTransactions(id int64, filter *transactio
 	return args.Get(0).([]*transactions.Transaction), args.Error(1)
 }
 
-func (m *MockTransactionUseCase) CreateTransaction(id int64, name string, quantity int, price float64) error {
-	args := m.Called(id, name, quantity, price)
+func (m *MockTransactionUseCase) CreateTransaction(transaction *transactions.Transaction) error {
+	args := m.Called(transaction)
 	return args.Error(0)
 }
 
@@ -71,3 +77,38 @@ func (m *MockTransactionUseCase) UpdateTransaction(transaction *transactions.Tra
 	args := m.Called(transaction)
 	return args.Error(0)
 }
+
+func (m *MockTransactionUseCase) SetStatus(userID int64, id int64, status string) error {
+	args := m.Called(userID, id, status)
+	return args.Error(0)
+}
+
+func (m *MockTransactionUseCase) Lock(userID int64, id int64) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
+
+func (m *MockTransactionUseCase) Unlock(userID int64, id int64) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
+
+func (m *MockTransactionUseCase) ListTransactions(userID int64, filter *transactions.Filter, page *transactions.Page) (*transactions.PageResult, error) {
+	args := m.Called(userID, filter, page)
+	return args.Get(0).(*transactions.PageResult), args.Error(1)
+}
+
+func (m *MockTransactionUseCase) SearchTransactions(userID int64, query string, limit int) ([]*transactions.SearchResult, error) {
+	args := m.Called(userID, query, limit)
+	return args.Get(0).([]*transactions.SearchResult), args.Error(1)
+}
+
+func (m *MockTransactionUseCase) TagTransactions(userID int64, ids []int64, add []int64, remove []int64) error {
+	args := m.Called(userID, ids, add, remove)
+	return args.Error(0)
+}
+
+func (m *MockTransactionUseCase) IndexSearch(now time.Time) error {
+	args := m.Called(now)
+	return args.Error(0)
+}
@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchTerms(t *testing.T) {
+	terms, err := transactions.SearchTerms(`Padaria "São" JOÃO, de sao +pad*`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"padaria", "sao", "joao", "pad"}, terms)
+
+	_, err = transactions.SearchTerms("a de -")
+	assert.True(t, errors.IsValidationError(err), "expected validation error, got %v", err)
+
+	assert.Equal(t, "padaria sao joao cafe da manha", transactions.SearchText("Padaria São João", []string{"Café da manhã"}))
+}
+
+func TestHighlightResult(t *testing.T) {
+	transaction := transactions.NewTransaction(1, 0, "PADARIA SÃO JOÃO", 1, money.MustParse("-30.00", "BRL"))
+	transaction.Splits = []*transactions.Split{
+		{Category: 1, Amount: money.MustParse("-20.00", "BRL"), Note: "Pão"},
+		{Category: 2, Amount: money.MustParse("-10.00", "BRL"), Note: "Café com pão de queijo"},
+	}
+
+	result := &transactions.SearchResult{Transaction: transaction}
+	transactions.HighlightResult(result, []string{"sao", "joa"})
+	assert.Equal(t, "PADARIA SÃO JOÃO", result.Snippet)
+	assert.Equal(t, []transactions.Highlight{{Start: 8, End: 11}, {Start: 12, End: 16}}, result.Highlights)
+
+	transactions.HighlightResult(result, []string{"pao", "queijo"})
+	assert.Equal(t, "Café com pão de queijo", result.Snippet)
+	assert.Equal(t, []transactions.Highlight{{Start: 9, End: 12}, {Start: 16, End: 22}}, result.Highlights)
+
+	transaction.Splits = nil
+	transaction.Description = strings.Repeat("compra ", 30) + "Posto Ipiranga"
+	transactions.HighlightResult(result, []string{"ipir"})
+	runes := []rune(result.Snippet)
+	require.Len(t, result.Highlights, 1)
+	assert.Equal(t, "Ipiranga", string(runes[result.Highlights[0].Start:result.Highlights[0].End]))
+	assert.True(t, strings.HasPrefix(result.Snippet, "…"))
+}
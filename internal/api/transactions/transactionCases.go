@@ -1,30 +1,150 @@
 package transactions
 
 import (
+	"fmt"
+	"log"
 	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/accounts"
+	"github.com/Renan-Parise/finances/internal/api/currencies"
+	"github.com/Renan-Parise/finances/internal/cache"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/pkg/money"
 )
 
+const maxTaggedAtOnce = 1000
+
 type TransactionUseCase interface {
 	FilterTransactions(userID int64, filter *Filter) ([]*Transaction, error)
-	CreateTransaction(userID int64, description string, category int, amount float64) error
+	ListTransactions(userID int64, filter *Filter, page *Page) (*PageResult, error)
+	SearchTransactions(userID int64, query string, limit int) ([]*SearchResult, error)
+	CreateTransaction(transaction *Transaction) error
 	GetTransactions(userID int64) ([]*Transaction, error)
 	UpdateTransaction(transaction *Transaction) error
 	DeleteTransaction(userID int64, id int64) error
+	SetStatus(userID int64, id int64, status string) error
+	Lock(userID int64, id int64) error
+	Unlock(userID int64, id int64) error
+	TagTransactions(userID int64, ids []int64, add []int64, remove []int64) error
+	IndexSearch(now time.Time) error
+}
+
+// Enricher fills in transactions from the user's automation rules before they
+// are stored.
+type Enricher interface {
+	Enrich(userID int64, batch []*Transaction) error
+}
+
+// Learner keeps models trained on the user's history, such as category
+// suggestions, current as transactions are written.
+type Learner interface {
+	Learn(userID int64, batch []*Transaction) error
+	Forget(userID int64, batch []*Transaction) error
+}
+
+// Relearn makes the learner forget the before transactions and learn the after
+// ones.
+func Relearn(learner Learner, userID int64, before, after []*Transaction) {
+	if len(before) > 0 {
+		if err := learner.Forget(userID, before); err != nil {
+			log.Printf("Failed to update suggestions for user %d: %v", userID, err)
+			return
+		}
+	}
+
+	if len(after) > 0 {
+		if err := learner.Learn(userID, after); err != nil {
+			log.Printf("Failed to update suggestions for user %d: %v", userID, err)
+		}
+	}
 }
 
 type transactionUseCase struct {
 	transactionRepo TransactionRepositories
+	accountRepo     accounts.AccountRepository
+	currencyRepo    currencies.CurrencyRepository
+	enricher        Enricher
+	learner         Learner
+	cache           cache.Invalidator
 }
 
-func NewTransactionUseCase(tr TransactionRepositories) TransactionUseCase {
+func NewTransactionUseCase(tr TransactionRepositories, ar accounts.AccountRepository, cr currencies.CurrencyRepository,
+	en Enricher, ln Learner, ci cache.Invalidator) TransactionUseCase {
 	return &transactionUseCase{
 		transactionRepo: tr,
+		accountRepo:     ar,
+		currencyRepo:    cr,
+		enricher:        en,
+		learner:         ln,
+		cache:           ci,
+	}
+}
+
+func (uc *transactionUseCase) SearchTransactions(userID int64, query string, limit int) ([]*SearchResult, error) {
+	if limit == 0 {
+		limit = DefaultPageSize
+	}
+
+	if limit < 0 || limit > MaxPageSize {
+		return nil, errors.NewValidationError("limit", fmt.Sprintf("limit must be between 1 and %d", MaxPageSize))
+	}
+
+	terms, err := SearchTerms(query)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := uc.transactionRepo.Search(userID, terms, time.Now(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		HighlightResult(result, terms)
+	}
+
+	if results == nil {
+		results = []*SearchResult{}
+	}
+	return results, nil
+}
+
+func (uc *transactionUseCase) CreateTransaction(transaction *Transaction) error {
+	if transaction.Status == "" {
+		transaction.Status = StatusPending
+	}
+
+	if err := validateStatus(transaction.Status); err != nil {
+		return err
+	}
+
+	if err := validateSplits(transaction); err != nil {
+		return err
+	}
+
+	var err error
+	if transaction.Tags, err = NormalizeTags(transaction.Tags); err != nil {
+		return err
+	}
+
+	if err := uc.enrich(transaction); err != nil {
+		return err
+	}
+
+	if err := uc.resolveAccount(transaction); err != nil {
+		return err
+	}
+
+	if err := uc.transactionRepo.Create(transaction); err != nil {
+		return err
 	}
+	cache.InvalidateUserOrLog(uc.cache, transaction.UserID)
+	uc.learn(transaction.UserID, nil, transaction)
+	return nil
 }
 
-func (uc *transactionUseCase) CreateTransaction(userID int64, description string, category int, amount float64) error {
-	transaction := NewTransaction(userID, description, category, amount)
-	return uc.transactionRepo.Create(transaction)
+func (uc *transactionUseCase) IndexSearch(now time.Time) error {
+	return uc.transactionRepo.IndexSearch()
 }
 
 func (uc *transactionUseCase) GetTransactions(userID int64) ([]*Transaction, error) {
@@ -32,14 +152,324 @@ func (uc *transactionUseCase) GetTransactions(userID int64) ([]*Transaction, err
 }
 
 func (uc *transactionUseCase) UpdateTransaction(transaction *Transaction) error {
+	existing, err := uc.getEditable(transaction.UserID, transaction.ID)
+	if err != nil {
+		return err
+	}
+
+	if transaction.Status == "" || transaction.Status == existing.Status {
+		transaction.Status = existing.Status
+		transaction.ReconciliationID = existing.ReconciliationID
+	} else if err := validateStatus(transaction.Status); err != nil {
+		return err
+	}
+
+	if transaction.Splits == nil {
+		transaction.Splits = existing.Splits
+	}
+	if err := validateSplits(transaction); err != nil {
+		return err
+	}
+
+	transaction.CreatedAt = existing.CreatedAt
+	if transaction.AccountID == 0 {
+		transaction.AccountID = existing.AccountID
+	}
+	if transaction.OccurredAt.IsZero() {
+		transaction.OccurredAt = existing.OccurredAt
+	}
+
+	if transaction.Tags == nil {
+		transaction.Tags = existing.Tags
+	}
+	if transaction.Tags, err = NormalizeTags(transaction.Tags); err != nil {
+		return err
+	}
+
+	if err := uc.enrich(transaction); err != nil {
+		return err
+	}
+
+	if transaction.Currency == "" && transaction.AccountID == 0 {
+		transaction.Currency = existing.Currency
+	}
+
+	if err := uc.resolveAccount(transaction); err != nil {
+		return err
+	}
+
 	transaction.UpdatedAt = time.Now()
-	return uc.transactionRepo.Update(transaction)
+	if err := uc.transactionRepo.Update(transaction); err != nil {
+		return err
+	}
+	cache.InvalidateUserOrLog(uc.cache, transaction.UserID)
+	uc.learn(transaction.UserID, existing, transaction)
+	return nil
 }
 
 func (uc *transactionUseCase) DeleteTransaction(userID int64, id int64) error {
-	return uc.transactionRepo.Delete(userID, id)
+	existing, err := uc.getEditable(userID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.transactionRepo.Delete(userID, id); err != nil {
+		return err
+	}
+	cache.InvalidateUserOrLog(uc.cache, userID)
+	uc.learn(userID, existing, nil)
+	return nil
 }
 
 func (uc *transactionUseCase) FilterTransactions(userID int64, filter *Filter) ([]*Transaction, error) {
 	return uc.transactionRepo.Filter(userID, filter)
 }
+
+func (uc *transactionUseCase) ListTransactions(userID int64, filter *Filter, page *Page) (*PageResult, error) {
+	if page.Limit == 0 {
+		page.Limit = DefaultPageSize
+	}
+
+	if page.Limit < 0 || page.Limit > MaxPageSize {
+		return nil, errors.NewValidationError("limit", fmt.Sprintf("limit must be between 1 and %d", MaxPageSize))
+	}
+
+	keys, err := SortKeys(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var after *Cursor
+	if page.Cursor != "" {
+		cursor, err := DecodeCursor(page.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		if cursor.Sort != sortSignature(keys) || len(cursor.Values) != len(keys) {
+			return nil, errors.NewValidationError("cursor", "cursor belongs to a listing with a different sort")
+		}
+		after = cursor
+	}
+
+	batch, err := uc.transactionRepo.FilterPage(userID, filter, after, page.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PageResult{Transactions: batch}
+	if len(batch) > page.Limit {
+		result.Transactions = batch[:page.Limit]
+		result.Next = EncodeCursor(NewCursor(batch[page.Limit-1], keys))
+	}
+
+	if result.Transactions == nil {
+		result.Transactions = []*Transaction{}
+	}
+
+	if page.WithTotal {
+		if result.Total, err = uc.transactionRepo.Count(userID, filter); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (uc *transactionUseCase) SetStatus(userID int64, id int64, status string) error {
+	if err := validateStatus(status); err != nil {
+		return err
+	}
+
+	if _, err := uc.getUnlocked(userID, id); err != nil {
+		return err
+	}
+
+	return uc.transactionRepo.SetStatus(userID, id, status)
+}
+
+func (uc *transactionUseCase) Lock(userID int64, id int64) error {
+	transaction, err := uc.getTransaction(userID, id)
+	if err != nil {
+		return err
+	}
+
+	if transaction.Status != StatusReconciled {
+		return errors.NewValidationError("id", "only reconciled transactions can be locked")
+	}
+	return uc.transactionRepo.SetLocked(userID, id, true)
+}
+
+func (uc *transactionUseCase) Unlock(userID int64, id int64) error {
+	if _, err := uc.getTransaction(userID, id); err != nil {
+		return err
+	}
+	return uc.transactionRepo.SetLocked(userID, id, false)
+}
+
+func (uc *transactionUseCase) TagTransactions(userID int64, ids []int64, add []int64, remove []int64) error {
+	if len(ids) == 0 {
+		return errors.NewValidationError("transactionIds", "at least one transaction is required")
+	}
+
+	if len(ids) > maxTaggedAtOnce {
+		return errors.NewValidationError("transactionIds", fmt.Sprintf("at most %d transactions can be tagged at once", maxTaggedAtOnce))
+	}
+
+	if len(add) == 0 && len(remove) == 0 {
+		return errors.NewValidationError("tags", "nothing to add or remove")
+	}
+
+	var err error
+	if add, err = NormalizeTags(add); err != nil {
+		return err
+	}
+	if remove, err = NormalizeTags(remove); err != nil {
+		return err
+	}
+
+	for _, tag := range add {
+		for _, removed := range remove {
+			if tag == removed {
+				return errors.NewValidationError("tags", "a tag cannot be both added and removed")
+			}
+		}
+	}
+
+	unique := make(map[int64]bool, len(ids))
+	var transactionIDs []int64
+	for _, id := range ids {
+		if !unique[id] {
+			unique[id] = true
+			transactionIDs = append(transactionIDs, id)
+		}
+	}
+
+	if err := uc.transactionRepo.ChangeTags(userID, transactionIDs, add, remove); err != nil {
+		return err
+	}
+	cache.InvalidateUserOrLog(uc.cache, userID)
+	return nil
+}
+
+func (uc *transactionUseCase) getTransaction(userID int64, id int64) (*Transaction, error) {
+	transaction, err := uc.transactionRepo.GetByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction == nil {
+		return nil, errors.NewValidationError("id", "transaction not found")
+	}
+	return transaction, nil
+}
+
+func (uc *transactionUseCase) getUnlocked(userID int64, id int64) (*Transaction, error) {
+	transaction, err := uc.getTransaction(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction.Locked {
+		return nil, errors.NewValidationError("id", "transaction is reconciled and locked; unlock it first")
+	}
+	return transaction, nil
+}
+
+func (uc *transactionUseCase) getEditable(userID int64, id int64) (*Transaction, error) {
+	transaction, err := uc.getUnlocked(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction.TransferID != 0 {
+		return nil, errors.NewValidationError("id", "transaction belongs to a transfer; change the transfer instead")
+	}
+	return transaction, nil
+}
+
+func (uc *transactionUseCase) enrich(transaction *Transaction) error {
+	if err := uc.enricher.Enrich(transaction.UserID, []*Transaction{transaction}); err != nil {
+		return err
+	}
+
+	if transaction.Category == 0 {
+		return errors.NewValidationError("category", "category is required; no rule matched this transaction")
+	}
+	return nil
+}
+
+func validateStatus(status string) error {
+	switch status {
+	case StatusPending, StatusCleared:
+		return nil
+	case StatusReconciled:
+		return errors.NewValidationError("status", "transactions are reconciled by finishing a reconciliation of their account")
+	}
+	return errors.NewValidationError("status", "status must be pending or cleared")
+}
+
+func validateSplits(transaction *Transaction) error {
+	if len(transaction.Splits) == 0 {
+		return nil
+	}
+
+	if len(transaction.Splits) == 1 {
+		return errors.NewValidationError("splits", "a split transaction needs at least two lines")
+	}
+
+	total := money.Zero(transaction.Amount.Currency)
+	var largest *Split
+	for _, split := range transaction.Splits {
+		if split.Category == 0 {
+			return errors.NewValidationError("splits", "every split line needs a category")
+		}
+
+		if split.Amount.IsZero() {
+			return errors.NewValidationError("splits", "split amounts must be different from zero")
+		}
+
+		if split.Amount.IsNegative() != transaction.Amount.IsNegative() {
+			return errors.NewValidationError("splits", "split amounts must have the same sign as the transaction amount")
+		}
+
+		total.Amount += split.Amount.Amount
+		if largest == nil || split.Amount.Abs().Cmp(largest.Amount.Abs()) > 0 {
+			largest = split
+		}
+	}
+
+	if total.Amount != transaction.Amount.Amount {
+		return errors.NewValidationError("splits", "split amounts must add up to "+transaction.Amount.String())
+	}
+
+	if transaction.Category == 0 {
+		transaction.Category = largest.Category
+	}
+	return nil
+}
+
+func (uc *transactionUseCase) resolveAccount(transaction *Transaction) error {
+	currency, err := accounts.ResolveCurrency(uc.accountRepo, uc.currencyRepo, transaction.UserID,
+		transaction.AccountID, transaction.Currency)
+	if err != nil {
+		return err
+	}
+
+	transaction.Currency = currency
+	transaction.Amount.Currency = currency
+	for _, split := range transaction.Splits {
+		split.Amount.Currency = currency
+	}
+	return nil
+}
+
+func (uc *transactionUseCase) learn(userID int64, before, after *Transaction) {
+	var forget, learn []*Transaction
+	if before != nil {
+		forget = []*Transaction{before}
+	}
+	if after != nil {
+		learn = []*Transaction{after}
+	}
+	Relearn(uc.learner, userID, forget, learn)
+}
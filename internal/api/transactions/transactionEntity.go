@@ -1,23 +1,141 @@
 package transactions
 
-import "time"
+import (
+	"time"
 
+	"github.com/Renan-Parise/finances/internal/ledger"
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+const (
+	StatusPending    = ledger.StatusPending
+	StatusCleared    = "cleared"
+	StatusReconciled = "reconciled"
+)
+
+// Transaction status follows the bank statement.
 type Transaction struct {
-	ID          int64     `json:"id"`
-	UserID      int64     `json:"userId"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
-	Description string    `json:"description"`
-	Category    int       `json:"category"`
-	Amount      float64   `json:"amount"`
+	ID               int64       `json:"id"`
+	UserID           int64       `json:"userId"`
+	AccountID        int64       `json:"accountId,omitempty"`
+	TransferID       int64       `json:"transferId,omitempty"`
+	OccurredAt       time.Time   `json:"occurredAt"`
+	CreatedAt        time.Time   `json:"createdAt"`
+	UpdatedAt        time.Time   `json:"updatedAt"`
+	Description      string      `json:"description"`
+	Category         int         `json:"category"`
+	Amount           money.Money `json:"amount"`
+	Currency         string      `json:"currency"`
+	Status           string      `json:"status"`
+	Locked           bool        `json:"locked"`
+	ReconciliationID int64       `json:"reconciliationId,omitempty"`
+	Splits           []*Split    `json:"splits,omitempty"`
+	Tags             []int64     `json:"tags,omitempty"`
+}
+
+type Split struct {
+	ID            int64       `json:"id"`
+	TransactionID int64       `json:"transactionId"`
+	Category      int         `json:"category"`
+	Amount        money.Money `json:"amount"`
+	Note          string      `json:"note,omitempty"`
+}
+
+// SelectableFields are the fields a listing may be narrowed to.
+var SelectableFields = map[string]bool{
+	"id":               true,
+	"userId":           true,
+	"accountId":        true,
+	"transferId":       true,
+	"occurredAt":       true,
+	"createdAt":        true,
+	"updatedAt":        true,
+	"description":      true,
+	"category":         true,
+	"amount":           true,
+	"currency":         true,
+	"status":           true,
+	"locked":           true,
+	"reconciliationId": true,
+	"splits":           true,
+	"tags":             true,
 }
 
+// SortableFields are the columns a listing may sort by.
+var SortableFields = map[string]bool{
+	"occurredAt":  true,
+	"createdAt":   true,
+	"description": true,
+	"amount":      true,
+}
+
+// Filter narrows a listing.
 type Filter struct {
-	Category int    `json:"category"`
-	Search   string `json:"search"`
-	Order    string `json:"order"`
-	Field    string `json:"field"`
-	From     string `json:"from"`
-	File     string `json:"file"`
-	To       string `json:"to"`
+	Category  int     `json:"category"`
+	AccountID int64   `json:"accountId"`
+	Status    string  `json:"status"`
+	Search    string  `json:"search"`
+	Order     string  `json:"order"`
+	Field     string  `json:"field"`
+	From      string  `json:"from"`
+	File      string  `json:"file"`
+	To        string  `json:"to"`
+	Query     string  `json:"query"`
+	Sort      string  `json:"sort"`
+	Tags      []int64 `json:"tags"`
+}
+
+// Aggregate summarizes the transactions of one currency matching a filter.
+type Aggregate struct {
+	Currency string      `json:"currency"`
+	Count    int         `json:"count"`
+	Sum      money.Money `json:"sum"`
+	Average  money.Money `json:"average"`
+}
+
+// SearchResult is a transaction found by a full-text search, best first.
+type SearchResult struct {
+	Transaction *Transaction `json:"transaction"`
+	Score       float64      `json:"score"`
+	Snippet     string       `json:"snippet"`
+	Highlights  []Highlight  `json:"highlights"`
+}
+
+// Highlight is a range of runes in a snippet, End being exclusive.
+type Highlight struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// SortKey is one column of a sort, the most significant first.
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+// Page asks for one page of a listing.
+type Page struct {
+	Limit     int
+	Cursor    string
+	WithTotal bool
+}
+
+// PageResult is one page of a listing.
+type PageResult struct {
+	Transactions []*Transaction
+	Next         string
+	Total        int
+}
+
+// Cursor is the position right after the last transaction of a page: its
+// values for each sort key, with the id breaking ties.
+type Cursor struct {
+	Sort   string   `json:"s"`
+	Values []string `json:"v"`
+	ID     int64    `json:"i"`
 }
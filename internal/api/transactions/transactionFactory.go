@@ -1,17 +1,179 @@
 package transactions
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/ledger"
+	"github.com/Renan-Parise/finances/pkg/money"
 )
 
-func NewTransaction(userID int64, description string, category int, amount float64) *Transaction {
+func NewTransaction(userID, accountID int64, description string, category int, amount money.Money) *Transaction {
 	now := time.Now()
 	return &Transaction{
 		UserID:      userID,
+		AccountID:   accountID,
+		OccurredAt:  now,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 		Description: description,
 		Category:    category,
 		Amount:      amount,
+		Status:      StatusPending,
+	}
+}
+
+// NormalizeTags sorts tag IDs and drops repeated ones.
+func NormalizeTags(ids []int64) ([]int64, error) {
+	return ledger.NormalizeTags(ids)
+}
+
+// LedgerEntry returns the row ledger.Insert stores for the transaction.
+func (t *Transaction) LedgerEntry() *ledger.Entry {
+	return &ledger.Entry{
+		UserID:      t.UserID,
+		AccountID:   t.AccountID,
+		TransferID:  t.TransferID,
+		OccurredAt:  t.OccurredAt,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+		Description: t.Description,
+		Category:    int64(t.Category),
+		Amount:      t.Amount,
+		Currency:    t.Currency,
+		Status:      t.Status,
+		Tags:        t.Tags,
+		Notes:       t.notes(),
+	}
+}
+
+func (t *Transaction) notes() []string {
+	notes := make([]string, 0, len(t.Splits))
+	for _, split := range t.Splits {
+		notes = append(notes, split.Note)
+	}
+	return notes
+}
+
+var occurredLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// ParseOccurredAt reads when a transaction happened: a date, a local date and
+// time, or an RFC 3339 timestamp whose offset is kept as the time zone.
+func ParseOccurredAt(value string) (time.Time, error) {
+	for _, layout := range occurredLayouts {
+		if occurredAt, err := time.Parse(layout, value); err == nil {
+			return occurredAt, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD or an RFC 3339 timestamp", value)
+}
+
+// SortKeys returns the keys a filter sorts by.
+func SortKeys(filter *Filter) ([]SortKey, error) {
+	if filter.Sort != "" {
+		return ParseSort(filter.Sort)
+	}
+
+	if filter.Field == "" {
+		return []SortKey{{Field: "occurredAt", Desc: true}}, nil
+	}
+
+	if !SortableFields[filter.Field] {
+		return nil, errors.NewValidationError("field", "invalid field for sorting: "+filter.Field)
+	}
+	return []SortKey{{Field: filter.Field, Desc: strings.ToUpper(filter.Order) == "DESC"}}, nil
+}
+
+func sortSignature(keys []SortKey) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key.Field + " " + direction(key.Desc)
+	}
+	return strings.Join(parts, ",")
+}
+
+func direction(desc bool) string {
+	if desc {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+// NewCursor points right after transaction in a listing sorted by keys.
+func NewCursor(transaction *Transaction, keys []SortKey) *Cursor {
+	cursor := &Cursor{Sort: sortSignature(keys), ID: transaction.ID}
+	for _, key := range keys {
+		var value string
+		switch key.Field {
+		case "occurredAt":
+			occurredAt, _ := ledger.StoredOccurredAt(transaction.OccurredAt)
+			value = occurredAt.Format("2006-01-02 15:04:05")
+		case "createdAt":
+			value = transaction.CreatedAt.UTC().Format("2006-01-02 15:04:05")
+		case "description":
+			value = transaction.Description
+		case "amount":
+			value = transaction.Amount.String()
+		}
+		cursor.Values = append(cursor.Values, value)
+	}
+	return cursor
+}
+
+func EncodeCursor(cursor *Cursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(value string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.NewValidationError("cursor", "invalid cursor")
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Sort == "" || len(cursor.Values) == 0 || cursor.ID == 0 {
+		return nil, errors.NewValidationError("cursor", "invalid cursor")
+	}
+	return &cursor, nil
+}
+
+// SelectFields narrows every transaction down to the given JSON fields, for
+// clients that only need a few of them.
+func SelectFields(batch []*Transaction, fields []string) ([]map[string]json.RawMessage, error) {
+	for _, field := range fields {
+		if !SelectableFields[field] {
+			return nil, errors.NewValidationError("fields", "unknown field: "+field)
+		}
+	}
+
+	selected := make([]map[string]json.RawMessage, len(batch))
+	for i, transaction := range batch {
+		data, err := json.Marshal(transaction)
+		if err != nil {
+			return nil, err
+		}
+
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(data, &all); err != nil {
+			return nil, err
+		}
+
+		selected[i] = make(map[string]json.RawMessage, len(fields))
+		for _, field := range fields {
+			if value, ok := all[field]; ok {
+				selected[i][field] = value
+			}
+		}
 	}
+	return selected, nil
 }
@@ -3,8 +3,12 @@ package transactions
 import (
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/Renan-Parise/finances/internal/errors"
 	"github.com/Renan-Parise/finances/internal/middlewares"
+	"github.com/Renan-Parise/finances/pkg/money"
 
 	"github.com/gin-gonic/gin"
 )
@@ -22,8 +26,13 @@ func NewTransactionHandler(router *gin.RouterGroup, tu TransactionUseCase) {
 	transactions.Use(middlewares.JWTAuthMiddleware())
 	{
 		transactions.POST("/filter", handler.FilterTransactions)
+		transactions.GET("/search", handler.SearchTransactions)
+		transactions.POST("/tags", handler.TagTransactions)
 		transactions.DELETE("/:id", handler.DeleteTransaction)
 		transactions.PUT("/:id", handler.UpdateTransaction)
+		transactions.PUT("/:id/status", handler.SetStatus)
+		transactions.POST("/:id/lock", handler.LockTransaction)
+		transactions.POST("/:id/unlock", handler.UnlockTransaction)
 		transactions.POST("/", handler.CreateTransaction)
 		transactions.GET("/", handler.GetTransactions)
 	}
@@ -37,9 +46,15 @@ func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 	}
 
 	var input struct {
-		Description string  `json:"description" binding:"required"`
-		Category    int     `json:"category" binding:"required"`
-		Amount      float64 `json:"amount" binding:"required"`
+		AccountID   int64       `json:"accountId"`
+		Description string      `json:"description" binding:"required"`
+		Category    int         `json:"category"`
+		Amount      money.Money `json:"amount"`
+		OccurredAt  string      `json:"occurredAt"`
+		Currency    string      `json:"currency"`
+		Status      string      `json:"status"`
+		Splits      []*Split    `json:"splits"`
+		Tags        []int64     `json:"tags"`
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -47,8 +62,31 @@ func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 		return
 	}
 
-	err := h.transactionUseCase.CreateTransaction(userID.(int64), input.Description, input.Category, input.Amount)
+	if input.Amount.IsZero() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Amount must be different from zero"})
+		return
+	}
+
+	transaction := NewTransaction(userID.(int64), input.AccountID, input.Description, input.Category, input.Amount)
+	if input.OccurredAt != "" {
+		occurredAt, err := ParseOccurredAt(input.OccurredAt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		transaction.OccurredAt = occurredAt
+	}
+	transaction.Currency = input.Currency
+	transaction.Status = input.Status
+	transaction.Splits = input.Splits
+	transaction.Tags = input.Tags
+
+	err := h.transactionUseCase.CreateTransaction(transaction)
 	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -63,13 +101,7 @@ func (h *TransactionHandler) GetTransactions(c *gin.Context) {
 		return
 	}
 
-	transactions, err := h.transactionUseCase.GetTransactions(userID.(int64))
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-
-	c.JSON(http.StatusOK, transactions)
+	h.listTransactions(c, userID.(int64), &Filter{Query: c.Query("q"), Sort: c.Query("sort")})
 }
 
 func (h *TransactionHandler) UpdateTransaction(c *gin.Context) {
@@ -87,9 +119,15 @@ func (h *TransactionHandler) UpdateTransaction(c *gin.Context) {
 	}
 
 	var input struct {
-		Description string  `json:"description" binding:"required"`
-		Category    int     `json:"category" binding:"required"`
-		Amount      float64 `json:"amount" binding:"required"`
+		AccountID   int64       `json:"accountId"`
+		Description string      `json:"description" binding:"required"`
+		Category    int         `json:"category"`
+		Amount      money.Money `json:"amount"`
+		OccurredAt  string      `json:"occurredAt"`
+		Currency    string      `json:"currency"`
+		Status      string      `json:"status"`
+		Splits      []*Split    `json:"splits"`
+		Tags        []int64     `json:"tags"`
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -97,16 +135,39 @@ func (h *TransactionHandler) UpdateTransaction(c *gin.Context) {
 		return
 	}
 
+	if input.Amount.IsZero() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Amount must be different from zero"})
+		return
+	}
+
+	var occurredAt time.Time
+	if input.OccurredAt != "" {
+		if occurredAt, err = ParseOccurredAt(input.OccurredAt); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
 	transaction := &Transaction{
 		ID:          id,
+		OccurredAt:  occurredAt,
 		UserID:      userID.(int64),
+		AccountID:   input.AccountID,
 		Description: input.Description,
 		Category:    input.Category,
 		Amount:      input.Amount,
+		Currency:    input.Currency,
+		Status:      input.Status,
+		Splits:      input.Splits,
+		Tags:        input.Tags,
 	}
 
 	err = h.transactionUseCase.UpdateTransaction(transaction)
 	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -130,6 +191,10 @@ func (h *TransactionHandler) DeleteTransaction(c *gin.Context) {
 
 	err = h.transactionUseCase.DeleteTransaction(userID.(int64), id)
 	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -150,11 +215,212 @@ func (h *TransactionHandler) FilterTransactions(c *gin.Context) {
 		return
 	}
 
-	transactions, err := h.transactionUseCase.FilterTransactions(userID.(int64), &input)
+	h.listTransactions(c, userID.(int64), &input)
+}
+
+func (h *TransactionHandler) listTransactions(c *gin.Context, userID int64, filter *Filter) {
+	page := &Page{Cursor: c.Query("cursor"), WithTotal: c.Query("total") == "true"}
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+		page.Limit = limit
+	}
+
+	result, err := h.transactionUseCase.ListTransactions(userID, filter, page)
+	if err != nil {
+		if validation, ok := err.(*errors.ValidationError); ok && validation.Position > 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "field": validation.Field, "position": validation.Position})
+			return
+		}
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var body interface{} = result.Transactions
+	if fields := c.Query("fields"); fields != "" {
+		selected, err := SelectFields(result.Transactions, strings.Split(fields, ","))
+		if err != nil {
+			if errors.IsValidationError(err) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		body = selected
+	}
+
+	if result.Next != "" {
+		next := *c.Request.URL
+		query := next.Query()
+		query.Set("cursor", result.Next)
+		query.Set("limit", strconv.Itoa(page.Limit))
+		next.RawQuery = query.Encode()
+		c.Header("Link", "<"+next.RequestURI()+">; rel=\"next\"")
+	}
+
+	if page.WithTotal {
+		c.Header("X-Total-Count", strconv.Itoa(result.Total))
+	}
+
+	c.JSON(http.StatusOK, body)
+}
+
+// SearchTransactions looks up the words in the q query parameter, best and
+// most recent matches first, up to limit results.
+func (h *TransactionHandler) SearchTransactions(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	var limit int
+	if value := c.Query("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+	}
+
+	results, err := h.transactionUseCase.SearchTransactions(userID.(int64), c.Query("q"), limit)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+func (h *TransactionHandler) TagTransactions(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	var input struct {
+		TransactionIDs []int64 `json:"transactionIds" binding:"required"`
+		Add            []int64 `json:"add"`
+		Remove         []int64 `json:"remove"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.transactionUseCase.TagTransactions(userID.(int64), input.TransactionIDs, input.Add, input.Remove)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Transactions tagged successfully"})
+}
+
+func (h *TransactionHandler) SetStatus(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID"})
+		return
+	}
+
+	var input struct {
+		Status string `json:"status" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.transactionUseCase.SetStatus(userID.(int64), id, input.Status)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Transaction status updated successfully"})
+}
+
+func (h *TransactionHandler) LockTransaction(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID"})
+		return
+	}
+
+	err = h.transactionUseCase.Lock(userID.(int64), id)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Transaction locked successfully"})
+}
+
+func (h *TransactionHandler) UnlockTransaction(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID"})
+		return
+	}
+
+	err = h.transactionUseCase.Unlock(userID.(int64), id)
 	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, transactions)
+	c.JSON(http.StatusOK, gin.H{"message": "Transaction unlocked successfully"})
 }
@@ -2,21 +2,37 @@ package transactions
 
 import (
 	"database/sql"
-	"fmt"
+	"math/big"
 	"strings"
+	"time"
 
 	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/ledger"
+	"github.com/Renan-Parise/finances/pkg/money"
 )
 
 type TransactionRepositories interface {
 	Create(transaction *Transaction) error
+	CreateBatch(transactions []*Transaction) error
 	GetAll(userID int64) ([]*Transaction, error)
 	GetByID(userID int64, id int64) (*Transaction, error)
 	Update(transaction *Transaction) error
 	Delete(userID int64, id int64) error
 	Filter(userID int64, filter *Filter) ([]*Transaction, error)
+	Stream(userID int64, filter *Filter, fn func(*Transaction) error) error
+	FilterPage(userID int64, filter *Filter, after *Cursor, limit int) ([]*Transaction, error)
+	Count(userID int64, filter *Filter) (int, error)
+	Aggregate(userID int64, filter *Filter) ([]*Aggregate, error)
+	IndexSearch() error
+	Search(userID int64, terms []string, today time.Time, limit int) ([]*SearchResult, error)
+	SetStatus(userID int64, id int64, status string) error
+	SetLocked(userID int64, id int64, locked bool) error
+	ChangeTags(userID int64, ids []int64, add []int64, remove []int64) error
 }
 
+const transactionColumns = `id, userId, COALESCE(accountId, 0), COALESCE(transferId, 0), occurredAt, occurredOffset, createdAt, updatedAt,
+              description, COALESCE(category, 0), amount, currency, status, locked, COALESCE(reconciliationId, 0)`
+
 type transactionRepositories struct {
 	db *sql.DB
 }
@@ -28,29 +44,47 @@ func NewTransactionRepositories(db *sql.DB) TransactionRepositories {
 }
 
 func (r *transactionRepositories) Create(transaction *Transaction) error {
-	query := `INSERT INTO transactions (userId, createdAt, updatedAt, description, category, amount)
-              VALUES (?, ?, ?, ?, ?, ?)`
-	stmt, err := r.db.Prepare(query)
+	return r.CreateBatch([]*Transaction{transaction})
+}
+
+func (r *transactionRepositories) CreateBatch(transactions []*Transaction) error {
+	tx, err := r.db.Begin()
 	if err != nil {
-		return errors.NewQueryError("error preparing query: " + err.Error())
+		return errors.NewDatabaseError("error starting transaction: " + err.Error())
 	}
-	defer stmt.Close()
+	defer tx.Rollback()
 
-	res, err := stmt.Exec(transaction.UserID, transaction.CreatedAt, transaction.UpdatedAt,
-		transaction.Description, transaction.Category, transaction.Amount)
-	if err != nil {
-		return errors.NewQueryError("error executing query: " + err.Error())
+	ids := make([]int64, len(transactions))
+	for i, transaction := range transactions {
+		if transaction.Status == "" {
+			transaction.Status = StatusPending
+		}
+
+		if transaction.OccurredAt.IsZero() {
+			transaction.OccurredAt = transaction.CreatedAt
+		}
+
+		if ids[i], err = ledger.Insert(tx, transaction.LedgerEntry()); err != nil {
+			return err
+		}
+
+		if err := insertSplits(tx, ids[i], transaction.Splits); err != nil {
+			return err
+		}
 	}
-	id, err := res.LastInsertId()
-	if err != nil {
-		return errors.NewQueryError("error getting last insert ID: " + err.Error())
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+
+	for i, transaction := range transactions {
+		transaction.ID = ids[i]
 	}
-	transaction.ID = id
 	return nil
 }
 
 func (r *transactionRepositories) GetAll(userID int64) ([]*Transaction, error) {
-	query := `SELECT id, userId, createdAt, updatedAt, description, category, amount 
+	query := `SELECT ` + transactionColumns + `
               FROM transactions 
               WHERE userId = ?`
 	rows, err := r.db.Query(query, userID)
@@ -61,46 +95,87 @@ func (r *transactionRepositories) GetAll(userID int64) ([]*Transaction, error) {
 
 	var transactions []*Transaction
 	for rows.Next() {
-		var transaction Transaction
-		err := rows.Scan(&transaction.ID, &transaction.UserID, &transaction.CreatedAt,
-			&transaction.UpdatedAt, &transaction.Description, &transaction.Category, &transaction.Amount)
+		transaction, err := scanTransaction(rows)
 		if err != nil {
 			return nil, errors.NewQueryError("error scanning row: " + err.Error())
 		}
-		transactions = append(transactions, &transaction)
+		transactions = append(transactions, transaction)
+	}
+	if err := r.loadDetails(transactions); err != nil {
+		return nil, err
 	}
 	return transactions, nil
 }
 
 func (r *transactionRepositories) GetByID(userID int64, id int64) (*Transaction, error) {
-	query := `SELECT id, userId, createdAt, updatedAt, description, category, amount 
+	query := `SELECT ` + transactionColumns + `
               FROM transactions 
               WHERE id = ? AND userId = ?`
-	row := r.db.QueryRow(query, id, userID)
-	var transaction Transaction
-	err := row.Scan(&transaction.ID, &transaction.UserID, &transaction.CreatedAt,
-		&transaction.UpdatedAt, &transaction.Description, &transaction.Category, &transaction.Amount)
+	transaction, err := scanTransaction(r.db.QueryRow(query, id, userID))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, errors.NewQueryError("error scanning row: " + err.Error())
 	}
-	return &transaction, nil
+	if err := r.loadDetails([]*Transaction{transaction}); err != nil {
+		return nil, err
+	}
+	return transaction, nil
 }
 
 func (r *transactionRepositories) Update(transaction *Transaction) error {
-	query := `UPDATE transactions SET updatedAt = ?, description = ?, category = ?, amount = ? 
+	tx, err := r.db.Begin()
+	if err != nil {
+		return errors.NewDatabaseError("error starting transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	err = tx.QueryRow(`SELECT locked FROM transactions WHERE id = ? AND userId = ? FOR UPDATE`,
+		transaction.ID, transaction.UserID).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return errors.NewValidationError("id", "transaction not found")
+	}
+	if err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+
+	if locked {
+		return errors.NewValidationError("id", "transaction is reconciled and locked")
+	}
+
+	query := `UPDATE transactions SET accountId = NULLIF(?, 0), occurredAt = ?, occurredOffset = ?, updatedAt = ?, description = ?,
+              searchText = ?, category = NULLIF(?, 0), amount = ?, currency = ?, status = ?, reconciliationId = NULLIF(?, 0)
               WHERE id = ? AND userId = ?`
-	stmt, err := r.db.Prepare(query)
+	occurredAt, offset := ledger.StoredOccurredAt(transaction.OccurredAt)
+	_, err = tx.Exec(query, transaction.AccountID, occurredAt, offset, transaction.UpdatedAt, transaction.Description,
+		SearchText(transaction.Description, transaction.notes()), transaction.Category, transaction.Amount, transaction.Currency, transaction.Status, transaction.ReconciliationID,
+		transaction.ID, transaction.UserID)
 	if err != nil {
-		return errors.NewQueryError("error preparing query: " + err.Error())
+		return errors.NewQueryError("error executing query: " + err.Error())
 	}
-	defer stmt.Close()
 
-	_, err = stmt.Exec(transaction.UpdatedAt, transaction.Description, transaction.Category,
-		transaction.Amount, transaction.ID, transaction.UserID)
-	return err
+	if _, err := tx.Exec(`DELETE FROM transaction_splits WHERE transactionId = ?`, transaction.ID); err != nil {
+		return errors.NewQueryError("error deleting splits: " + err.Error())
+	}
+
+	if err := insertSplits(tx, transaction.ID, transaction.Splits); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM transaction_tags WHERE transactionId = ?`, transaction.ID); err != nil {
+		return errors.NewQueryError("error deleting tags: " + err.Error())
+	}
+
+	if err := ledger.InsertTags(tx, transaction.UserID, transaction.ID, transaction.Tags); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+	return nil
 }
 
 func (r *transactionRepositories) Delete(userID int64, id int64) error {
@@ -115,17 +190,345 @@ func (r *transactionRepositories) Delete(userID int64, id int64) error {
 	return err
 }
 
+func (r *transactionRepositories) SetStatus(userID int64, id int64, status string) error {
+	query := `UPDATE transactions SET status = ?, reconciliationId = NULL WHERE id = ? AND userId = ? AND locked = FALSE`
+	if _, err := r.db.Exec(query, status, id, userID); err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+	return nil
+}
+
+func (r *transactionRepositories) SetLocked(userID int64, id int64, locked bool) error {
+	query := `UPDATE transactions SET locked = ? WHERE id = ? AND userId = ?`
+	if _, err := r.db.Exec(query, locked, id, userID); err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+	return nil
+}
+
+func (r *transactionRepositories) ChangeTags(userID int64, ids []int64, add []int64, remove []int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return errors.NewDatabaseError("error starting transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	transactionList, transactionArgs := ledger.IDList(ids)
+	var owned int
+	err = tx.QueryRow(`SELECT COUNT(*) FROM transactions WHERE userId = ? AND id IN `+transactionList,
+		append([]interface{}{userID}, transactionArgs...)...).Scan(&owned)
+	if err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+
+	if owned != len(ids) {
+		return errors.NewValidationError("transactionIds", "transaction not found")
+	}
+
+	if err := checkTags(tx, userID, append(append([]int64{}, add...), remove...)); err != nil {
+		return err
+	}
+
+	if len(add) > 0 {
+		tagList, tagArgs := ledger.IDList(add)
+		query := `INSERT IGNORE INTO transaction_tags (transactionId, tagId)
+                  SELECT t.id, g.id FROM transactions t JOIN tags g ON g.userId = t.userId
+                  WHERE t.userId = ? AND t.id IN ` + transactionList + ` AND g.id IN ` + tagList
+		args := append(append([]interface{}{userID}, transactionArgs...), tagArgs...)
+		if _, err := tx.Exec(query, args...); err != nil {
+			return errors.NewQueryError("error adding tags: " + err.Error())
+		}
+	}
+
+	if len(remove) > 0 {
+		tagList, tagArgs := ledger.IDList(remove)
+		query := `DELETE FROM transaction_tags WHERE transactionId IN ` + transactionList + ` AND tagId IN ` + tagList
+		if _, err := tx.Exec(query, append(append([]interface{}{}, transactionArgs...), tagArgs...)...); err != nil {
+			return errors.NewQueryError("error removing tags: " + err.Error())
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+	return nil
+}
+
 func (r *transactionRepositories) Filter(userID int64, filter *Filter) ([]*Transaction, error) {
+	query, args, err := filterQuery(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	var transactions []*Transaction
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		transactions = append(transactions, transaction)
+	}
+	if err := r.loadDetails(transactions); err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+const streamBatchSize = 500
+
+func (r *transactionRepositories) Stream(userID int64, filter *Filter, fn func(*Transaction) error) error {
+	query, args, err := filterQuery(userID, filter)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	batch := make([]*Transaction, 0, streamBatchSize)
+	flush := func() error {
+		if err := r.loadDetails(batch); err != nil {
+			return err
+		}
+		for _, transaction := range batch {
+			if err := fn(transaction); err != nil {
+				return err
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return errors.NewQueryError("error scanning row: " + err.Error())
+		}
+
+		batch = append(batch, transaction)
+		if len(batch) == streamBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return errors.NewQueryError("error reading rows: " + err.Error())
+	}
+	return flush()
+}
+
+func (r *transactionRepositories) FilterPage(userID int64, filter *Filter, after *Cursor, limit int) ([]*Transaction, error) {
+	keys, err := SortKeys(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions, args, err := filterConditions(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if after != nil {
+		if len(after.Values) != len(keys) {
+			return nil, errors.NewValidationError("cursor", "cursor belongs to a listing with a different sort")
+		}
+
+		keyset, keysetArgs := keysetCondition(keys, after)
+		conditions += " AND " + keyset
+		args = append(args, keysetArgs...)
+	}
+
+	query := `SELECT ` + transactionColumns + ` FROM transactions WHERE ` + conditions +
+		" ORDER BY " + orderClause(keys) + ", id " + direction(keys[len(keys)-1].Desc) + " LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	var transactions []*Transaction
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		transactions = append(transactions, transaction)
+	}
+	if err := r.loadDetails(transactions); err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+func (r *transactionRepositories) Count(userID int64, filter *Filter) (int, error) {
+	conditions, args, err := filterConditions(userID, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM transactions WHERE `+conditions, args...).Scan(&count); err != nil {
+		return 0, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	return count, nil
+}
+
+func (r *transactionRepositories) Aggregate(userID int64, filter *Filter) ([]*Aggregate, error) {
+	conditions, args, err := filterConditions(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT currency, COUNT(*), COALESCE(SUM(amount), 0) FROM transactions WHERE ` + conditions +
+		` GROUP BY currency ORDER BY currency`
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	aggregates := []*Aggregate{}
+	for rows.Next() {
+		var aggregate Aggregate
+		if err := rows.Scan(&aggregate.Currency, &aggregate.Count, &aggregate.Sum); err != nil {
+			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		aggregate.Sum.Currency = aggregate.Currency
+		aggregate.Average = money.FromRat(new(big.Rat).Quo(aggregate.Sum.Rat(), big.NewRat(int64(aggregate.Count), 1)),
+			aggregate.Currency)
+		aggregates = append(aggregates, &aggregate)
+	}
+	return aggregates, rows.Err()
+}
+
+func (r *transactionRepositories) IndexSearch() error {
+	rows, err := r.db.Query(`SELECT id FROM transactions WHERE searchText IS NULL`)
+	if err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return errors.NewQueryError("error reading rows: " + err.Error())
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return errors.NewDatabaseError("error starting transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	for _, id := range ids {
+		if err := ledger.RefreshSearchText(tx, id); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+	return nil
+}
+
+func (r *transactionRepositories) Search(userID int64, terms []string, today time.Time, limit int) ([]*SearchResult, error) {
+	words := make([]string, len(terms))
+	for i, term := range terms {
+		words[i] = "+" + term + "*"
+	}
+	against := strings.Join(words, " ")
+
+	query := `SELECT ` + transactionColumns + `,
+              MATCH(searchText) AGAINST(? IN BOOLEAN MODE) / (1 + GREATEST(DATEDIFF(?, occurredAt), 0) / 90) AS score
+              FROM transactions
+              WHERE userId = ? AND MATCH(searchText) AGAINST(? IN BOOLEAN MODE)
+              ORDER BY score DESC, occurredAt DESC, id DESC
+              LIMIT ?`
+	rows, err := r.db.Query(query, against, today.Format("2006-01-02"), userID, against, limit)
+	if err != nil {
+		return nil, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	var results []*SearchResult
+	var found []*Transaction
+	for rows.Next() {
+		result := &SearchResult{}
+		transaction, err := scanTransaction(scoredRow{rows, &result.Score})
+		if err != nil {
+			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		result.Transaction = transaction
+		results = append(results, result)
+		found = append(found, transaction)
+	}
+	if err := r.loadDetails(found); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func filterQuery(userID int64, filter *Filter) (string, []interface{}, error) {
+	conditions, args, err := filterConditions(userID, filter)
+	if err != nil {
+		return "", nil, err
+	}
+
 	query := `
-		SELECT id, userId, createdAt, updatedAt, description, category, amount
+		SELECT ` + transactionColumns + `
 		FROM transactions
-		WHERE userId = ?
-	`
+		WHERE ` + conditions
+
+	if filter.Sort != "" || filter.Field != "" {
+		keys, err := SortKeys(filter)
+		if err != nil {
+			return "", nil, err
+		}
+		query += " ORDER BY " + orderClause(keys)
+	}
+	return query, args, nil
+}
+
+func filterConditions(userID int64, filter *Filter) (string, []interface{}, error) {
+	query := "userId = ?"
 	args := []interface{}{userID}
 
 	if filter.Category != 0 {
-		query += " AND category = ?"
-		args = append(args, filter.Category)
+		query += " AND (category = ? OR id IN (SELECT transactionId FROM transaction_splits WHERE category = ?))"
+		args = append(args, filter.Category, filter.Category)
+	}
+
+	if filter.AccountID != 0 {
+		query += " AND accountId = ?"
+		args = append(args, filter.AccountID)
+	}
+
+	if filter.Status != "" {
+		query += " AND status = ?"
+		args = append(args, filter.Status)
 	}
 
 	if filter.Search != "" {
@@ -134,48 +537,311 @@ func (r *transactionRepositories) Filter(userID int64, filter *Filter) ([]*Trans
 	}
 
 	if filter.From != "" {
-		query += " AND createdAt >= ?"
+		query += " AND occurredAt >= ?"
 		args = append(args, filter.From)
 	}
 
-	if filter.To != "" {
-		query += " AND createdAt <= ?"
+	if len(filter.To) == len("2006-01-02") {
+		query += " AND occurredAt < DATE_ADD(?, INTERVAL 1 DAY)"
 		args = append(args, filter.To)
+	} else if filter.To != "" {
+		query += " AND occurredAt <= ?"
+		args = append(args, filter.To)
+	}
+
+	if len(filter.Tags) > 0 {
+		tags, err := ledger.NormalizeTags(filter.Tags)
+		if err != nil {
+			return "", nil, err
+		}
+
+		list, tagArgs := ledger.IDList(tags)
+		query += " AND id IN (SELECT transactionId FROM transaction_tags WHERE tagId IN " + list +
+			" GROUP BY transactionId HAVING COUNT(*) = ?)"
+		args = append(append(args, tagArgs...), len(tags))
+	}
+
+	if filter.Query != "" {
+		expr, err := ParseQuery(filter.Query)
+		if err != nil {
+			return "", nil, err
+		}
+
+		condition, queryArgs := compileQuery(expr)
+		query += " AND " + condition
+		args = append(args, queryArgs...)
+	}
+	return query, args, nil
+}
+
+func orderClause(keys []SortKey) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key.Field + " " + direction(key.Desc)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func keysetCondition(keys []SortKey, after *Cursor) (string, []interface{}) {
+	var alternatives []string
+	var args []interface{}
+	for i := 0; i <= len(keys); i++ {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, keys[j].Field+" = "+placeholder(keys[j].Field))
+			args = append(args, after.Values[j])
+		}
+
+		if i < len(keys) {
+			parts = append(parts, keys[i].Field+" "+beyond(keys[i].Desc)+" "+placeholder(keys[i].Field))
+			args = append(args, after.Values[i])
+		} else {
+			parts = append(parts, "id "+beyond(keys[len(keys)-1].Desc)+" ?")
+			args = append(args, after.ID)
+		}
+		alternatives = append(alternatives, "("+strings.Join(parts, " AND ")+")")
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")", args
+}
+
+func beyond(desc bool) string {
+	if desc {
+		return "<"
+	}
+	return ">"
+}
+
+func placeholder(column string) string {
+	if column == "amount" {
+		return "CAST(? AS DECIMAL(10,2))"
+	}
+	return "?"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func compileQuery(expr Expr) (string, []interface{}) {
+	switch e := expr.(type) {
+	case *Logical:
+		left, args := compileQuery(e.Left)
+		right, rightArgs := compileQuery(e.Right)
+		return "(" + left + " " + e.Op + " " + right + ")", append(args, rightArgs...)
+	case *Not:
+		condition, args := compileQuery(e.Expr)
+		return "NOT (" + condition + ")", args
+	case *Comparison:
+		return compileComparison(e)
+	}
+	return "FALSE", nil
+}
+
+func compileComparison(c *Comparison) (string, []interface{}) {
+	field := queryFields[c.Field]
+	column := field.column
+	if field.kind == kindID {
+		column = "COALESCE(" + column + ", 0)"
+	}
+
+	values := make([]interface{}, len(c.Values))
+	for i, value := range c.Values {
+		values[i] = bindValue(field, value, c.Day)
 	}
 
-	if filter.Field != "" {
-		allowedFields := map[string]bool{
-			"createdAt":   true,
-			"description": true,
-			"amount":      true,
+	switch {
+	case c.Op == "~":
+		return "LOWER(" + column + ") LIKE LOWER(?)", []interface{}{"%" + likeEscaper.Replace(values[0].(string)) + "%"}
+	case field.column == "tag":
+		list := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ") + ")"
+		condition := "id IN (SELECT transactionId FROM transaction_tags WHERE tagId IN " + list + ")"
+		if c.Op == "!=" {
+			condition = "NOT " + condition
+		}
+		return condition, values
+	case c.Field == "category":
+		list := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ") + ")"
+		condition := "(" + column + " IN " + list + " OR id IN (SELECT transactionId FROM transaction_splits WHERE category IN " + list + "))"
+		if c.Op == "!=" {
+			condition = "NOT " + condition
 		}
-		if !allowedFields[filter.Field] {
-			return nil, fmt.Errorf("invalid field for sorting: %s", filter.Field)
+		return condition, append(append([]interface{}{}, values...), values...)
+	case c.Op == "IN":
+		list := "(" + strings.TrimSuffix(strings.Repeat(placeholder(column)+", ", len(values)), ", ") + ")"
+		if c.Day {
+			column = "DATE(" + column + ")"
 		}
+		return column + " IN " + list, values
+	case c.Day:
+		return compileDay(column, c.Op, values[0])
+	}
+	return column + " " + c.Op + " " + placeholder(column), values
+}
+
+func compileDay(column, op string, day interface{}) (string, []interface{}) {
+	const next = "DATE_ADD(?, INTERVAL 1 DAY)"
+	switch op {
+	case "=":
+		return "(" + column + " >= ? AND " + column + " < " + next + ")", []interface{}{day, day}
+	case "!=":
+		return "(" + column + " < ? OR " + column + " >= " + next + ")", []interface{}{day, day}
+	case "<=":
+		return column + " < " + next, []interface{}{day}
+	case ">":
+		return column + " >= " + next, []interface{}{day}
+	}
+	return column + " " + op + " ?", []interface{}{day}
+}
 
-		order := "ASC"
-		if strings.ToUpper(filter.Order) == "DESC" {
-			order = "DESC"
+func bindValue(field queryField, value interface{}, day bool) interface{} {
+	switch v := value.(type) {
+	case money.Money:
+		return v.String()
+	case time.Time:
+		if day {
+			return v.Format("2006-01-02")
+		}
+		if field.column == "occurredAt" {
+			wall, _ := ledger.StoredOccurredAt(v)
+			return wall.Format("2006-01-02 15:04:05")
 		}
+		return v.UTC().Format("2006-01-02 15:04:05")
+	}
+	return value
+}
+
+func (r *transactionRepositories) loadDetails(transactions []*Transaction) error {
+	if err := r.loadSplits(transactions); err != nil {
+		return err
+	}
+	return r.loadTags(transactions)
+}
+
+func (r *transactionRepositories) loadTags(transactions []*Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
 
-		query += fmt.Sprintf(" ORDER BY %s %s", filter.Field, order)
+	byID := make(map[int64]*Transaction, len(transactions))
+	ids := make([]int64, 0, len(transactions))
+	for _, transaction := range transactions {
+		byID[transaction.ID] = transaction
+		ids = append(ids, transaction.ID)
 	}
 
+	list, args := ledger.IDList(ids)
+	rows, err := r.db.Query(`SELECT transactionId, tagId FROM transaction_tags WHERE transactionId IN `+list+
+		` ORDER BY tagId`, args...)
+	if err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var transactionID, tagID int64
+		if err := rows.Scan(&transactionID, &tagID); err != nil {
+			return errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		if transaction, ok := byID[transactionID]; ok {
+			transaction.Tags = append(transaction.Tags, tagID)
+		}
+	}
+	return rows.Err()
+}
+
+func (r *transactionRepositories) loadSplits(transactions []*Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*Transaction, len(transactions))
+	placeholders := make([]string, 0, len(transactions))
+	args := make([]interface{}, 0, len(transactions))
+	for _, transaction := range transactions {
+		byID[transaction.ID] = transaction
+		placeholders = append(placeholders, "?")
+		args = append(args, transaction.ID)
+	}
+
+	query := `SELECT id, transactionId, category, amount, note
+              FROM transaction_splits
+              WHERE transactionId IN (` + strings.Join(placeholders, ", ") + `)
+              ORDER BY id`
 	rows, err := r.db.Query(query, args...)
 	if err != nil {
-		return nil, errors.NewQueryError("error executing query: " + err.Error())
+		return errors.NewQueryError("error executing query: " + err.Error())
 	}
 	defer rows.Close()
 
-	var transactions []*Transaction
 	for rows.Next() {
-		var transaction Transaction
-		err := rows.Scan(&transaction.ID, &transaction.UserID, &transaction.CreatedAt,
-			&transaction.UpdatedAt, &transaction.Description, &transaction.Category, &transaction.Amount)
+		var split Split
+		if err := rows.Scan(&split.ID, &split.TransactionID, &split.Category, &split.Amount, &split.Note); err != nil {
+			return errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		if transaction, ok := byID[split.TransactionID]; ok {
+			split.Amount.Currency = transaction.Currency
+			transaction.Splits = append(transaction.Splits, &split)
+		}
+	}
+	return rows.Err()
+}
+
+func checkTags(tx *sql.Tx, userID int64, tags []int64) error {
+	tags, err := ledger.NormalizeTags(tags)
+	if err != nil || len(tags) == 0 {
+		return err
+	}
+
+	list, args := ledger.IDList(tags)
+	var owned int
+	err = tx.QueryRow(`SELECT COUNT(*) FROM tags WHERE userId = ? AND id IN `+list,
+		append([]interface{}{userID}, args...)...).Scan(&owned)
+	if err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+
+	if owned != len(tags) {
+		return errors.NewValidationError("tags", "tag not found")
+	}
+	return nil
+}
+
+func insertSplits(tx *sql.Tx, transactionID int64, splits []*Split) error {
+	for _, split := range splits {
+		res, err := tx.Exec(`INSERT INTO transaction_splits (transactionId, category, amount, note) VALUES (?, ?, ?, ?)`,
+			transactionID, split.Category, split.Amount, split.Note)
 		if err != nil {
-			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+			return errors.NewQueryError("error inserting split: " + err.Error())
+		}
+		if split.ID, err = res.LastInsertId(); err != nil {
+			return errors.NewQueryError("error getting last insert ID: " + err.Error())
 		}
-		transactions = append(transactions, &transaction)
+		split.TransactionID = transactionID
 	}
-	return transactions, nil
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+type scoredRow struct {
+	rowScanner
+	score *float64
+}
+
+func (r scoredRow) Scan(dest ...interface{}) error {
+	return r.rowScanner.Scan(append(dest, r.score)...)
+}
+
+func scanTransaction(row rowScanner) (*Transaction, error) {
+	var transaction Transaction
+	var offset int
+	err := row.Scan(&transaction.ID, &transaction.UserID, &transaction.AccountID, &transaction.TransferID,
+		&transaction.OccurredAt, &offset, &transaction.CreatedAt, &transaction.UpdatedAt, &transaction.Description, &transaction.Category,
+		&transaction.Amount, &transaction.Currency, &transaction.Status, &transaction.Locked, &transaction.ReconciliationID)
+	if err != nil {
+		return nil, err
+	}
+	transaction.Amount.Currency = transaction.Currency
+	transaction.OccurredAt = ledger.RestoreOccurredAt(transaction.OccurredAt, offset)
+	return &transaction, nil
 }
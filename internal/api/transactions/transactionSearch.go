@@ -0,0 +1,131 @@
+package transactions
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/ledger"
+	"github.com/Renan-Parise/finances/internal/utils"
+)
+
+const (
+	minSearchTermLength = 3
+	maxSearchTerms      = 10
+	snippetLength       = 120
+	snippetLead         = 30
+)
+
+// SearchText is what the full-text index holds for a transaction: its
+// description and split notes, without accents or case.
+func SearchText(description string, notes []string) string {
+	return ledger.SearchText(description, notes)
+}
+
+// SearchTerms reduces a search to the words looked up in the index, folded the
+// same way the index is.
+func SearchTerms(query string) ([]string, error) {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, word := range splitWords(foldText(query)) {
+		if len([]rune(word)) < minSearchTermLength || seen[word] {
+			continue
+		}
+		seen[word] = true
+		terms = append(terms, word)
+	}
+
+	if len(terms) == 0 {
+		return nil, errors.NewValidationError("q", "search needs a word of at least 3 letters or digits")
+	}
+
+	if len(terms) > maxSearchTerms {
+		return nil, errors.NewValidationError("q", "search may have at most 10 words")
+	}
+	return terms, nil
+}
+
+// HighlightResult picks the snippet of a result and marks the matched words.
+func HighlightResult(result *SearchResult, terms []string) {
+	texts := []string{result.Transaction.Description}
+	for _, split := range result.Transaction.Splits {
+		texts = append(texts, split.Note)
+	}
+
+	result.Snippet, result.Highlights = texts[0], []Highlight{}
+	for _, text := range texts {
+		if highlights := matchTerms(text, terms); len(highlights) > len(result.Highlights) {
+			result.Snippet, result.Highlights = text, highlights
+		}
+	}
+	result.Snippet, result.Highlights = shorten(result.Snippet, result.Highlights)
+}
+
+func foldText(value string) string {
+	return strings.ToLower(utils.RemoveAccents(value))
+}
+
+func splitWords(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func matchTerms(text string, terms []string) []Highlight {
+	runes := []rune(text)
+	var highlights []Highlight
+	for start := 0; start < len(runes); {
+		if !unicode.IsLetter(runes[start]) && !unicode.IsDigit(runes[start]) {
+			start++
+			continue
+		}
+
+		end := start
+		for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end])) {
+			end++
+		}
+
+		word := foldText(string(runes[start:end]))
+		for _, term := range terms {
+			if strings.HasPrefix(word, term) {
+				highlights = append(highlights, Highlight{Start: start, End: end})
+				break
+			}
+		}
+		start = end
+	}
+	return highlights
+}
+
+func shorten(text string, highlights []Highlight) (string, []Highlight) {
+	runes := []rune(text)
+	if len(runes) <= snippetLength {
+		return text, highlights
+	}
+
+	start := 0
+	if len(highlights) > 0 && highlights[0].Start > snippetLead {
+		start = highlights[0].Start - snippetLead
+	}
+	end := start + snippetLength
+	if end > len(runes) {
+		end, start = len(runes), len(runes)-snippetLength
+	}
+
+	prefix, suffix := "", ""
+	if start > 0 {
+		prefix = "…"
+	}
+	if end < len(runes) {
+		suffix = "…"
+	}
+
+	shift := len([]rune(prefix)) - start
+	shifted := []Highlight{}
+	for _, highlight := range highlights {
+		if highlight.Start >= start && highlight.End <= end {
+			shifted = append(shifted, Highlight{Start: highlight.Start + shift, End: highlight.End + shift})
+		}
+	}
+	return prefix + string(runes[start:end]) + suffix, shifted
+}
@@ -0,0 +1,520 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/categories"
+	"github.com/Renan-Parise/finances/internal/api/users"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/middlewares"
+	"github.com/Renan-Parise/finances/internal/sessions"
+	"github.com/Renan-Parise/finances/pkg/totp"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryUserRepository struct {
+	users       map[int64]*users.User
+	backupCodes map[int64]map[string]bool
+}
+
+func newMemoryUserRepository() *memoryUserRepository {
+	return &memoryUserRepository{users: map[int64]*users.User{}, backupCodes: map[int64]map[string]bool{}}
+}
+
+func (r *memoryUserRepository) Create(user *users.User) error {
+	for _, other := range r.users {
+		if other.Email == user.Email {
+			return errors.NewValidationError("email", "the given email already exists: "+user.Email)
+		}
+	}
+	user.ID = int64(len(r.users) + 1)
+	stored := *user
+	r.users[user.ID] = &stored
+	return nil
+}
+
+func (r *memoryUserRepository) GetByID(id int64) (*users.User, error) {
+	if user, ok := r.users[id]; ok {
+		copied := *user
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (r *memoryUserRepository) GetByEmail(email string) (*users.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			return r.GetByID(user.ID)
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryUserRepository) ExistsByEmail(email string) (bool, error) {
+	user, err := r.GetByEmail(email)
+	return user != nil, err
+}
+
+func (r *memoryUserRepository) ExistsByUsername(username string) (bool, error) {
+	for _, user := range r.users {
+		if user.Username == username {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryUserRepository) UpdateTwoFactor(user *users.User) error {
+	stored := r.users[user.ID]
+	stored.IsTwoFAEnabled = user.IsTwoFAEnabled
+	stored.TwoFASecret = user.TwoFASecret
+	stored.TwoFASecretExpiration = user.TwoFASecretExpiration
+	stored.TwoFALastUsedStep = user.TwoFALastUsedStep
+	return nil
+}
+
+func (r *memoryUserRepository) ReplaceBackupCodes(userID int64, codeHashes []string) error {
+	r.backupCodes[userID] = map[string]bool{}
+	for _, hash := range codeHashes {
+		r.backupCodes[userID][hash] = true
+	}
+	return nil
+}
+
+func (r *memoryUserRepository) UseBackupCode(userID int64, codeHash string) (bool, error) {
+	if !r.backupCodes[userID][codeHash] {
+		return false, nil
+	}
+	delete(r.backupCodes[userID], codeHash)
+	return true, nil
+}
+
+func (r *memoryUserRepository) UpdatePasswordRecovery(user *users.User) error {
+	stored := r.users[user.ID]
+	stored.PasswordRecoveryCode = user.PasswordRecoveryCode
+	stored.RecoveryCodeExpiration = user.RecoveryCodeExpiration
+	return nil
+}
+
+func (r *memoryUserRepository) ResetPassword(user *users.User, codeHash string) (bool, error) {
+	stored := r.users[user.ID]
+	if stored.PasswordRecoveryCode != codeHash {
+		return false, nil
+	}
+	stored.Password = user.Password
+	stored.PasswordRecoveryCode = ""
+	return true, nil
+}
+
+type memorySessionStore struct {
+	sessions map[string]*sessions.Session
+	refresh  map[string]*sessions.RefreshToken
+	used     map[string]bool
+	denied   map[string]bool
+	failures map[int64]int64
+	revoked  map[int64]int64
+}
+
+func newMemorySessionStore() *memorySessionStore {
+	return &memorySessionStore{
+		sessions: map[string]*sessions.Session{},
+		refresh:  map[string]*sessions.RefreshToken{},
+		used:     map[string]bool{},
+		denied:   map[string]bool{},
+		failures: map[int64]int64{},
+		revoked:  map[int64]int64{},
+	}
+}
+
+func (s *memorySessionStore) SaveSession(ctx context.Context, session *sessions.Session, ttl time.Duration) error {
+	s.sessions[session.ID] = session
+	return nil
+}
+
+func (s *memorySessionStore) GetSession(ctx context.Context, sessionID string) (*sessions.Session, error) {
+	return s.sessions[sessionID], nil
+}
+
+func (s *memorySessionStore) ListSessions(ctx context.Context, userID int64) ([]*sessions.Session, error) {
+	var list []*sessions.Session
+	for _, session := range s.sessions {
+		if session.UserID == userID {
+			list = append(list, session)
+		}
+	}
+	return list, nil
+}
+
+func (s *memorySessionStore) RevokeSession(ctx context.Context, userID int64, sessionID string) error {
+	delete(s.sessions, sessionID)
+	return nil
+}
+
+func (s *memorySessionStore) SaveRefreshToken(ctx context.Context, token string, refresh *sessions.RefreshToken, ttl time.Duration) error {
+	s.refresh[token] = refresh
+	return nil
+}
+
+func (s *memorySessionStore) GetRefreshToken(ctx context.Context, token string) (*sessions.RefreshToken, error) {
+	return s.refresh[token], nil
+}
+
+func (s *memorySessionStore) ConsumeRefreshToken(ctx context.Context, token string, ttl time.Duration) (bool, error) {
+	first := !s.used[token]
+	s.used[token] = true
+	return first, nil
+}
+
+func (s *memorySessionStore) DenyToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	s.denied[tokenID] = true
+	return nil
+}
+
+func (s *memorySessionStore) RevokeUser(ctx context.Context, userID int64, ttl time.Duration) error {
+	s.revoked[userID] = time.Now().UnixMicro()
+	for id, session := range s.sessions {
+		if session.UserID == userID {
+			delete(s.sessions, id)
+		}
+	}
+	return nil
+}
+
+func (s *memorySessionStore) FailedAttempts(ctx context.Context, userID int64) (int64, error) {
+	return s.failures[userID], nil
+}
+
+func (s *memorySessionStore) RecordFailedAttempt(ctx context.Context, userID int64, window time.Duration) (int64, error) {
+	s.failures[userID]++
+	return s.failures[userID], nil
+}
+
+func (s *memorySessionStore) ClearFailedAttempts(ctx context.Context, userID int64) error {
+	delete(s.failures, userID)
+	return nil
+}
+
+func (s *memorySessionStore) IsRevoked(ctx context.Context, userID int64, issuedAt int64, tokenID, sessionID string) (bool, error) {
+	if revokedAt, ok := s.revoked[userID]; ok && issuedAt <= revokedAt {
+		return true, nil
+	}
+	if s.denied[tokenID] {
+		return true, nil
+	}
+	return sessionID != "" && s.sessions[sessionID] == nil, nil
+}
+
+type failingCategoryUseCase struct {
+	categories.CategoryUseCase
+}
+
+func (f *failingCategoryUseCase) CreateDefaultCategories(userID int64) error {
+	return fmt.Errorf("database is down")
+}
+
+type recordingMailer struct {
+	sent []string
+	err  error
+}
+
+func (m *recordingMailer) Send(to, subject, body string) error {
+	m.sent = append(m.sent, body)
+	return m.err
+}
+
+func TestRegisterKeepsUserWhenDefaultCategoriesFail(t *testing.T) {
+	repo := newMemoryUserRepository()
+	uc := users.NewUserUseCase(repo, &failingCategoryUseCase{}, &recordingMailer{}, newMemorySessionStore())
+
+	user, err := uc.Register(" ana ", "ana@example.com", "secret123")
+	require.NoError(t, err)
+	assert.Equal(t, "ana", user.Username)
+
+	_, err = uc.Register("other", "ana@example.com", "secret123")
+	assert.True(t, errors.IsValidationError(err))
+}
+
+func enrolledUser(t *testing.T, uc users.UserUseCase, now *time.Time) (*users.User, string, []string) {
+	t.Setenv("SECRET_KEY", "test-secret")
+
+	user, err := uc.Register("ana", "ana@example.com", "secret123")
+	require.NoError(t, err)
+
+	setup, err := uc.SetupTwoFactor(user.ID)
+	require.NoError(t, err)
+	assert.Contains(t, setup.URI, "secret="+setup.Secret)
+
+	code, err := totp.GenerateCode(setup.Secret, *now)
+	require.NoError(t, err)
+
+	_, err = uc.EnableTwoFactor(user.ID, wrongCode(code))
+	assert.True(t, errors.IsValidationError(err))
+
+	backupCodes, err := uc.EnableTwoFactor(user.ID, code)
+	require.NoError(t, err)
+	assert.Len(t, backupCodes, 10)
+
+	*now = now.Add(30 * time.Second)
+	return user, setup.Secret, backupCodes
+}
+
+func wrongCode(code string) string {
+	if code == "000000" {
+		return "111111"
+	}
+	return "000000"
+}
+
+func TestTwoFactorEnrollLoginAndBackupCodes(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	uc := users.NewUserUseCase(newMemoryUserRepository(), &failingCategoryUseCase{}, &recordingMailer{},
+		newMemorySessionStore(), users.WithClock(func() time.Time { return now }))
+
+	_, secret, backupCodes := enrolledUser(t, uc, &now)
+
+	login, err := uc.Login("ana@example.com", "secret123", nil)
+	require.NoError(t, err)
+	assert.True(t, login.TwoFactorRequired)
+	assert.Empty(t, login.Token)
+
+	code, err := totp.GenerateCode(secret, now)
+	require.NoError(t, err)
+
+	result, err := uc.VerifyTwoFactor(login.ChallengeToken, code, nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.Token)
+	assert.NotEmpty(t, result.RefreshToken)
+
+	_, err = uc.VerifyTwoFactor(login.ChallengeToken, code, nil)
+	assert.True(t, errors.IsValidationError(err))
+
+	result, err = uc.VerifyTwoFactor(login.ChallengeToken, backupCodes[0], nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.Token)
+
+	_, err = uc.VerifyTwoFactor(login.ChallengeToken, backupCodes[0], nil)
+	assert.True(t, errors.IsValidationError(err))
+
+	_, err = uc.VerifyTwoFactor("not a token", backupCodes[1], nil)
+	assert.True(t, errors.IsValidationError(err))
+}
+
+func TestVerifyTwoFactorLocksOutAfterFailedAttempts(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	store := newMemorySessionStore()
+	uc := users.NewUserUseCase(newMemoryUserRepository(), &failingCategoryUseCase{}, &recordingMailer{},
+		store, users.WithClock(func() time.Time { return now }))
+
+	user, secret, backupCodes := enrolledUser(t, uc, &now)
+
+	login, err := uc.Login("ana@example.com", "secret123", nil)
+	require.NoError(t, err)
+
+	code, err := totp.GenerateCode(secret, now)
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		_, err := uc.VerifyTwoFactor(login.ChallengeToken, wrongCode(code), nil)
+		assert.EqualError(t, err, "Error while validating: field 'code': invalid two-factor code.")
+	}
+
+	for _, attempt := range []string{code, backupCodes[0]} {
+		_, err := uc.VerifyTwoFactor(login.ChallengeToken, attempt, nil)
+		assert.EqualError(t, err, "Error while validating: field 'code': too many invalid two-factor codes, try again later.")
+	}
+
+	require.NoError(t, store.ClearFailedAttempts(context.Background(), user.ID))
+	_, err = uc.VerifyTwoFactor(login.ChallengeToken, code, nil)
+	assert.NoError(t, err)
+}
+
+func TestRequestPasswordResetHidesMailerErrors(t *testing.T) {
+	repo := newMemoryUserRepository()
+	mailer := &recordingMailer{err: fmt.Errorf("smtp is down")}
+	uc := users.NewUserUseCase(repo, &failingCategoryUseCase{}, mailer, newMemorySessionStore())
+
+	_, err := uc.Register("ana", "ana@example.com", "secret123")
+	require.NoError(t, err)
+
+	assert.NoError(t, uc.RequestPasswordReset("ana@example.com"))
+	assert.Len(t, mailer.sent, 1)
+
+	assert.NoError(t, uc.RequestPasswordReset("nobody@example.com"))
+	assert.Len(t, mailer.sent, 1)
+}
+
+func mailedRecoveryCode(t *testing.T, mailer *recordingMailer) string {
+	require.NotEmpty(t, mailer.sent)
+	_, rest, found := strings.Cut(mailer.sent[len(mailer.sent)-1], "Use the code ")
+	require.True(t, found)
+	return strings.Fields(rest)[0]
+}
+
+type staleUserRepository struct {
+	*memoryUserRepository
+	stale *users.User
+}
+
+func (r *staleUserRepository) GetByEmail(email string) (*users.User, error) {
+	stale := *r.stale
+	return &stale, nil
+}
+
+func TestResetPasswordRejectsExpiredCodes(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	mailer := &recordingMailer{}
+	uc := users.NewUserUseCase(newMemoryUserRepository(), &failingCategoryUseCase{}, mailer,
+		newMemorySessionStore(), users.WithClock(func() time.Time { return now }))
+
+	_, err := uc.Register("ana", "ana@example.com", "secret123")
+	require.NoError(t, err)
+	require.NoError(t, uc.RequestPasswordReset("ana@example.com"))
+	code := mailedRecoveryCode(t, mailer)
+
+	now = now.Add(31 * time.Minute)
+	err = uc.ResetPassword("ana@example.com", code, "newsecret123")
+	assert.EqualError(t, err, "Error while validating: field 'code': invalid or expired recovery code.")
+}
+
+func TestResetPasswordCodesAreSingleUse(t *testing.T) {
+	repo := newMemoryUserRepository()
+	mailer := &recordingMailer{}
+	uc := users.NewUserUseCase(repo, &failingCategoryUseCase{}, mailer, newMemorySessionStore())
+
+	user, err := uc.Register("ana", "ana@example.com", "secret123")
+	require.NoError(t, err)
+	require.NoError(t, uc.RequestPasswordReset("ana@example.com"))
+	code := mailedRecoveryCode(t, mailer)
+	requested := *repo.users[user.ID]
+
+	require.NoError(t, uc.ResetPassword("ana@example.com", code, "newsecret123"))
+
+	err = uc.ResetPassword("ana@example.com", code, "othersecret123")
+	assert.True(t, errors.IsValidationError(err))
+
+	racing := users.NewUserUseCase(&staleUserRepository{memoryUserRepository: repo, stale: &requested},
+		&failingCategoryUseCase{}, mailer, newMemorySessionStore())
+	err = racing.ResetPassword("ana@example.com", code, "othersecret123")
+	assert.True(t, errors.IsValidationError(err))
+
+	_, err = uc.Login("ana@example.com", "othersecret123", nil)
+	assert.True(t, errors.IsValidationError(err))
+}
+
+func TestResetPasswordRevokesSessions(t *testing.T) {
+	store := newMemorySessionStore()
+	mailer := &recordingMailer{}
+	uc := users.NewUserUseCase(newMemoryUserRepository(), &failingCategoryUseCase{}, mailer, store)
+
+	user, login := loggedInUser(t, uc)
+	router := sessionRouter(store, uc)
+	assert.Equal(t, http.StatusOK, request(router, http.MethodGet, "/protected", login.Token))
+
+	require.NoError(t, uc.RequestPasswordReset("ana@example.com"))
+	require.NoError(t, uc.ResetPassword("ana@example.com", mailedRecoveryCode(t, mailer), "newsecret123"))
+
+	assert.Contains(t, store.revoked, user.ID)
+	assert.Empty(t, store.sessions)
+	assert.Equal(t, http.StatusUnauthorized, request(router, http.MethodGet, "/protected", login.Token))
+
+	_, err := uc.Refresh(login.RefreshToken, nil)
+	assert.True(t, errors.IsValidationError(err))
+}
+
+func loggedInUser(t *testing.T, uc users.UserUseCase) (*users.User, *users.LoginResult) {
+	t.Setenv("SECRET_KEY", "test-secret")
+
+	user, err := uc.Register("ana", "ana@example.com", "secret123")
+	require.NoError(t, err)
+
+	result, err := uc.Login("ana@example.com", "secret123", nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Token)
+	return user, result
+}
+
+func sessionRouter(store sessions.Store, uc users.UserUseCase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(middlewares.JWTAuthMiddlewareWithStore(store))
+	router.GET("/protected", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.POST("/logout", func(c *gin.Context) {
+		err := uc.Logout(c.GetInt64("userID"), c.GetString("tokenID"), c.GetString("sessionID"), c.GetTime("tokenExpiresAt"))
+		if err != nil {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+	return router
+}
+
+func request(router *gin.Engine, method, path, token string) int {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder.Code
+}
+
+func TestRefreshRotatesTokensAndRevokesSessionOnReuse(t *testing.T) {
+	store := newMemorySessionStore()
+	uc := users.NewUserUseCase(newMemoryUserRepository(), &failingCategoryUseCase{}, &recordingMailer{}, store)
+
+	_, login := loggedInUser(t, uc)
+
+	refreshed, err := uc.Refresh(login.RefreshToken, nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, refreshed.Token)
+	assert.NotEqual(t, login.RefreshToken, refreshed.RefreshToken)
+	assert.Len(t, store.sessions, 1)
+
+	_, err = uc.Refresh(login.RefreshToken, nil)
+	assert.EqualError(t, err, "Error while validating: field 'refreshToken': refresh token reuse detected, session revoked.")
+	assert.Empty(t, store.sessions)
+
+	_, err = uc.Refresh(refreshed.RefreshToken, nil)
+	assert.True(t, errors.IsValidationError(err))
+
+	router := sessionRouter(store, uc)
+	assert.Equal(t, http.StatusUnauthorized, request(router, http.MethodGet, "/protected", refreshed.Token))
+}
+
+func TestLogoutDeniesTheAccessToken(t *testing.T) {
+	store := newMemorySessionStore()
+	uc := users.NewUserUseCase(newMemoryUserRepository(), &failingCategoryUseCase{}, &recordingMailer{}, store)
+
+	_, login := loggedInUser(t, uc)
+	router := sessionRouter(store, uc)
+
+	assert.Equal(t, http.StatusOK, request(router, http.MethodGet, "/protected", login.Token))
+	assert.Equal(t, http.StatusNoContent, request(router, http.MethodPost, "/logout", login.Token))
+
+	assert.Len(t, store.denied, 1)
+	assert.Empty(t, store.sessions)
+	assert.Equal(t, http.StatusUnauthorized, request(router, http.MethodGet, "/protected", login.Token))
+}
+
+func TestLogoutEverywhereRevokesEarlierTokens(t *testing.T) {
+	store := newMemorySessionStore()
+	uc := users.NewUserUseCase(newMemoryUserRepository(), &failingCategoryUseCase{}, &recordingMailer{}, store)
+
+	user, login := loggedInUser(t, uc)
+	router := sessionRouter(store, uc)
+
+	require.NoError(t, uc.LogoutEverywhere(user.ID))
+	assert.Contains(t, store.revoked, user.ID)
+	assert.Empty(t, store.sessions)
+	assert.Equal(t, http.StatusUnauthorized, request(router, http.MethodGet, "/protected", login.Token))
+}
@@ -0,0 +1,39 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Renan-Parise/finances/internal/api/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretEncryption(t *testing.T) {
+	t.Setenv("TOTP_ENCRYPTION_KEY", "test-key")
+
+	sealed, err := users.EncryptSecret("JBSWY3DPEHPK3PXP")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sealed, "v1:"))
+	assert.NotContains(t, sealed, "JBSWY3DPEHPK3PXP")
+	assert.LessOrEqual(t, len(sealed), 255)
+
+	secret, err := users.DecryptSecret(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", secret)
+
+	legacy, err := users.DecryptSecret("JBSWY3DPEHPK3PXP")
+	require.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", legacy)
+
+	t.Setenv("TOTP_ENCRYPTION_KEY", "another-key")
+	_, err = users.DecryptSecret(sealed)
+	assert.Error(t, err)
+
+	assert.NoError(t, users.CheckSecretKey())
+
+	t.Setenv("TOTP_ENCRYPTION_KEY", "")
+	_, err = users.EncryptSecret("JBSWY3DPEHPK3PXP")
+	assert.Error(t, err)
+	assert.Error(t, users.CheckSecretKey())
+}
@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/users"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserUseCase struct {
+	mock.Mock
+}
+
+func TestNewUserHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.Default()
+	group := router.Group("/api")
+
+	mockUseCase := new(MockUserUseCase)
+	users.NewUserHandler(group, mockUseCase)
+
+	routes := router.Routes()
+
+	expectedRoutes := []struct {
+		method string
+		path   string
+	}{
+		{"POST", "/api/auth/register"},
+		{"POST", "/api/auth/login"},
+		{"POST", "/api/auth/2fa/verify"},
+		{"POST", "/api/auth/password/forgot"},
+		{"POST", "/api/auth/password/reset"},
+		{"POST", "/api/auth/refresh"},
+		{"POST", "/api/auth/logout"},
+		{"POST", "/api/auth/logout-all"},
+		{"GET", "/api/auth/sessions"},
+		{"DELETE", "/api/auth/sessions/:id"},
+		{"POST", "/api/auth/2fa/setup"},
+		{"POST", "/api/auth/2fa/enable"},
+		{"POST", "/api/auth/2fa/disable"},
+		{"POST", "/api/auth/2fa/backup-codes"},
+	}
+
+	for _, expected := range expectedRoutes {
+		found := false
+		for _, route := range routes {
+			if route.Method == expected.method && route.Path == expected.path {
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, "Route %s %s not registered", expected.method, expected.path)
+	}
+}
+
+func (m *MockUserUseCase) Register(username, email, password string) (*users.User, error) {
+	args := m.Called(username, email, password)
+	return args.Get(0).(*users.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) Login(email, password string, client *users.ClientInfo) (*users.LoginResult, error) {
+	args := m.Called(email, password, client)
+	return args.Get(0).(*users.LoginResult), args.Error(1)
+}
+
+func (m *MockUserUseCase) VerifyTwoFactor(challengeToken, code string, client *users.ClientInfo) (*users.LoginResult, error) {
+	args := m.Called(challengeToken, code, client)
+	return args.Get(0).(*users.LoginResult), args.Error(1)
+}
+
+func (m *MockUserUseCase) SetupTwoFactor(userID int64) (*users.TwoFactorSetup, error) {
+	args := m.Called(userID)
+	return args.Get(0).(*users.TwoFactorSetup), args.Error(1)
+}
+
+func (m *MockUserUseCase) EnableTwoFactor(userID int64, code string) ([]string, error) {
+	args := m.Called(userID, code)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockUserUseCase) DisableTwoFactor(userID int64, code string) error {
+	args := m.Called(userID, code)
+	return args.Error(0)
+}
+
+func (m *MockUserUseCase) RegenerateBackupCodes(userID int64, code string) ([]string, error) {
+	args := m.Called(userID, code)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockUserUseCase) RequestPasswordReset(email string) error {
+	args := m.Called(email)
+	return args.Error(0)
+}
+
+func (m *MockUserUseCase) ResetPassword(email, code, newPassword string) error {
+	args := m.Called(email, code, newPassword)
+	return args.Error(0)
+}
+
+func (m *MockUserUseCase) Refresh(refreshToken string, client *users.ClientInfo) (*users.LoginResult, error) {
+	args := m.Called(refreshToken, client)
+	return args.Get(0).(*users.LoginResult), args.Error(1)
+}
+
+func (m *MockUserUseCase) Logout(userID int64, tokenID, sessionID string, expiresAt time.Time) error {
+	args := m.Called(userID, tokenID, sessionID, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockUserUseCase) LogoutEverywhere(userID int64) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *MockUserUseCase) ListSessions(userID int64, currentSessionID string) ([]*users.ActiveSession, error) {
+	args := m.Called(userID, currentSessionID)
+	return args.Get(0).([]*users.ActiveSession), args.Error(1)
+}
+
+func (m *MockUserUseCase) RevokeSession(userID int64, sessionID string) error {
+	args := m.Called(userID, sessionID)
+	return args.Error(0)
+}
@@ -0,0 +1,587 @@
+package users
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/categories"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/mailer"
+	"github.com/Renan-Parise/finances/internal/sessions"
+	"github.com/Renan-Parise/finances/pkg/jwt"
+	"github.com/Renan-Parise/finances/pkg/totp"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	accessTokenDuration    = 15 * time.Minute
+	refreshTokenDuration   = 30 * 24 * time.Hour
+	challengeTokenDuration = 5 * time.Minute
+	twoFactorSetupDuration = 10 * time.Minute
+	recoveryCodeDuration   = 30 * time.Minute
+	defaultTOTPIssuer      = "Finances"
+
+	maxTwoFactorAttempts     = 5
+	twoFactorLockoutDuration = 15 * time.Minute
+)
+
+type UserUseCase interface {
+	Register(username, email, password string) (*User, error)
+	Login(email, password string, client *ClientInfo) (*LoginResult, error)
+	VerifyTwoFactor(challengeToken, code string, client *ClientInfo) (*LoginResult, error)
+	Refresh(refreshToken string, client *ClientInfo) (*LoginResult, error)
+	Logout(userID int64, tokenID, sessionID string, expiresAt time.Time) error
+	LogoutEverywhere(userID int64) error
+	ListSessions(userID int64, currentSessionID string) ([]*ActiveSession, error)
+	RevokeSession(userID int64, sessionID string) error
+	SetupTwoFactor(userID int64) (*TwoFactorSetup, error)
+	EnableTwoFactor(userID int64, code string) ([]string, error)
+	DisableTwoFactor(userID int64, code string) error
+	RegenerateBackupCodes(userID int64, code string) ([]string, error)
+	RequestPasswordReset(email string) error
+	ResetPassword(email, code, newPassword string) error
+}
+
+type userUseCase struct {
+	userRepo        UserRepository
+	categoryUseCase categories.CategoryUseCase
+	mailer          mailer.Mailer
+	sessions        sessions.Store
+	now             func() time.Time
+}
+
+type Option func(*userUseCase)
+
+// WithClock replaces time.Now, which decides the valid two-factor codes and
+// when setups and recovery codes expire.
+func WithClock(now func() time.Time) Option {
+	return func(uc *userUseCase) {
+		uc.now = now
+	}
+}
+
+func NewUserUseCase(ur UserRepository, cu categories.CategoryUseCase, m mailer.Mailer, ss sessions.Store,
+	options ...Option) UserUseCase {
+	uc := &userUseCase{
+		userRepo:        ur,
+		categoryUseCase: cu,
+		mailer:          m,
+		sessions:        ss,
+		now:             time.Now,
+	}
+	for _, option := range options {
+		option(uc)
+	}
+	return uc
+}
+
+func (uc *userUseCase) Register(username, email, password string) (*User, error) {
+	username = strings.TrimSpace(username)
+	email = strings.TrimSpace(email)
+
+	exists, err := uc.userRepo.ExistsByEmail(email)
+	if err != nil {
+		return nil, errors.NewServiceError("error checking if email exists: " + err.Error())
+	}
+	if exists {
+		return nil, errors.NewValidationError("email", "the given email already exists: "+email)
+	}
+
+	exists, err = uc.userRepo.ExistsByUsername(username)
+	if err != nil {
+		return nil, errors.NewServiceError("error checking if username exists: " + err.Error())
+	}
+	if exists {
+		return nil, errors.NewValidationError("username", "the given username already exists: "+username)
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, errors.NewServiceError("error hashing password: " + err.Error())
+	}
+
+	user := NewUser(username, email, string(hashedPassword))
+	if err := uc.userRepo.Create(user); err != nil {
+		return nil, err
+	}
+
+	if err := uc.categoryUseCase.CreateDefaultCategories(user.ID); err != nil {
+		log.Printf("Failed to create default categories for user %d: %v", user.ID, err)
+	}
+
+	return user, nil
+}
+
+func (uc *userUseCase) Login(email, password string, client *ClientInfo) (*LoginResult, error) {
+	user, err := uc.userRepo.GetByEmail(strings.TrimSpace(email))
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil || !user.Active {
+		return nil, errors.NewValidationError("credentials", "invalid email or password")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return nil, errors.NewValidationError("credentials", "invalid email or password")
+	}
+
+	if user.IsTwoFAEnabled {
+		challenge, err := jwt.GenerateScopedToken(user.ID, jwt.ScopeTwoFactorPending, challengeTokenDuration)
+		if err != nil {
+			return nil, errors.NewServiceError("error generating challenge token: " + err.Error())
+		}
+		return &LoginResult{TwoFactorRequired: true, ChallengeToken: challenge}, nil
+	}
+
+	return uc.startSession(user, client)
+}
+
+func (uc *userUseCase) VerifyTwoFactor(challengeToken, code string, client *ClientInfo) (*LoginResult, error) {
+	userID, err := jwt.ParseScopedToken(challengeToken, jwt.ScopeTwoFactorPending)
+	if err != nil {
+		return nil, errors.NewValidationError("challengeToken", err.Error())
+	}
+
+	user, err := uc.getActiveUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsTwoFAEnabled {
+		return nil, errors.NewValidationError("code", "two-factor authentication is not enabled")
+	}
+
+	ctx := context.Background()
+	attempts, err := uc.sessions.FailedAttempts(ctx, user.ID)
+	if err != nil {
+		return nil, errors.NewServiceError("error reading two-factor attempts: " + err.Error())
+	}
+	if attempts >= maxTwoFactorAttempts {
+		return nil, errors.NewValidationError("code", "too many invalid two-factor codes, try again later")
+	}
+
+	valid, err := uc.verifyTOTP(user, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if !valid {
+		valid, err = uc.userRepo.UseBackupCode(user.ID, HashBackupCode(code))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !valid {
+		if _, err := uc.sessions.RecordFailedAttempt(ctx, user.ID, twoFactorLockoutDuration); err != nil {
+			return nil, errors.NewServiceError("error recording two-factor attempt: " + err.Error())
+		}
+		return nil, errors.NewValidationError("code", "invalid two-factor code")
+	}
+
+	if err := uc.sessions.ClearFailedAttempts(ctx, user.ID); err != nil {
+		return nil, errors.NewServiceError("error clearing two-factor attempts: " + err.Error())
+	}
+
+	return uc.startSession(user, client)
+}
+
+func (uc *userUseCase) SetupTwoFactor(userID int64) (*TwoFactorSetup, error) {
+	user, err := uc.getActiveUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsTwoFAEnabled {
+		return nil, errors.NewValidationError("twoFactor", "two-factor authentication is already enabled")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, errors.NewServiceError("error generating two-factor secret: " + err.Error())
+	}
+
+	now := uc.now()
+	expiration := now.Add(twoFactorSetupDuration)
+	user.TwoFASecret = secret
+	user.TwoFASecretExpiration = &expiration
+	user.TwoFALastUsedStep = 0
+	user.UpdatedAt = now
+
+	if err := uc.userRepo.UpdateTwoFactor(user); err != nil {
+		return nil, err
+	}
+
+	issuer := os.Getenv("TOTP_ISSUER")
+	if issuer == "" {
+		issuer = defaultTOTPIssuer
+	}
+
+	return &TwoFactorSetup{
+		Secret: secret,
+		URI:    totp.URI(issuer, user.Email, secret),
+	}, nil
+}
+
+func (uc *userUseCase) EnableTwoFactor(userID int64, code string) ([]string, error) {
+	user, err := uc.getActiveUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsTwoFAEnabled {
+		return nil, errors.NewValidationError("twoFactor", "two-factor authentication is already enabled")
+	}
+
+	if user.TwoFASecret == "" || user.TwoFASecretExpiration == nil || uc.now().After(*user.TwoFASecretExpiration) {
+		return nil, errors.NewValidationError("twoFactor", "two-factor setup expired or was not started")
+	}
+
+	valid, err := uc.verifyTOTP(user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, errors.NewValidationError("code", "invalid two-factor code")
+	}
+
+	user.IsTwoFAEnabled = true
+	user.TwoFASecretExpiration = nil
+	user.UpdatedAt = uc.now()
+	if err := uc.userRepo.UpdateTwoFactor(user); err != nil {
+		return nil, err
+	}
+
+	return uc.replaceBackupCodes(user.ID)
+}
+
+func (uc *userUseCase) DisableTwoFactor(userID int64, code string) error {
+	user, err := uc.getActiveUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.IsTwoFAEnabled {
+		return errors.NewValidationError("twoFactor", "two-factor authentication is not enabled")
+	}
+
+	valid, err := uc.verifyTOTP(user, code)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.NewValidationError("code", "invalid two-factor code")
+	}
+
+	user.IsTwoFAEnabled = false
+	user.TwoFASecret = ""
+	user.TwoFASecretExpiration = nil
+	user.TwoFALastUsedStep = 0
+	user.UpdatedAt = uc.now()
+	if err := uc.userRepo.UpdateTwoFactor(user); err != nil {
+		return err
+	}
+
+	return uc.userRepo.ReplaceBackupCodes(user.ID, nil)
+}
+
+func (uc *userUseCase) RegenerateBackupCodes(userID int64, code string) ([]string, error) {
+	user, err := uc.getActiveUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsTwoFAEnabled {
+		return nil, errors.NewValidationError("twoFactor", "two-factor authentication is not enabled")
+	}
+
+	valid, err := uc.verifyTOTP(user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, errors.NewValidationError("code", "invalid two-factor code")
+	}
+
+	return uc.replaceBackupCodes(user.ID)
+}
+
+func (uc *userUseCase) RequestPasswordReset(email string) error {
+	user, err := uc.userRepo.GetByEmail(strings.TrimSpace(email))
+	if err != nil {
+		return err
+	}
+	if user == nil || !user.Active {
+		return nil
+	}
+
+	code, err := NewRecoveryCode()
+	if err != nil {
+		return errors.NewServiceError("error generating recovery code: " + err.Error())
+	}
+
+	now := uc.now()
+	expiration := now.Add(recoveryCodeDuration)
+	user.PasswordRecoveryCode = HashRecoveryCode(code)
+	user.RecoveryCodeExpiration = &expiration
+	user.UpdatedAt = now
+
+	if err := uc.userRepo.UpdatePasswordRecovery(user); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Hello %s,\n\nUse the code %s to reset your password. It expires in %d minutes.\n\n"+
+		"If you did not request a password reset, you can ignore this email.",
+		user.Username, code, int(recoveryCodeDuration.Minutes()))
+
+	if err := uc.mailer.Send(user.Email, "Password recovery", body); err != nil {
+		log.Printf("Failed to send recovery email to user %d: %v", user.ID, err)
+	}
+	return nil
+}
+
+func (uc *userUseCase) ResetPassword(email, code, newPassword string) error {
+	invalidCode := errors.NewValidationError("code", "invalid or expired recovery code")
+
+	user, err := uc.userRepo.GetByEmail(strings.TrimSpace(email))
+	if err != nil {
+		return err
+	}
+	if user == nil || !user.Active || user.PasswordRecoveryCode == "" || user.RecoveryCodeExpiration == nil {
+		return invalidCode
+	}
+
+	now := uc.now()
+	codeHash := HashRecoveryCode(code)
+	if subtle.ConstantTimeCompare([]byte(codeHash), []byte(user.PasswordRecoveryCode)) != 1 ||
+		now.After(*user.RecoveryCodeExpiration) {
+		return invalidCode
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.NewServiceError("error hashing password: " + err.Error())
+	}
+
+	user.Password = string(hashedPassword)
+	user.UpdatedAt = now
+
+	reset, err := uc.userRepo.ResetPassword(user, codeHash)
+	if err != nil {
+		return err
+	}
+	if !reset {
+		return invalidCode
+	}
+
+	if err := uc.sessions.RevokeUser(context.Background(), user.ID, accessTokenDuration); err != nil {
+		return errors.NewServiceError("error revoking sessions: " + err.Error())
+	}
+	return nil
+}
+
+func (uc *userUseCase) getActiveUser(userID int64) (*User, error) {
+	user, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !user.Active {
+		return nil, errors.NewValidationError("user", "user not found")
+	}
+	return user, nil
+}
+
+func (uc *userUseCase) verifyTOTP(user *User, code string) (bool, error) {
+	step, ok := totp.Verify(user.TwoFASecret, code, uc.now())
+	if !ok || step <= user.TwoFALastUsedStep {
+		return false, nil
+	}
+
+	user.TwoFALastUsedStep = step
+	user.UpdatedAt = uc.now()
+	if err := uc.userRepo.UpdateTwoFactor(user); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (uc *userUseCase) replaceBackupCodes(userID int64) ([]string, error) {
+	codes, err := NewBackupCodes()
+	if err != nil {
+		return nil, errors.NewServiceError("error generating backup codes: " + err.Error())
+	}
+
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, HashBackupCode(code))
+	}
+
+	if err := uc.userRepo.ReplaceBackupCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (uc *userUseCase) Refresh(refreshToken string, client *ClientInfo) (*LoginResult, error) {
+	ctx := context.Background()
+	invalidToken := errors.NewValidationError("refreshToken", "invalid or expired refresh token")
+
+	refresh, err := uc.sessions.GetRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return nil, errors.NewServiceError("error reading refresh token: " + err.Error())
+	}
+	if refresh == nil {
+		return nil, invalidToken
+	}
+
+	first, err := uc.sessions.ConsumeRefreshToken(ctx, refreshToken, refreshTokenDuration)
+	if err != nil {
+		return nil, errors.NewServiceError("error consuming refresh token: " + err.Error())
+	}
+	if !first {
+		if err := uc.sessions.RevokeSession(ctx, refresh.UserID, refresh.SessionID); err != nil {
+			return nil, errors.NewServiceError("error revoking session: " + err.Error())
+		}
+		return nil, errors.NewValidationError("refreshToken", "refresh token reuse detected, session revoked")
+	}
+
+	session, err := uc.sessions.GetSession(ctx, refresh.SessionID)
+	if err != nil {
+		return nil, errors.NewServiceError("error reading session: " + err.Error())
+	}
+	if session == nil {
+		return nil, invalidToken
+	}
+
+	user, err := uc.getActiveUser(refresh.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	session.LastUsedAt = uc.now()
+	if client != nil {
+		session.UserAgent = client.UserAgent
+		session.IP = client.IP
+	}
+
+	return uc.issueTokens(user, session)
+}
+
+func (uc *userUseCase) Logout(userID int64, tokenID, sessionID string, expiresAt time.Time) error {
+	ctx := context.Background()
+
+	if tokenID != "" {
+		if err := uc.sessions.DenyToken(ctx, tokenID, expiresAt); err != nil {
+			return errors.NewServiceError("error revoking token: " + err.Error())
+		}
+	}
+
+	if sessionID != "" {
+		if err := uc.sessions.RevokeSession(ctx, userID, sessionID); err != nil {
+			return errors.NewServiceError("error revoking session: " + err.Error())
+		}
+	}
+	return nil
+}
+
+func (uc *userUseCase) LogoutEverywhere(userID int64) error {
+	if err := uc.sessions.RevokeUser(context.Background(), userID, accessTokenDuration); err != nil {
+		return errors.NewServiceError("error revoking sessions: " + err.Error())
+	}
+	return nil
+}
+
+func (uc *userUseCase) ListSessions(userID int64, currentSessionID string) ([]*ActiveSession, error) {
+	list, err := uc.sessions.ListSessions(context.Background(), userID)
+	if err != nil {
+		return nil, errors.NewServiceError("error listing sessions: " + err.Error())
+	}
+
+	active := make([]*ActiveSession, 0, len(list))
+	for _, session := range list {
+		active = append(active, &ActiveSession{
+			Session: session,
+			Current: session.ID == currentSessionID,
+		})
+	}
+
+	sort.Slice(active, func(i, j int) bool {
+		return active[i].LastUsedAt.After(active[j].LastUsedAt)
+	})
+	return active, nil
+}
+
+func (uc *userUseCase) RevokeSession(userID int64, sessionID string) error {
+	ctx := context.Background()
+
+	session, err := uc.sessions.GetSession(ctx, sessionID)
+	if err != nil {
+		return errors.NewServiceError("error reading session: " + err.Error())
+	}
+	if session == nil || session.UserID != userID {
+		return errors.NewValidationError("session", "session not found")
+	}
+
+	if err := uc.sessions.RevokeSession(ctx, userID, sessionID); err != nil {
+		return errors.NewServiceError("error revoking session: " + err.Error())
+	}
+	return nil
+}
+
+func (uc *userUseCase) startSession(user *User, client *ClientInfo) (*LoginResult, error) {
+	sessionID, err := sessions.NewID()
+	if err != nil {
+		return nil, errors.NewServiceError("error generating session: " + err.Error())
+	}
+
+	now := uc.now()
+	session := &sessions.Session{
+		ID:         sessionID,
+		UserID:     user.ID,
+		CreatedAt:  now,
+		LastUsedAt: now,
+	}
+	if client != nil {
+		session.UserAgent = client.UserAgent
+		session.IP = client.IP
+	}
+
+	return uc.issueTokens(user, session)
+}
+
+func (uc *userUseCase) issueTokens(user *User, session *sessions.Session) (*LoginResult, error) {
+	ctx := context.Background()
+
+	if err := uc.sessions.SaveSession(ctx, session, refreshTokenDuration); err != nil {
+		return nil, errors.NewServiceError("error saving session: " + err.Error())
+	}
+
+	refreshToken, err := sessions.NewRefreshToken()
+	if err != nil {
+		return nil, errors.NewServiceError("error generating refresh token: " + err.Error())
+	}
+
+	refresh := &sessions.RefreshToken{SessionID: session.ID, UserID: user.ID}
+	if err := uc.sessions.SaveRefreshToken(ctx, refreshToken, refresh, refreshTokenDuration); err != nil {
+		return nil, errors.NewServiceError("error saving refresh token: " + err.Error())
+	}
+
+	token, err := jwt.GenerateToken(user.ID, session.ID, accessTokenDuration)
+	if err != nil {
+		return nil, errors.NewServiceError("error generating token: " + err.Error())
+	}
+
+	return &LoginResult{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(accessTokenDuration.Seconds()),
+	}, nil
+}
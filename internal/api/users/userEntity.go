@@ -0,0 +1,47 @@
+package users
+
+import (
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/sessions"
+)
+
+type User struct {
+	ID                     int64      `json:"id"`
+	Username               string     `json:"username"`
+	Email                  string     `json:"email"`
+	Password               string     `json:"-"`
+	Active                 bool       `json:"active"`
+	CreatedAt              time.Time  `json:"createdAt"`
+	UpdatedAt              time.Time  `json:"updatedAt"`
+	DeactivatedAt          *time.Time `json:"deactivatedAt,omitempty"`
+	IsTwoFAEnabled         bool       `json:"isTwoFAEnabled"`
+	TwoFASecret            string     `json:"-"`
+	TwoFASecretExpiration  *time.Time `json:"-"`
+	TwoFALastUsedStep      int64      `json:"-"`
+	PasswordRecoveryCode   string     `json:"-"`
+	RecoveryCodeExpiration *time.Time `json:"-"`
+}
+
+type LoginResult struct {
+	Token             string `json:"token,omitempty"`
+	RefreshToken      string `json:"refreshToken,omitempty"`
+	ExpiresIn         int64  `json:"expiresIn,omitempty"`
+	TwoFactorRequired bool   `json:"twoFactorRequired"`
+	ChallengeToken    string `json:"challengeToken,omitempty"`
+}
+
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+type ActiveSession struct {
+	*sessions.Session
+	Current bool `json:"current"`
+}
+
+type TwoFactorSetup struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
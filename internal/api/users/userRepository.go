@@ -0,0 +1,238 @@
+package users
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const duplicateEntry = 1062
+
+type UserRepository interface {
+	Create(user *User) error
+	GetByID(id int64) (*User, error)
+	GetByEmail(email string) (*User, error)
+	ExistsByEmail(email string) (bool, error)
+	ExistsByUsername(username string) (bool, error)
+	UpdateTwoFactor(user *User) error
+	ReplaceBackupCodes(userID int64, codeHashes []string) error
+	UseBackupCode(userID int64, codeHash string) (bool, error)
+	UpdatePasswordRecovery(user *User) error
+	ResetPassword(user *User, codeHash string) (bool, error)
+}
+
+type userRepository struct {
+	db *sql.DB
+}
+
+func NewUserRepository(db *sql.DB) UserRepository {
+	return &userRepository{db: db}
+}
+
+const userColumns = `id, username, email, password, active, createdAt, updatedAt, deactivatedAt,
+	isTwoFAEnabled, twoFACode, twoFACodeExpiration, twoFALastUsedStep,
+	passwordRecoveryCode, recoveryCodeExpiration`
+
+func (r *userRepository) Create(user *User) error {
+	query := `INSERT INTO users (username, email, password, active, createdAt, updatedAt) VALUES (?, ?, ?, ?, ?, ?)`
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return errors.NewQueryError("error preparing query: " + err.Error())
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(user.Username, user.Email, user.Password, user.Active, user.CreatedAt, user.UpdatedAt)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == duplicateEntry {
+			if strings.Contains(mysqlErr.Message, "uq_user_email") {
+				return errors.NewValidationError("email", "the given email already exists: "+user.Email)
+			}
+			return errors.NewValidationError("username", "the given username already exists: "+user.Username)
+		}
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return errors.NewQueryError("error getting last insert ID: " + err.Error())
+	}
+
+	user.ID = id
+	return nil
+}
+
+func (r *userRepository) GetByID(id int64) (*User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = ?`
+	return r.scanUser(r.db.QueryRow(query, id))
+}
+
+func (r *userRepository) GetByEmail(email string) (*User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE LOWER(email) = LOWER(?)`
+	return r.scanUser(r.db.QueryRow(query, email))
+}
+
+func (r *userRepository) ExistsByEmail(email string) (bool, error) {
+	query := `SELECT COUNT(*) FROM users WHERE LOWER(email) = LOWER(?)`
+	var count int
+	err := r.db.QueryRow(query, email).Scan(&count)
+	if err != nil {
+		return false, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	return count > 0, nil
+}
+
+func (r *userRepository) ExistsByUsername(username string) (bool, error) {
+	query := `SELECT COUNT(*) FROM users WHERE LOWER(username) = LOWER(?)`
+	var count int
+	err := r.db.QueryRow(query, username).Scan(&count)
+	if err != nil {
+		return false, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	return count > 0, nil
+}
+
+func (r *userRepository) UpdateTwoFactor(user *User) error {
+	query := `UPDATE users SET isTwoFAEnabled = ?, twoFACode = ?, twoFACodeExpiration = ?, twoFALastUsedStep = ?, updatedAt = ?
+              WHERE id = ?`
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return errors.NewQueryError("error preparing query: " + err.Error())
+	}
+	defer stmt.Close()
+
+	secret, err := EncryptSecret(user.TwoFASecret)
+	if err != nil {
+		return errors.NewServiceError("error encrypting two-factor secret: " + err.Error())
+	}
+
+	_, err = stmt.Exec(user.IsTwoFAEnabled, secret, user.TwoFASecretExpiration,
+		user.TwoFALastUsedStep, user.UpdatedAt, user.ID)
+	if err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+	return nil
+}
+
+func (r *userRepository) ReplaceBackupCodes(userID int64, codeHashes []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return errors.NewDatabaseError("error starting transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM user_backup_codes WHERE userId = ?`, userID); err != nil {
+		return errors.NewQueryError("error deleting backup codes: " + err.Error())
+	}
+
+	stmt, err := tx.Prepare(`INSERT INTO user_backup_codes (userId, codeHash, createdAt) VALUES (?, ?, ?)`)
+	if err != nil {
+		return errors.NewQueryError("error preparing query: " + err.Error())
+	}
+	defer stmt.Close()
+
+	now := time.Now()
+	for _, codeHash := range codeHashes {
+		if _, err := stmt.Exec(userID, codeHash, now); err != nil {
+			return errors.NewQueryError("error inserting backup code: " + err.Error())
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewDatabaseError("error committing transaction: " + err.Error())
+	}
+	return nil
+}
+
+func (r *userRepository) UseBackupCode(userID int64, codeHash string) (bool, error) {
+	query := `UPDATE user_backup_codes SET usedAt = ? WHERE userId = ? AND codeHash = ? AND usedAt IS NULL`
+	res, err := r.db.Exec(query, time.Now(), userID, codeHash)
+	if err != nil {
+		return false, errors.NewQueryError("error executing query: " + err.Error())
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.NewQueryError("error getting affected rows: " + err.Error())
+	}
+	return affected == 1, nil
+}
+
+func (r *userRepository) UpdatePasswordRecovery(user *User) error {
+	query := `UPDATE users SET passwordRecoveryCode = ?, recoveryCodeExpiration = ?, updatedAt = ? WHERE id = ?`
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return errors.NewQueryError("error preparing query: " + err.Error())
+	}
+	defer stmt.Close()
+
+	var recoveryCode interface{}
+	if user.PasswordRecoveryCode != "" {
+		recoveryCode = user.PasswordRecoveryCode
+	}
+
+	_, err = stmt.Exec(recoveryCode, user.RecoveryCodeExpiration, user.UpdatedAt, user.ID)
+	if err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+	return nil
+}
+
+func (r *userRepository) ResetPassword(user *User, codeHash string) (bool, error) {
+	query := `UPDATE users SET password = ?, passwordRecoveryCode = NULL, recoveryCodeExpiration = NULL, updatedAt = ?
+              WHERE id = ? AND passwordRecoveryCode = ? AND recoveryCodeExpiration > ?`
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return false, errors.NewQueryError("error preparing query: " + err.Error())
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(user.Password, user.UpdatedAt, user.ID, codeHash, user.UpdatedAt)
+	if err != nil {
+		return false, errors.NewQueryError("error executing query: " + err.Error())
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.NewQueryError("error getting affected rows: " + err.Error())
+	}
+	return affected == 1, nil
+}
+
+func (r *userRepository) scanUser(row *sql.Row) (*User, error) {
+	var user User
+	var deactivatedAt, twoFAExpiration, recoveryExpiration sql.NullTime
+	var twoFAEnabled sql.NullBool
+	var twoFASecret, recoveryCode sql.NullString
+	var twoFALastUsedStep sql.NullInt64
+	err := row.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.Active,
+		&user.CreatedAt, &user.UpdatedAt, &deactivatedAt,
+		&twoFAEnabled, &twoFASecret, &twoFAExpiration, &twoFALastUsedStep,
+		&recoveryCode, &recoveryExpiration)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.NewQueryError("error scanning row: " + err.Error())
+	}
+	if deactivatedAt.Valid {
+		user.DeactivatedAt = &deactivatedAt.Time
+	}
+	if twoFAExpiration.Valid {
+		user.TwoFASecretExpiration = &twoFAExpiration.Time
+	}
+	user.IsTwoFAEnabled = twoFAEnabled.Bool
+	if user.TwoFASecret, err = DecryptSecret(twoFASecret.String); err != nil {
+		return nil, errors.NewServiceError("error decrypting two-factor secret: " + err.Error())
+	}
+	user.TwoFALastUsedStep = twoFALastUsedStep.Int64
+	if recoveryExpiration.Valid {
+		user.RecoveryCodeExpiration = &recoveryExpiration.Time
+	}
+	user.PasswordRecoveryCode = recoveryCode.String
+	return &user, nil
+}
@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/api/views"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePeriod(t *testing.T) {
+	now := time.Date(2026, 8, 15, 21, 30, 0, 0, time.UTC)
+
+	cases := map[string][2]string{
+		"today":             {"2026-08-15", "2026-08-15"},
+		"Yesterday":         {"2026-08-14", "2026-08-14"},
+		"last 30 days":      {"2026-07-17", "2026-08-15"},
+		"last 2 weeks":      {"2026-08-02", "2026-08-15"},
+		"last 1 month":      {"2026-07-16", "2026-08-15"},
+		"this week":         {"2026-08-10", "2026-08-16"},
+		"this month":        {"2026-08-01", "2026-08-31"},
+		"this quarter":      {"2026-07-01", "2026-09-30"},
+		"this year":         {"2026-01-01", "2026-12-31"},
+		"previous month":    {"2026-07-01", "2026-07-31"},
+		"last month":        {"2026-07-01", "2026-07-31"},
+		"previous  quarter": {"2026-04-01", "2026-06-30"},
+		"previous year":     {"2025-01-01", "2025-12-31"},
+	}
+
+	for period, expected := range cases {
+		from, to, err := views.ResolvePeriod(period, now)
+		require.NoError(t, err, period)
+		assert.Equal(t, expected[0], from.Format("2006-01-02"), period)
+		assert.Equal(t, expected[1], to.Format("2006-01-02"), period)
+	}
+
+	for _, period := range []string{"", "last days", "last 0 days", "next month", "this fortnight", "last 3 decades"} {
+		_, _, err := views.ResolvePeriod(period, now)
+		assert.True(t, errors.IsValidationError(err), "expected validation error for %q, got %v", period, err)
+	}
+}
+
+func TestResolvePeriodAtMonthEnd(t *testing.T) {
+	cases := []struct {
+		now    time.Time
+		period string
+		from   string
+		to     string
+	}{
+		{time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), "last 1 month", "2026-03-01", "2026-03-31"},
+		{time.Date(2026, 5, 31, 0, 0, 0, 0, time.UTC), "last 3 months", "2026-03-01", "2026-05-31"},
+		{time.Date(2026, 5, 31, 0, 0, 0, 0, time.UTC), "last 1 quarter", "2026-03-01", "2026-05-31"},
+		{time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC), "last 2 months", "2026-11-01", "2026-12-31"},
+		{time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), "last 1 year", "2027-03-01", "2028-02-29"},
+		{time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), "previous month", "2026-02-01", "2026-02-28"},
+	}
+
+	for _, c := range cases {
+		from, to, err := views.ResolvePeriod(c.period, c.now)
+		require.NoError(t, err, c.period)
+		assert.Equal(t, c.from, from.Format("2006-01-02"), "%s on %s", c.period, c.now.Format("2006-01-02"))
+		assert.Equal(t, c.to, to.Format("2006-01-02"), "%s on %s", c.period, c.now.Format("2006-01-02"))
+	}
+}
+
+func TestResolveFilterReplacesDates(t *testing.T) {
+	filter := transactions.Filter{Category: 4, From: "2020-01-01", To: "2020-12-31"}
+	now := time.Date(2026, 8, 15, 0, 0, 0, 0, time.UTC)
+
+	resolved, err := views.ResolveFilter(views.NewView(1, " Restaurants ", filter, "previous month"), now)
+	require.NoError(t, err)
+	assert.Equal(t, &transactions.Filter{Category: 4, From: "2026-07-01", To: "2026-07-31"}, resolved)
+
+	resolved, err = views.ResolveFilter(views.NewView(1, "All time", filter, ""), now)
+	require.NoError(t, err)
+	assert.Equal(t, &filter, resolved)
+}
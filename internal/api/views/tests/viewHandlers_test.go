@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/api/views"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockViewUseCase struct {
+	mock.Mock
+}
+
+func TestNewViewHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.Default()
+	group := router.Group("/api")
+
+	mockUseCase := new(MockViewUseCase)
+	views.NewViewHandler(group, mockUseCase)
+
+	routes := router.Routes()
+
+	expectedRoutes := []struct {
+		method string
+		path   string
+	}{
+		{"POST", "/api/views/"},
+		{"GET", "/api/views/"},
+		{"GET", "/api/views/:id"},
+		{"PUT", "/api/views/:id"},
+		{"DELETE", "/api/views/:id"},
+		{"GET", "/api/views/:id/run"},
+	}
+
+	for _, expected := range expectedRoutes {
+		found := false
+		for _, route := range routes {
+			if route.Method == expected.method && route.Path == expected.path {
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, "Route %s %s not registered", expected.method, expected.path)
+	}
+}
+
+func (m *MockViewUseCase) CreateView(view *views.View) error {
+	args := m.Called(view)
+	return args.Error(0)
+}
+
+func (m *MockViewUseCase) GetViews(userID int64) ([]*views.View, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]*views.View), args.Error(1)
+}
+
+func (m *MockViewUseCase) GetView(userID int64, id int64) (*views.View, error) {
+	args := m.Called(userID, id)
+	return args.Get(0).(*views.View), args.Error(1)
+}
+
+func (m *MockViewUseCase) UpdateView(view *views.View) error {
+	args := m.Called(view)
+	return args.Error(0)
+}
+
+func (m *MockViewUseCase) DeleteView(userID int64, id int64) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
+
+func (m *MockViewUseCase) RunView(userID int64, id int64, page *transactions.Page) (*views.Result, error) {
+	args := m.Called(userID, id, page)
+	return args.Get(0).(*views.Result), args.Error(1)
+}
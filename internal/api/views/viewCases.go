@@ -0,0 +1,151 @@
+package views
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/errors"
+)
+
+type ViewUseCase interface {
+	CreateView(view *View) error
+	GetViews(userID int64) ([]*View, error)
+	GetView(userID int64, id int64) (*View, error)
+	UpdateView(view *View) error
+	DeleteView(userID int64, id int64) error
+	RunView(userID int64, id int64, page *transactions.Page) (*Result, error)
+}
+
+type viewUseCase struct {
+	viewRepo           ViewRepository
+	transactionRepo    transactions.TransactionRepositories
+	transactionUseCase transactions.TransactionUseCase
+}
+
+func NewViewUseCase(vr ViewRepository, tr transactions.TransactionRepositories,
+	tu transactions.TransactionUseCase) ViewUseCase {
+	return &viewUseCase{
+		viewRepo:           vr,
+		transactionRepo:    tr,
+		transactionUseCase: tu,
+	}
+}
+
+func (uc *viewUseCase) CreateView(view *View) error {
+	if err := uc.validateView(view); err != nil {
+		return err
+	}
+	return uc.viewRepo.Create(view)
+}
+
+func (uc *viewUseCase) GetViews(userID int64) ([]*View, error) {
+	return uc.viewRepo.GetAll(userID)
+}
+
+func (uc *viewUseCase) GetView(userID int64, id int64) (*View, error) {
+	return uc.getView(userID, id)
+}
+
+func (uc *viewUseCase) UpdateView(view *View) error {
+	existing, err := uc.getView(view.UserID, view.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.validateView(view); err != nil {
+		return err
+	}
+
+	view.CreatedAt = existing.CreatedAt
+	view.UpdatedAt = time.Now()
+	return uc.viewRepo.Update(view)
+}
+
+func (uc *viewUseCase) DeleteView(userID int64, id int64) error {
+	if _, err := uc.getView(userID, id); err != nil {
+		return err
+	}
+	return uc.viewRepo.Delete(userID, id)
+}
+
+func (uc *viewUseCase) RunView(userID int64, id int64, page *transactions.Page) (*Result, error) {
+	view, err := uc.getView(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := ResolveFilter(view, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	listing, err := uc.transactionUseCase.ListTransactions(userID, filter, page)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregates, err := uc.transactionRepo.Aggregate(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		View:         view,
+		From:         filter.From,
+		To:           filter.To,
+		Aggregates:   aggregates,
+		Transactions: listing.Transactions,
+		Next:         listing.Next,
+	}, nil
+}
+
+func (uc *viewUseCase) validateView(view *View) error {
+	if view.Name == "" {
+		return errors.NewValidationError("name", "name is required")
+	}
+
+	if len(view.Name) > 100 {
+		return errors.NewValidationError("name", "name must be at most 100 characters")
+	}
+
+	if view.Period != "" {
+		if _, _, err := ResolvePeriod(view.Period, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	if view.Filter.Query != "" {
+		if _, err := transactions.ParseQuery(view.Filter.Query); err != nil {
+			return err
+		}
+	}
+
+	if _, err := transactions.SortKeys(&view.Filter); err != nil {
+		return err
+	}
+
+	views, err := uc.viewRepo.GetAll(view.UserID)
+	if err != nil {
+		return err
+	}
+
+	for _, other := range views {
+		if other.ID != view.ID && strings.EqualFold(other.Name, view.Name) {
+			return errors.NewValidationError("name", "a view named '"+view.Name+"' already exists")
+		}
+	}
+	return nil
+}
+
+func (uc *viewUseCase) getView(userID int64, id int64) (*View, error) {
+	view, err := uc.viewRepo.GetByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if view == nil {
+		return nil, errors.NewValidationError("id", "view not found")
+	}
+	return view, nil
+}
@@ -0,0 +1,28 @@
+package views
+
+import (
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+)
+
+// View is a named, saved filter.
+type View struct {
+	ID        int64               `json:"id"`
+	UserID    int64               `json:"userId"`
+	Name      string              `json:"name"`
+	Filter    transactions.Filter `json:"filter"`
+	Period    string              `json:"period,omitempty"`
+	CreatedAt time.Time           `json:"createdAt"`
+	UpdatedAt time.Time           `json:"updatedAt"`
+}
+
+// Result is one page of a view's transactions.
+type Result struct {
+	View         *View                       `json:"view"`
+	From         string                      `json:"from,omitempty"`
+	To           string                      `json:"to,omitempty"`
+	Aggregates   []*transactions.Aggregate   `json:"aggregates"`
+	Transactions []*transactions.Transaction `json:"transactions"`
+	Next         string                      `json:"-"`
+}
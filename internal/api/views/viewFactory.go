@@ -0,0 +1,109 @@
+package views
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/utils"
+)
+
+const maxPeriodLength = 1000
+
+func NewView(userID int64, name string, filter transactions.Filter, period string) *View {
+	now := time.Now()
+	return &View{
+		UserID:    userID,
+		Name:      strings.TrimSpace(name),
+		Filter:    filter,
+		Period:    strings.TrimSpace(period),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// ResolvePeriod turns a relative period into the first and last day it covers
+// on the day of now.
+func ResolvePeriod(period string, now time.Time) (time.Time, time.Time, error) {
+	words := strings.Fields(strings.ToLower(period))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	invalid := errors.NewValidationError("period",
+		`unknown period "`+period+`", expected one like "last 30 days", "this month" or "previous quarter"`)
+
+	switch {
+	case len(words) == 1 && words[0] == "today":
+		return today, today, nil
+	case len(words) == 1 && words[0] == "yesterday":
+		yesterday := today.AddDate(0, 0, -1)
+		return yesterday, yesterday, nil
+	case len(words) == 2 && words[0] == "this":
+		start, ok := startOf(today, words[1])
+		if !ok {
+			return time.Time{}, time.Time{}, invalid
+		}
+		return start, shift(start, words[1], 1).AddDate(0, 0, -1), nil
+	case len(words) == 2 && (words[0] == "previous" || words[0] == "last"):
+		start, ok := startOf(today, words[1])
+		if !ok {
+			return time.Time{}, time.Time{}, invalid
+		}
+		return shift(start, words[1], -1), start.AddDate(0, 0, -1), nil
+	case len(words) == 3 && words[0] == "last":
+		count, err := strconv.Atoi(words[1])
+		unit := strings.TrimSuffix(words[2], "s")
+		if _, ok := startOf(today, unit); !ok || err != nil || count < 1 || count > maxPeriodLength {
+			return time.Time{}, time.Time{}, invalid
+		}
+		return shift(today, unit, -count).AddDate(0, 0, 1), today, nil
+	}
+	return time.Time{}, time.Time{}, invalid
+}
+
+// ResolveFilter returns the filter a view runs with on the day of now.
+func ResolveFilter(view *View, now time.Time) (*transactions.Filter, error) {
+	filter := view.Filter
+	if view.Period == "" {
+		return &filter, nil
+	}
+
+	from, to, err := ResolvePeriod(view.Period, now)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.From = from.Format("2006-01-02")
+	filter.To = to.Format("2006-01-02")
+	return &filter, nil
+}
+
+func startOf(day time.Time, unit string) (time.Time, bool) {
+	switch unit {
+	case "day":
+		return day, true
+	case "week":
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7), true
+	case "month":
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC), true
+	case "quarter":
+		return time.Date(day.Year(), (day.Month()-1)/3*3+1, 1, 0, 0, 0, 0, time.UTC), true
+	case "year":
+		return time.Date(day.Year(), 1, 1, 0, 0, 0, 0, time.UTC), true
+	}
+	return time.Time{}, false
+}
+
+func shift(day time.Time, unit string, count int) time.Time {
+	switch unit {
+	case "week":
+		return day.AddDate(0, 0, 7*count)
+	case "month":
+		return utils.AddMonths(day, count)
+	case "quarter":
+		return utils.AddMonths(day, 3*count)
+	case "year":
+		return utils.AddMonths(day, 12*count)
+	}
+	return day.AddDate(0, 0, count)
+}
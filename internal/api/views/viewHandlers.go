@@ -0,0 +1,210 @@
+package views
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ViewHandler struct {
+	viewUseCase ViewUseCase
+}
+
+func NewViewHandler(router *gin.RouterGroup, vu ViewUseCase) {
+	handler := &ViewHandler{
+		viewUseCase: vu,
+	}
+
+	views := router.Group("/views")
+	views.Use(middlewares.JWTAuthMiddleware())
+	{
+		views.POST("/", handler.CreateView)
+		views.GET("/", handler.GetViews)
+		views.GET("/:id", handler.GetView)
+		views.PUT("/:id", handler.UpdateView)
+		views.DELETE("/:id", handler.DeleteView)
+		views.GET("/:id/run", handler.RunView)
+	}
+}
+
+type viewInput struct {
+	Name   string              `json:"name" binding:"required"`
+	Filter transactions.Filter `json:"filter"`
+	Period string              `json:"period"`
+}
+
+func (h *ViewHandler) CreateView(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	var input viewInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	view := NewView(userID.(int64), input.Name, input.Filter, input.Period)
+	if err := h.viewUseCase.CreateView(view); err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, view)
+}
+
+func (h *ViewHandler) GetViews(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	views, err := h.viewUseCase.GetViews(userID.(int64))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, views)
+}
+
+func (h *ViewHandler) GetView(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid view ID"})
+		return
+	}
+
+	view, err := h.viewUseCase.GetView(userID.(int64), id)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+func (h *ViewHandler) UpdateView(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid view ID"})
+		return
+	}
+
+	var input viewInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	view := NewView(userID.(int64), input.Name, input.Filter, input.Period)
+	view.ID = id
+	if err := h.viewUseCase.UpdateView(view); err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "View updated successfully"})
+}
+
+func (h *ViewHandler) DeleteView(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid view ID"})
+		return
+	}
+
+	if err := h.viewUseCase.DeleteView(userID.(int64), id); err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "View deleted successfully"})
+}
+
+// RunView pages through a view like the transaction listing, with the limit
+// and cursor query parameters and the next page in a Link header.
+func (h *ViewHandler) RunView(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid view ID"})
+		return
+	}
+
+	page := &transactions.Page{Cursor: c.Query("cursor")}
+	if value := c.Query("limit"); value != "" {
+		if page.Limit, err = strconv.Atoi(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+	}
+
+	result, err := h.viewUseCase.RunView(userID.(int64), id, page)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if result.Next != "" {
+		next := *c.Request.URL
+		query := next.Query()
+		query.Set("cursor", result.Next)
+		query.Set("limit", strconv.Itoa(page.Limit))
+		next.RawQuery = query.Encode()
+		c.Header("Link", "<"+next.RequestURI()+">; rel=\"next\"")
+	}
+
+	c.JSON(http.StatusOK, result)
+}
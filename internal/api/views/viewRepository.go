@@ -0,0 +1,115 @@
+package views
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+)
+
+type ViewRepository interface {
+	Create(view *View) error
+	GetAll(userID int64) ([]*View, error)
+	GetByID(userID int64, id int64) (*View, error)
+	Update(view *View) error
+	Delete(userID int64, id int64) error
+}
+
+const viewColumns = `id, userId, name, filter, COALESCE(period, ''), createdAt, updatedAt`
+
+type viewRepository struct {
+	db *sql.DB
+}
+
+func NewViewRepository(db *sql.DB) ViewRepository {
+	return &viewRepository{db: db}
+}
+
+func (r *viewRepository) Create(view *View) error {
+	filter, err := json.Marshal(view.Filter)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO views (userId, name, filter, period, createdAt, updatedAt)
+              VALUES (?, ?, ?, NULLIF(?, ''), ?, ?)`
+	res, err := r.db.Exec(query, view.UserID, view.Name, filter, view.Period, view.CreatedAt, view.UpdatedAt)
+	if err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+
+	if view.ID, err = res.LastInsertId(); err != nil {
+		return errors.NewQueryError("error getting last insert ID: " + err.Error())
+	}
+	return nil
+}
+
+func (r *viewRepository) GetAll(userID int64) ([]*View, error) {
+	query := `SELECT ` + viewColumns + ` FROM views WHERE userId = ? ORDER BY name`
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, errors.NewQueryError("error executing query: " + err.Error())
+	}
+	defer rows.Close()
+
+	var views []*View
+	for rows.Next() {
+		view, err := scanView(rows)
+		if err != nil {
+			return nil, errors.NewQueryError("error scanning row: " + err.Error())
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+func (r *viewRepository) GetByID(userID int64, id int64) (*View, error) {
+	query := `SELECT ` + viewColumns + ` FROM views WHERE id = ? AND userId = ?`
+	view, err := scanView(r.db.QueryRow(query, id, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.NewQueryError("error scanning row: " + err.Error())
+	}
+	return view, nil
+}
+
+func (r *viewRepository) Update(view *View) error {
+	filter, err := json.Marshal(view.Filter)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE views SET name = ?, filter = ?, period = NULLIF(?, ''), updatedAt = ? WHERE id = ? AND userId = ?`
+	if _, err := r.db.Exec(query, view.Name, filter, view.Period, view.UpdatedAt, view.ID, view.UserID); err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+	return nil
+}
+
+func (r *viewRepository) Delete(userID int64, id int64) error {
+	query := `DELETE FROM views WHERE id = ? AND userId = ?`
+	if _, err := r.db.Exec(query, id, userID); err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanView(row rowScanner) (*View, error) {
+	var view View
+	var filter []byte
+	err := row.Scan(&view.ID, &view.UserID, &view.Name, &filter, &view.Period, &view.CreatedAt, &view.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(filter, &view.Filter); err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/Renan-Parise/finances/internal/redis"
+)
+
+const (
+	generationKey       = "cache:generation:%d"
+	sharedGenerationKey = "cache:generation"
+)
+
+// Cached responses are keyed by the user's current generation, so bumping it
+// makes every previously cached entry unreachable until it expires.
+type Invalidator interface {
+	InvalidateUser(userID int64) error
+	InvalidateAll() error
+}
+
+// Counters holds the generation counters, in Redis outside of tests.
+type Counters interface {
+	Incr(ctx context.Context, key string) (int64, error)
+	MGet(ctx context.Context, keys ...string) ([][]byte, error)
+}
+
+type redisCounters struct{}
+
+func (redisCounters) Incr(ctx context.Context, key string) (int64, error) {
+	return redis.Incr(ctx, key)
+}
+
+func (redisCounters) MGet(ctx context.Context, keys ...string) ([][]byte, error) {
+	return redis.MGet(ctx, keys...)
+}
+
+type Generations struct {
+	counters Counters
+}
+
+func NewGenerations(counters Counters) *Generations {
+	return &Generations{counters: counters}
+}
+
+func NewRedisInvalidator() Invalidator {
+	return NewGenerations(redisCounters{})
+}
+
+func (g *Generations) InvalidateUser(userID int64) error {
+	_, err := g.counters.Incr(context.Background(), fmt.Sprintf(generationKey, userID))
+	return err
+}
+
+func (g *Generations) InvalidateAll() error {
+	_, err := g.counters.Incr(context.Background(), sharedGenerationKey)
+	return err
+}
+
+// Generation adds the user's generation to the shared one.
+func (g *Generations) Generation(ctx context.Context, userID int64) (int64, error) {
+	values, err := g.counters.MGet(ctx, fmt.Sprintf(generationKey, userID), sharedGenerationKey)
+	if err != nil {
+		return 0, err
+	}
+
+	var generation int64
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		n, err := strconv.ParseInt(string(value), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		generation += n
+	}
+	return generation, nil
+}
+
+// InvalidateUserOrLog invalidates the user's cache and only logs a failure.
+func InvalidateUserOrLog(invalidator Invalidator, userID int64) {
+	if err := invalidator.InvalidateUser(userID); err != nil {
+		log.Printf("Failed to invalidate cache for user %d: %v", userID, err)
+	}
+}
+
+// InvalidateAllOrLog is InvalidateUserOrLog for every user.
+func InvalidateAllOrLog(invalidator Invalidator) {
+	if err := invalidator.InvalidateAll(); err != nil {
+		log.Printf("Failed to invalidate cache for all users: %v", err)
+	}
+}
+
+// Generation reads the user's generation from Redis.
+func Generation(ctx context.Context, userID int64) (int64, error) {
+	return NewGenerations(redisCounters{}).Generation(ctx, userID)
+}
@@ -1,42 +1,176 @@
 package container
 
 import (
+	"log"
+
+	"github.com/Renan-Parise/finances/internal/api/accounts"
 	"github.com/Renan-Parise/finances/internal/api/categories"
+	"github.com/Renan-Parise/finances/internal/api/currencies"
+	"github.com/Renan-Parise/finances/internal/api/duplicates"
+	"github.com/Renan-Parise/finances/internal/api/exports"
+	"github.com/Renan-Parise/finances/internal/api/imports"
+	"github.com/Renan-Parise/finances/internal/api/installments"
+	"github.com/Renan-Parise/finances/internal/api/journals"
+	"github.com/Renan-Parise/finances/internal/api/reconciliations"
+	"github.com/Renan-Parise/finances/internal/api/recurring"
+	"github.com/Renan-Parise/finances/internal/api/rules"
 	"github.com/Renan-Parise/finances/internal/api/statistics"
+	"github.com/Renan-Parise/finances/internal/api/suggestions"
+	"github.com/Renan-Parise/finances/internal/api/tags"
 	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/api/users"
+	"github.com/Renan-Parise/finances/internal/api/views"
+	"github.com/Renan-Parise/finances/internal/cache"
 	"github.com/Renan-Parise/finances/internal/db"
+	"github.com/Renan-Parise/finances/internal/mailer"
+	"github.com/Renan-Parise/finances/internal/sessions"
 )
 
 type Container struct {
+	AccountRepository accounts.AccountRepository
+	AccountUseCase    accounts.AccountUseCase
+
 	CategoryRepository categories.CategoryRepository
 	CategoryUseCase    categories.CategoryUseCase
 
+	CurrencyRepository currencies.CurrencyRepository
+	CurrencyUseCase    currencies.CurrencyUseCase
+
+	DuplicateRepository duplicates.DuplicateRepository
+	DuplicateUseCase    duplicates.DuplicateUseCase
+
+	ExportUseCase exports.ExportUseCase
+
+	ImportRepository imports.ImportRepository
+	ImportUseCase    imports.ImportUseCase
+
+	InstallmentRepository installments.InstallmentRepository
+	InstallmentUseCase    installments.InstallmentUseCase
+
+	JournalRepository journals.JournalRepository
+	JournalUseCase    journals.JournalUseCase
+
+	ReconciliationRepository reconciliations.ReconciliationRepository
+	ReconciliationUseCase    reconciliations.ReconciliationUseCase
+
+	RecurringRepository recurring.RecurringRepository
+	RecurringUseCase    recurring.RecurringUseCase
+
+	RuleRepository rules.RuleRepository
+	RuleUseCase    rules.RuleUseCase
+
 	TransactionRepository transactions.TransactionRepositories
 	TransactionUseCase    transactions.TransactionUseCase
 
 	StatisticsRepository statistics.StatisticsRepository
 	StatisticsUseCase    statistics.StatisticsUseCase
+
+	SuggestionRepository suggestions.SuggestionRepository
+	SuggestionUseCase    suggestions.SuggestionUseCase
+
+	TagRepository tags.TagRepository
+	TagUseCase    tags.TagUseCase
+
+	UserRepository users.UserRepository
+	UserUseCase    users.UserUseCase
+
+	ViewRepository views.ViewRepository
+	ViewUseCase    views.ViewUseCase
 }
 
 func NewContainer() *Container {
 	database := db.GetDB()
 
+	accountRepo := accounts.NewAccountRepository(database)
 	categoryRepo := categories.NewCategoryRepository(database)
+	currencyRepo := currencies.NewCurrencyRepository(database)
+	duplicateRepo := duplicates.NewDuplicateRepository(database)
+	importRepo := imports.NewImportRepository(database)
+	installmentRepo := installments.NewInstallmentRepository(database)
+	journalRepo := journals.NewJournalRepository(database)
+	reconciliationRepo := reconciliations.NewReconciliationRepository(database)
+	recurringRepo := recurring.NewRecurringRepository(database)
+	ruleRepo := rules.NewRuleRepository(database)
 	statisticsRepo := statistics.NewStatisticsRepository(database)
+	suggestionRepo := suggestions.NewSuggestionRepository(database)
+	tagRepo := tags.NewTagRepository(database)
 	transactionRepo := transactions.NewTransactionRepositories(database)
+	userRepo := users.NewUserRepository(database)
+	viewRepo := views.NewViewRepository(database)
 
-	categoryUseCase := categories.NewCategoryUseCase(categoryRepo)
-	statisticsUseCase := statistics.NewStatisticsUseCase(statisticsRepo)
-	transactionUseCase := transactions.NewTransactionUseCase(transactionRepo)
+	cacheInvalidator := cache.NewRedisInvalidator()
+	rateProvider := currencies.NewTableRateProvider(currencyRepo)
+
+	suggestionUseCase := suggestions.NewSuggestionUseCase(suggestionRepo, transactionRepo)
+	ruleUseCase := rules.NewRuleUseCase(ruleRepo, transactionRepo, accountRepo, categoryRepo, tagRepo, suggestionUseCase, cacheInvalidator)
+	accountUseCase := accounts.NewAccountUseCase(accountRepo, currencyRepo, cacheInvalidator)
+	categoryUseCase := categories.NewCategoryUseCase(categoryRepo, cacheInvalidator)
+	currencyUseCase := currencies.NewCurrencyUseCase(currencyRepo, rateProvider, cacheInvalidator)
+	duplicateUseCase := duplicates.NewDuplicateUseCase(duplicateRepo, transactionRepo, suggestionUseCase, cacheInvalidator)
+	exportUseCase := exports.NewExportUseCase(transactionRepo, categoryRepo, accountRepo)
+	importUseCase := imports.NewImportUseCase(importRepo, transactionRepo, accountRepo, currencyRepo, ruleUseCase, suggestionUseCase, cacheInvalidator)
+	installmentUseCase := installments.NewInstallmentUseCase(installmentRepo, accountRepo, currencyRepo, suggestionUseCase, cacheInvalidator)
+	journalUseCase := journals.NewJournalUseCase(journalRepo, transactionRepo, accountRepo, categoryRepo, suggestionUseCase, cacheInvalidator)
+	reconciliationUseCase := reconciliations.NewReconciliationUseCase(reconciliationRepo, transactionRepo, accountRepo)
+	recurringUseCase := recurring.NewRecurringUseCase(recurringRepo, accountRepo, currencyRepo, suggestionUseCase, cacheInvalidator)
+	statisticsUseCase := statistics.NewStatisticsUseCase(statisticsRepo, currencyRepo, rateProvider)
+	tagUseCase := tags.NewTagUseCase(tagRepo, cacheInvalidator)
+	transactionUseCase := transactions.NewTransactionUseCase(transactionRepo, accountRepo, currencyRepo, ruleUseCase, suggestionUseCase, cacheInvalidator)
+	if err := users.CheckSecretKey(); err != nil {
+		log.Fatalf("Could not set up two-factor secrets: %v", err)
+	}
+	userUseCase := users.NewUserUseCase(userRepo, categoryUseCase, mailer.NewMailer(), sessions.NewRedisStore())
+	viewUseCase := views.NewViewUseCase(viewRepo, transactionRepo, transactionUseCase)
 
 	return &Container{
+		AccountUseCase:    accountUseCase,
+		AccountRepository: accountRepo,
+
+		DuplicateUseCase:    duplicateUseCase,
+		DuplicateRepository: duplicateRepo,
+
+		ExportUseCase: exportUseCase,
+
+		ImportUseCase:    importUseCase,
+		ImportRepository: importRepo,
+
+		InstallmentUseCase:    installmentUseCase,
+		InstallmentRepository: installmentRepo,
+
+		JournalUseCase:    journalUseCase,
+		JournalRepository: journalRepo,
+
+		ReconciliationUseCase:    reconciliationUseCase,
+		ReconciliationRepository: reconciliationRepo,
+
+		RecurringUseCase:    recurringUseCase,
+		RecurringRepository: recurringRepo,
+
+		RuleUseCase:    ruleUseCase,
+		RuleRepository: ruleRepo,
+
 		TransactionUseCase:    transactionUseCase,
 		TransactionRepository: transactionRepo,
 
 		CategoryUseCase:    categoryUseCase,
 		CategoryRepository: categoryRepo,
 
+		CurrencyUseCase:    currencyUseCase,
+		CurrencyRepository: currencyRepo,
+
 		StatisticsUseCase:    statisticsUseCase,
 		StatisticsRepository: statisticsRepo,
+
+		SuggestionUseCase:    suggestionUseCase,
+		SuggestionRepository: suggestionRepo,
+
+		TagUseCase:    tagUseCase,
+		TagRepository: tagRepo,
+
+		UserUseCase:    userUseCase,
+		UserRepository: userRepo,
+
+		ViewUseCase:    viewUseCase,
+		ViewRepository: viewRepo,
 	}
 }
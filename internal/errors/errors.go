@@ -5,9 +5,11 @@ import (
 	"fmt"
 )
 
+// ValidationError reports invalid input.
 type ValidationError struct {
-	Field   string
-	Message string
+	Field    string
+	Message  string
+	Position int
 }
 
 type QueryError struct {
@@ -23,6 +25,9 @@ type ServiceError struct {
 }
 
 func (e *ValidationError) Error() string {
+	if e.Position > 0 {
+		return fmt.Sprintf("Error while validating: field '%s': %s at position %d.", e.Field, e.Message, e.Position)
+	}
 	return fmt.Sprintf("Error while validating: field '%s': %s.", e.Field, e.Message)
 }
 
@@ -42,6 +47,10 @@ func NewValidationError(field, message string) *ValidationError {
 	return &ValidationError{Field: field, Message: message}
 }
 
+func NewPositionedValidationError(field, message string, position int) *ValidationError {
+	return &ValidationError{Field: field, Message: message, Position: position}
+}
+
 func NewQueryError(reason string) *QueryError {
 	return &QueryError{Reason: reason}
 }
@@ -77,3 +86,7 @@ func IsServiceError(err error) bool {
 func Is(err, target error) bool {
 	return errors.Is(err, target)
 }
+
+func As(err error, target interface{}) bool {
+	return errors.As(err, target)
+}
@@ -0,0 +1,163 @@
+// Package ledger writes transaction rows.
+package ledger
+
+import (
+	"database/sql"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/utils"
+	"github.com/Renan-Parise/finances/pkg/money"
+)
+
+// StatusPending is the status of a transaction that has not been checked
+// against a statement yet.
+const StatusPending = "pending"
+
+// Entry is a transaction to insert.
+type Entry struct {
+	UserID      int64
+	AccountID   int64
+	TransferID  int64
+	OccurredAt  time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Description string
+	Category    int64
+	Amount      money.Money
+	Currency    string
+	Status      string
+	Tags        []int64
+	Notes       []string
+}
+
+// Insert stores the entry and its tags in tx and returns the new transaction
+// ID.
+func Insert(tx *sql.Tx, entry *Entry) (int64, error) {
+	status := entry.Status
+	if status == "" {
+		status = StatusPending
+	}
+
+	occurredAt, offset := StoredOccurredAt(entry.OccurredAt)
+	res, err := tx.Exec(`INSERT INTO transactions (userId, accountId, transferId, occurredAt, occurredOffset, createdAt, updatedAt,
+              description, searchText, category, amount, currency, status)
+              VALUES (?, NULLIF(?, 0), NULLIF(?, 0), ?, ?, ?, ?, ?, ?, NULLIF(?, 0), ?, ?, ?)`,
+		entry.UserID, entry.AccountID, entry.TransferID, occurredAt, offset, entry.CreatedAt, entry.UpdatedAt,
+		entry.Description, SearchText(entry.Description, entry.Notes), entry.Category, entry.Amount, entry.Currency, status)
+	if err != nil {
+		return 0, errors.NewQueryError("error inserting transaction: " + err.Error())
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, errors.NewQueryError("error getting last insert ID: " + err.Error())
+	}
+
+	if err := InsertTags(tx, entry.UserID, id, entry.Tags); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// RefreshSearchText rebuilds the search text of a stored transaction from its
+// description and split notes.
+func RefreshSearchText(tx *sql.Tx, transactionID int64) error {
+	var description, notes string
+	err := tx.QueryRow(`SELECT t.description, COALESCE(GROUP_CONCAT(s.note ORDER BY s.id SEPARATOR '\n'), '')
+              FROM transactions t
+              LEFT JOIN transaction_splits s ON s.transactionId = t.id
+              WHERE t.id = ?
+              GROUP BY t.id, t.description`, transactionID).Scan(&description, &notes)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return errors.NewQueryError("error executing query: " + err.Error())
+	}
+
+	if _, err := tx.Exec(`UPDATE transactions SET searchText = ? WHERE id = ?`,
+		SearchText(description, strings.Split(notes, "\n")), transactionID); err != nil {
+		return errors.NewQueryError("error updating search text: " + err.Error())
+	}
+	return nil
+}
+
+// SearchText is what the full-text index holds for a transaction: its
+// description and split notes, without accents or case.
+func SearchText(description string, notes []string) string {
+	return strings.ToLower(utils.RemoveAccents(strings.Join(append([]string{description}, notes...), " ")))
+}
+
+// InsertTags attaches tags to a stored transaction, failing when one of them
+// is not the user's.
+func InsertTags(tx *sql.Tx, userID int64, transactionID int64, tags []int64) error {
+	tags, err := NormalizeTags(tags)
+	if err != nil || len(tags) == 0 {
+		return err
+	}
+
+	list, args := IDList(tags)
+	res, err := tx.Exec(`INSERT INTO transaction_tags (transactionId, tagId) SELECT ?, id FROM tags WHERE userId = ? AND id IN `+list,
+		append([]interface{}{transactionID, userID}, args...)...)
+	if err != nil {
+		return errors.NewQueryError("error inserting tags: " + err.Error())
+	}
+
+	if affected, err := res.RowsAffected(); err != nil || affected != int64(len(tags)) {
+		return errors.NewValidationError("tags", "tag not found")
+	}
+	return nil
+}
+
+// NormalizeTags sorts tag IDs and drops repeated ones.
+func NormalizeTags(ids []int64) ([]int64, error) {
+	if ids == nil {
+		return nil, nil
+	}
+
+	seen := make(map[int64]bool, len(ids))
+	normalized := []int64{}
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, errors.NewValidationError("tags", "invalid tag ID")
+		}
+		if !seen[id] {
+			seen[id] = true
+			normalized = append(normalized, id)
+		}
+	}
+	sort.Slice(normalized, func(i, j int) bool { return normalized[i] < normalized[j] })
+	return normalized, nil
+}
+
+// IDList returns a parenthesized placeholder list for ids with its arguments.
+func IDList(ids []int64) (string, []interface{}) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	return "(" + strings.Join(placeholders, ", ") + ")", args
+}
+
+// StoredOccurredAt splits a time into its wall clock and its UTC offset in
+// minutes.
+func StoredOccurredAt(occurredAt time.Time) (time.Time, int) {
+	_, offset := occurredAt.Zone()
+	wall := time.Date(occurredAt.Year(), occurredAt.Month(), occurredAt.Day(),
+		occurredAt.Hour(), occurredAt.Minute(), occurredAt.Second(), 0, time.UTC)
+	return wall, offset / 60
+}
+
+// RestoreOccurredAt rebuilds the time split by StoredOccurredAt.
+func RestoreOccurredAt(wall time.Time, offset int) time.Time {
+	if offset == 0 {
+		return wall
+	}
+	return time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), 0,
+		time.FixedZone("", offset*60))
+}
@@ -0,0 +1,25 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/ledger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoredOccurredAtKeepsWallClockAndOffset(t *testing.T) {
+	saoPaulo := time.FixedZone("", -3*60*60)
+	occurredAt := time.Date(2026, 1, 31, 22, 30, 15, 500, saoPaulo)
+
+	wall, offset := ledger.StoredOccurredAt(occurredAt)
+	assert.Equal(t, time.Date(2026, 1, 31, 22, 30, 15, 0, time.UTC), wall)
+	assert.Equal(t, -180, offset)
+
+	restored := ledger.RestoreOccurredAt(wall, offset)
+	assert.True(t, restored.Equal(occurredAt.Truncate(time.Second)))
+
+	wall, offset = ledger.StoredOccurredAt(time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, 0, offset)
+	assert.Equal(t, time.UTC, ledger.RestoreOccurredAt(wall, offset).Location())
+}
@@ -0,0 +1,24 @@
+package mailer
+
+import (
+	"os"
+)
+
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+func NewMailer() Mailer {
+	switch os.Getenv("MAIL_DRIVER") {
+	case "smtp":
+		return NewSMTPMailer(
+			os.Getenv("SMTP_HOST"),
+			os.Getenv("SMTP_PORT"),
+			os.Getenv("SMTP_USERNAME"),
+			os.Getenv("SMTP_PASSWORD"),
+			os.Getenv("SMTP_FROM"),
+		)
+	default:
+		return NewFileMailer(os.Getenv("MAIL_FILE_PATH"))
+	}
+}
@@ -0,0 +1,37 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Renan-Parise/finances/internal/mailer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileMailerAppendsMessages(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.log")
+	m := mailer.NewFileMailer(path)
+
+	assert.NoError(t, m.Send("jane@example.com", "First", "code 123"))
+	assert.NoError(t, m.Send("john@example.com", "Second", "code 456"))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "To: jane@example.com")
+	assert.Contains(t, string(content), "Subject: First")
+	assert.Contains(t, string(content), "code 123")
+	assert.Contains(t, string(content), "To: john@example.com")
+	assert.Contains(t, string(content), "code 456")
+}
+
+func TestNewMailerDefaultsToFileMailer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.log")
+	t.Setenv("MAIL_DRIVER", "")
+	t.Setenv("MAIL_FILE_PATH", path)
+
+	assert.NoError(t, mailer.NewMailer().Send("jane@example.com", "Hello", "body"))
+
+	_, err := os.Stat(path)
+	assert.NoError(t, err)
+}
@@ -2,16 +2,24 @@ package middlewares
 
 import (
 	"fmt"
+	"log"
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/Renan-Parise/finances/internal/sessions"
 	jwt "github.com/golang-jwt/jwt/v4"
 
 	"github.com/gin-gonic/gin"
 )
 
 func JWTAuthMiddleware() gin.HandlerFunc {
+	return JWTAuthMiddlewareWithStore(sessions.NewRedisStore())
+}
+
+// JWTAuthMiddlewareWithStore checks token revocation against the given store.
+func JWTAuthMiddlewareWithStore(store sessions.Store) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -42,11 +50,39 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 
 		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 			if service, exists := claims["service"]; exists && service == "auth" {
+				c.Set("service", service)
 				c.Next()
 				return
 			}
+			if scope, ok := claims["scope"].(string); ok && scope != "" {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "token is not valid for this resource"})
+				return
+			}
 			if userID, ok := claims["userID"].(float64); ok {
+				issuedAt, ok := claims["iat_us"].(float64)
+				if !ok {
+					seconds, _ := claims["iat"].(float64)
+					issuedAt = seconds * 1e6
+				}
+				expiresAt, _ := claims["exp"].(float64)
+				tokenID, _ := claims["jti"].(string)
+				sessionID, _ := claims["sid"].(string)
+
+				revoked, err := store.IsRevoked(c.Request.Context(), int64(userID), int64(issuedAt), tokenID, sessionID)
+				if err != nil {
+					log.Printf("Could not check token revocation: %v", err)
+					c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "could not verify the token, try again later"})
+					return
+				}
+				if revoked {
+					c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "token has been revoked"})
+					return
+				}
+
 				c.Set("userID", int64(userID))
+				c.Set("tokenID", tokenID)
+				c.Set("sessionID", sessionID)
+				c.Set("tokenExpiresAt", time.Unix(int64(expiresAt), 0))
 			}
 			c.Next()
 			return
@@ -56,3 +92,14 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 		}
 	}
 }
+
+// ServiceOnlyMiddleware runs after JWTAuthMiddleware and refuses user tokens.
+func ServiceOnlyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, exists := c.Get("service"); !exists {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "this resource is only available to services"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -3,11 +3,13 @@ package middlewares
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"hash/fnv"
+	"log"
 	"net/http"
 	"time"
 
-	"github.com/Renan-Parise/finances/internal/errors"
+	"github.com/Renan-Parise/finances/internal/cache"
 	"github.com/Renan-Parise/finances/internal/redis"
 	"github.com/gin-gonic/gin"
 )
@@ -17,7 +19,19 @@ const cacheDuration = 2 * time.Minute
 func RedisCacheMiddleware(c *gin.Context) {
 	ctx := context.Background()
 
-	cacheKey := generateCacheKey(c.Request)
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Next()
+		return
+	}
+
+	generation, err := cache.Generation(ctx, userID.(int64))
+	if err != nil {
+		c.Next()
+		return
+	}
+
+	cacheKey := CacheKey(userID.(int64), generation, c.Request)
 
 	cached, err := redis.Get(ctx, cacheKey)
 	if err == nil && cached != nil {
@@ -39,18 +53,19 @@ func RedisCacheMiddleware(c *gin.Context) {
 	if statusCode == http.StatusOK {
 		err := redis.Set(ctx, cacheKey, buff.body.Bytes(), cacheDuration)
 		if err != nil {
-			errors.NewServiceError("Failed to cache response. Error: %v" + err.Error())
+			log.Printf("Failed to cache response: %v", err)
 		}
 	}
 
 	c.Writer = writer
 }
 
-func generateCacheKey(req *http.Request) string {
+// CacheKey is where a user's response to req is cached at a generation.
+func CacheKey(userID, generation int64, req *http.Request) string {
 	data := req.URL.Path + "?" + req.URL.RawQuery
 	h := fnv.New64a()
 	h.Write([]byte(data))
-	return "cache:" + string(h.Sum(nil))
+	return fmt.Sprintf("cache:%d:%d:%x", userID, generation, h.Sum(nil))
 }
 
 type responseBuffer struct {
@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/middlewares"
+	"github.com/gin-gonic/gin"
+	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecret = "test-secret"
+
+func signToken(t *testing.T, claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	assert.NoError(t, err)
+	return token
+}
+
+func performRequest(router *gin.Engine, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestJWTAuthMiddlewareRejectsScopedTokens(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("SECRET_KEY", testSecret)
+
+	router := gin.New()
+	router.GET("/protected", middlewares.JWTAuthMiddleware(), func(c *gin.Context) {
+		userID, _ := c.Get("userID")
+		c.JSON(http.StatusOK, gin.H{"userID": userID})
+	})
+
+	expiresAt := time.Now().Add(time.Minute).Unix()
+
+	access := signToken(t, jwt.MapClaims{"userID": 7, "exp": expiresAt})
+	assert.Equal(t, http.StatusServiceUnavailable, performRequest(router, access).Code)
+
+	pending := signToken(t, jwt.MapClaims{"userID": 7, "scope": "2fa_pending", "exp": expiresAt})
+	assert.Equal(t, http.StatusUnauthorized, performRequest(router, pending).Code)
+}
+
+func TestServiceOnlyMiddlewareRefusesUserTokens(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("SECRET_KEY", testSecret)
+
+	router := gin.New()
+	router.GET("/protected", middlewares.JWTAuthMiddleware(), middlewares.ServiceOnlyMiddleware(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	expiresAt := time.Now().Add(time.Minute).Unix()
+
+	service := signToken(t, jwt.MapClaims{"service": "auth", "exp": expiresAt})
+	assert.Equal(t, http.StatusOK, performRequest(router, service).Code)
+
+	noService := signToken(t, jwt.MapClaims{"exp": expiresAt})
+	assert.Equal(t, http.StatusForbidden, performRequest(router, noService).Code)
+}
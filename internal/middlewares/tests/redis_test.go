@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/Renan-Parise/finances/internal/cache"
+	"github.com/Renan-Parise/finances/internal/middlewares"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryCounters struct {
+	values map[string]int64
+}
+
+func (m *memoryCounters) Incr(ctx context.Context, key string) (int64, error) {
+	m.values[key]++
+	return m.values[key], nil
+}
+
+func (m *memoryCounters) MGet(ctx context.Context, keys ...string) ([][]byte, error) {
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		if value, ok := m.values[key]; ok {
+			values[i] = []byte(strconv.FormatInt(value, 10))
+		}
+	}
+	return values, nil
+}
+
+func cacheKey(t *testing.T, generations *cache.Generations, userID int64, req *http.Request) string {
+	generation, err := generations.Generation(context.Background(), userID)
+	require.NoError(t, err)
+	return middlewares.CacheKey(userID, generation, req)
+}
+
+func TestCacheKeysAreSeparatedByUser(t *testing.T) {
+	generations := cache.NewGenerations(&memoryCounters{values: map[string]int64{}})
+	req := httptest.NewRequest(http.MethodGet, "/api/transactions?limit=10", nil)
+
+	assert.NotEqual(t, cacheKey(t, generations, 1, req), cacheKey(t, generations, 2, req))
+}
+
+func TestInvalidateUserChangesOnlyThatUsersKeys(t *testing.T) {
+	generations := cache.NewGenerations(&memoryCounters{values: map[string]int64{}})
+	req := httptest.NewRequest(http.MethodGet, "/api/statistics", nil)
+
+	first, second := cacheKey(t, generations, 1, req), cacheKey(t, generations, 2, req)
+
+	require.NoError(t, generations.InvalidateUser(1))
+	assert.NotEqual(t, first, cacheKey(t, generations, 1, req))
+	assert.Equal(t, second, cacheKey(t, generations, 2, req))
+
+	first, second = cacheKey(t, generations, 1, req), cacheKey(t, generations, 2, req)
+
+	require.NoError(t, generations.InvalidateAll())
+	assert.NotEqual(t, first, cacheKey(t, generations, 1, req))
+	assert.NotEqual(t, second, cacheKey(t, generations, 2, req))
+}
@@ -2,6 +2,7 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"log"
 	"os"
 	"time"
@@ -12,6 +13,8 @@ import (
 var (
 	rdb *redis.Client
 	ctx = context.Background()
+
+	ErrNotConnected = errors.New("redis client is not connected")
 )
 
 func GetRedis() {
@@ -32,13 +35,94 @@ func GetClient() *redis.Client {
 }
 
 func Set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
+	if rdb == nil {
+		return ErrNotConnected
+	}
 	return rdb.Set(ctx, key, value, expiration).Err()
 }
 
 func Get(ctx context.Context, key string) ([]byte, error) {
+	if rdb == nil {
+		return nil, ErrNotConnected
+	}
 	val, err := rdb.Get(ctx, key).Bytes()
 	if err == redis.Nil {
 		return nil, nil
 	}
 	return val, err
 }
+
+func SetNX(ctx context.Context, key string, value []byte, expiration time.Duration) (bool, error) {
+	if rdb == nil {
+		return false, ErrNotConnected
+	}
+	return rdb.SetNX(ctx, key, value, expiration).Result()
+}
+
+func MGet(ctx context.Context, keys ...string) ([][]byte, error) {
+	if rdb == nil {
+		return nil, ErrNotConnected
+	}
+	vals, err := rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([][]byte, len(vals))
+	for i, val := range vals {
+		if s, ok := val.(string); ok {
+			result[i] = []byte(s)
+		}
+	}
+	return result, nil
+}
+
+func Del(ctx context.Context, keys ...string) error {
+	if rdb == nil {
+		return ErrNotConnected
+	}
+	return rdb.Del(ctx, keys...).Err()
+}
+
+func SAdd(ctx context.Context, key string, members ...string) error {
+	if rdb == nil {
+		return ErrNotConnected
+	}
+	values := make([]interface{}, len(members))
+	for i, member := range members {
+		values[i] = member
+	}
+	return rdb.SAdd(ctx, key, values...).Err()
+}
+
+func SRem(ctx context.Context, key string, members ...string) error {
+	if rdb == nil {
+		return ErrNotConnected
+	}
+	values := make([]interface{}, len(members))
+	for i, member := range members {
+		values[i] = member
+	}
+	return rdb.SRem(ctx, key, values...).Err()
+}
+
+func SMembers(ctx context.Context, key string) ([]string, error) {
+	if rdb == nil {
+		return nil, ErrNotConnected
+	}
+	return rdb.SMembers(ctx, key).Result()
+}
+
+func Expire(ctx context.Context, key string, expiration time.Duration) error {
+	if rdb == nil {
+		return ErrNotConnected
+	}
+	return rdb.Expire(ctx, key, expiration).Err()
+}
+
+func Incr(ctx context.Context, key string) (int64, error) {
+	if rdb == nil {
+		return 0, ErrNotConnected
+	}
+	return rdb.Incr(ctx, key).Result()
+}
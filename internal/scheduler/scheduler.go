@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job runs periodically with the time of the tick.
+type Job func(now time.Time) error
+
+type entry struct {
+	name     string
+	interval time.Duration
+	job      Job
+}
+
+type Scheduler struct {
+	entries []entry
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+func (s *Scheduler) Every(name string, interval time.Duration, job Job) {
+	s.entries = append(s.entries, entry{name: name, interval: interval, job: job})
+}
+
+// Start launches every registered job in its own goroutine until ctx is
+// cancelled.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, e := range s.entries {
+		go s.run(ctx, e)
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, e entry) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	s.execute(e, time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.execute(e, now)
+		}
+	}
+}
+
+func (s *Scheduler) execute(e entry, now time.Time) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Scheduled job %s panicked: %v", e.name, r)
+		}
+	}()
+
+	if err := e.job(now); err != nil {
+		log.Printf("Scheduled job %s failed: %v", e.name, err)
+	}
+}
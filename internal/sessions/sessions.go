@@ -0,0 +1,335 @@
+package sessions
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/redis"
+)
+
+const (
+	revokedBeforeKey = "sessions:revoked-before:%d"
+	denylistKey      = "sessions:denylist:%s"
+	sessionKey       = "sessions:session:%s"
+	userSessionsKey  = "sessions:user:%d"
+	refreshKey       = "sessions:refresh:%s"
+	refreshUsedKey   = "sessions:refresh-used:%s"
+	failedAttemptKey = "sessions:failed-attempts:%d"
+)
+
+// Store is the session state the user use case works with, kept in Redis.
+type Store interface {
+	SaveSession(ctx context.Context, session *Session, ttl time.Duration) error
+	GetSession(ctx context.Context, sessionID string) (*Session, error)
+	ListSessions(ctx context.Context, userID int64) ([]*Session, error)
+	RevokeSession(ctx context.Context, userID int64, sessionID string) error
+	SaveRefreshToken(ctx context.Context, token string, refresh *RefreshToken, ttl time.Duration) error
+	GetRefreshToken(ctx context.Context, token string) (*RefreshToken, error)
+	ConsumeRefreshToken(ctx context.Context, token string, ttl time.Duration) (bool, error)
+	DenyToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	RevokeUser(ctx context.Context, userID int64, ttl time.Duration) error
+	FailedAttempts(ctx context.Context, userID int64) (int64, error)
+	RecordFailedAttempt(ctx context.Context, userID int64, window time.Duration) (int64, error)
+	ClearFailedAttempts(ctx context.Context, userID int64) error
+	IsRevoked(ctx context.Context, userID int64, issuedAt int64, tokenID, sessionID string) (bool, error)
+}
+
+type redisStore struct{}
+
+func NewRedisStore() Store {
+	return &redisStore{}
+}
+
+func (s *redisStore) SaveSession(ctx context.Context, session *Session, ttl time.Duration) error {
+	return SaveSession(ctx, session, ttl)
+}
+
+func (s *redisStore) GetSession(ctx context.Context, sessionID string) (*Session, error) {
+	return GetSession(ctx, sessionID)
+}
+
+func (s *redisStore) ListSessions(ctx context.Context, userID int64) ([]*Session, error) {
+	return ListSessions(ctx, userID)
+}
+
+func (s *redisStore) RevokeSession(ctx context.Context, userID int64, sessionID string) error {
+	return RevokeSession(ctx, userID, sessionID)
+}
+
+func (s *redisStore) SaveRefreshToken(ctx context.Context, token string, refresh *RefreshToken, ttl time.Duration) error {
+	return SaveRefreshToken(ctx, token, refresh, ttl)
+}
+
+func (s *redisStore) GetRefreshToken(ctx context.Context, token string) (*RefreshToken, error) {
+	return GetRefreshToken(ctx, token)
+}
+
+func (s *redisStore) ConsumeRefreshToken(ctx context.Context, token string, ttl time.Duration) (bool, error) {
+	return ConsumeRefreshToken(ctx, token, ttl)
+}
+
+func (s *redisStore) DenyToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	return DenyToken(ctx, tokenID, expiresAt)
+}
+
+func (s *redisStore) RevokeUser(ctx context.Context, userID int64, ttl time.Duration) error {
+	return RevokeUser(ctx, userID, ttl)
+}
+
+func (s *redisStore) FailedAttempts(ctx context.Context, userID int64) (int64, error) {
+	return FailedAttempts(ctx, userID)
+}
+
+func (s *redisStore) RecordFailedAttempt(ctx context.Context, userID int64, window time.Duration) (int64, error) {
+	return RecordFailedAttempt(ctx, userID, window)
+}
+
+func (s *redisStore) ClearFailedAttempts(ctx context.Context, userID int64) error {
+	return ClearFailedAttempts(ctx, userID)
+}
+
+func (s *redisStore) IsRevoked(ctx context.Context, userID int64, issuedAt int64, tokenID, sessionID string) (bool, error) {
+	return IsRevoked(ctx, userID, issuedAt, tokenID, sessionID)
+}
+
+// Session groups every refresh token derived from a single login.
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     int64     `json:"userId"`
+	UserAgent  string    `json:"userAgent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+}
+
+type RefreshToken struct {
+	SessionID string `json:"sessionId"`
+	UserID    int64  `json:"userId"`
+}
+
+func NewID() (string, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+func NewRefreshToken() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func SaveSession(ctx context.Context, session *Session, ttl time.Duration) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	if err := redis.Set(ctx, fmt.Sprintf(sessionKey, session.ID), data, ttl); err != nil {
+		return err
+	}
+
+	userKey := fmt.Sprintf(userSessionsKey, session.UserID)
+	if err := redis.SAdd(ctx, userKey, session.ID); err != nil {
+		return err
+	}
+	return redis.Expire(ctx, userKey, ttl)
+}
+
+func GetSession(ctx context.Context, sessionID string) (*Session, error) {
+	data, err := redis.Get(ctx, fmt.Sprintf(sessionKey, sessionID))
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func ListSessions(ctx context.Context, userID int64) ([]*Session, error) {
+	userKey := fmt.Sprintf(userSessionsKey, userID)
+	ids, err := redis.SMembers(ctx, userKey)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = fmt.Sprintf(sessionKey, id)
+	}
+
+	values, err := redis.MGet(ctx, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []*Session
+	var expired []string
+	for i, data := range values {
+		if data == nil {
+			expired = append(expired, ids[i])
+			continue
+		}
+		var session Session
+		if err := json.Unmarshal(data, &session); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, &session)
+	}
+
+	if len(expired) > 0 {
+		if err := redis.SRem(ctx, userKey, expired...); err != nil {
+			return nil, err
+		}
+	}
+	return sessions, nil
+}
+
+func RevokeSession(ctx context.Context, userID int64, sessionID string) error {
+	if err := redis.Del(ctx, fmt.Sprintf(sessionKey, sessionID)); err != nil {
+		return err
+	}
+	return redis.SRem(ctx, fmt.Sprintf(userSessionsKey, userID), sessionID)
+}
+
+func SaveRefreshToken(ctx context.Context, token string, refresh *RefreshToken, ttl time.Duration) error {
+	data, err := json.Marshal(refresh)
+	if err != nil {
+		return err
+	}
+	return redis.Set(ctx, fmt.Sprintf(refreshKey, hashToken(token)), data, ttl)
+}
+
+func GetRefreshToken(ctx context.Context, token string) (*RefreshToken, error) {
+	data, err := redis.Get(ctx, fmt.Sprintf(refreshKey, hashToken(token)))
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	var refresh RefreshToken
+	if err := json.Unmarshal(data, &refresh); err != nil {
+		return nil, err
+	}
+	return &refresh, nil
+}
+
+// ConsumeRefreshToken marks the token as used and reports whether this call
+// was the first one to do so.
+func ConsumeRefreshToken(ctx context.Context, token string, ttl time.Duration) (bool, error) {
+	return redis.SetNX(ctx, fmt.Sprintf(refreshUsedKey, hashToken(token)), []byte("1"), ttl)
+}
+
+// DenyToken blocks a single access token until it would have expired anyway.
+func DenyToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return redis.Set(ctx, fmt.Sprintf(denylistKey, tokenID), []byte("1"), ttl)
+}
+
+// RevokeUser invalidates every token issued to the user up to now and drops
+// their sessions.
+func RevokeUser(ctx context.Context, userID int64, ttl time.Duration) error {
+	now := strconv.FormatInt(time.Now().UnixMicro(), 10)
+	if err := redis.Set(ctx, fmt.Sprintf(revokedBeforeKey, userID), []byte(now), ttl); err != nil {
+		return err
+	}
+
+	userKey := fmt.Sprintf(userSessionsKey, userID)
+	ids, err := redis.SMembers(ctx, userKey)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{userKey}
+	for _, id := range ids {
+		keys = append(keys, fmt.Sprintf(sessionKey, id))
+	}
+	return redis.Del(ctx, keys...)
+}
+
+func FailedAttempts(ctx context.Context, userID int64) (int64, error) {
+	value, err := redis.Get(ctx, fmt.Sprintf(failedAttemptKey, userID))
+	if err != nil || value == nil {
+		return 0, err
+	}
+	return strconv.ParseInt(string(value), 10, 64)
+}
+
+// RecordFailedAttempt counts a failed two-factor attempt and returns the count
+// so far.
+func RecordFailedAttempt(ctx context.Context, userID int64, window time.Duration) (int64, error) {
+	key := fmt.Sprintf(failedAttemptKey, userID)
+	count, err := redis.Incr(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	if count == 1 {
+		if err := redis.Expire(ctx, key, window); err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+func ClearFailedAttempts(ctx context.Context, userID int64) error {
+	return redis.Del(ctx, fmt.Sprintf(failedAttemptKey, userID))
+}
+
+// IsRevoked reports whether an access token was revoked, either by itself,
+// through its session or by a user-wide revocation.
+func IsRevoked(ctx context.Context, userID int64, issuedAt int64, tokenID, sessionID string) (bool, error) {
+	keys := []string{fmt.Sprintf(revokedBeforeKey, userID)}
+	if tokenID != "" {
+		keys = append(keys, fmt.Sprintf(denylistKey, tokenID))
+	}
+	if sessionID != "" {
+		keys = append(keys, fmt.Sprintf(sessionKey, sessionID))
+	}
+
+	values, err := redis.MGet(ctx, keys...)
+	if err != nil {
+		return false, err
+	}
+
+	if values[0] != nil {
+		revokedAt, err := strconv.ParseInt(string(values[0]), 10, 64)
+		if err != nil {
+			return false, err
+		}
+		if issuedAt <= revokedAt {
+			return true, nil
+		}
+	}
+
+	next := 1
+	if tokenID != "" {
+		if values[next] != nil {
+			return true, nil
+		}
+		next++
+	}
+	if sessionID != "" && values[next] == nil {
+		return true, nil
+	}
+	return false, nil
+}
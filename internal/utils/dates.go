@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/errors"
+)
+
+var dateTokens = strings.NewReplacer(
+	"YYYY", "2006",
+	"YY", "06",
+	"MM", "01",
+	"DD", "02",
+	"hh", "15",
+	"mm", "04",
+	"ss", "05",
+)
+
+// DateLayout turns a user-facing format such as DD/MM/YYYY into a Go time
+// layout.
+func DateLayout(format string) (string, error) {
+	layout := dateTokens.Replace(format)
+	if !strings.Contains(layout, "01") || !strings.Contains(layout, "02") ||
+		!(strings.Contains(layout, "2006") || strings.Contains(layout, "06")) {
+		return "", errors.NewValidationError("dateFormat", "date format must contain YYYY (or YY), MM and DD")
+	}
+	return layout, nil
+}
+
+// AddMonths moves date by months, clamping to the end of shorter months so a
+// date on the 31st stays at each month's last day.
+func AddMonths(date time.Time, months int) time.Time {
+	first := time.Date(date.Year(), date.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+
+	day := date.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
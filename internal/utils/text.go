@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// RemoveAccents strips diacritics, turning "Padaria São João" into "Padaria
+// Sao Joao".
+func RemoveAccents(value string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	result, _, err := transform.String(t, value)
+	if err != nil {
+		return value
+	}
+	return result
+}
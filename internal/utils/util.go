@@ -1,6 +1,6 @@
 package utils
 
-func MergeKeys(maps ...map[string]float64) map[string]struct{} {
+func MergeKeys[V any](maps ...map[string]V) map[string]struct{} {
 	merged := make(map[string]struct{})
 	for _, m := range maps {
 		for key := range m {
@@ -1,12 +1,30 @@
 package main
 
 import (
+	"context"
+	"time"
+
+	"github.com/Renan-Parise/finances/internal/api/accounts"
 	"github.com/Renan-Parise/finances/internal/api/categories"
+	"github.com/Renan-Parise/finances/internal/api/currencies"
+	"github.com/Renan-Parise/finances/internal/api/duplicates"
+	"github.com/Renan-Parise/finances/internal/api/exports"
+	"github.com/Renan-Parise/finances/internal/api/imports"
+	"github.com/Renan-Parise/finances/internal/api/installments"
+	"github.com/Renan-Parise/finances/internal/api/journals"
+	"github.com/Renan-Parise/finances/internal/api/reconciliations"
+	"github.com/Renan-Parise/finances/internal/api/recurring"
+	"github.com/Renan-Parise/finances/internal/api/rules"
 	"github.com/Renan-Parise/finances/internal/api/statistics"
+	"github.com/Renan-Parise/finances/internal/api/suggestions"
+	"github.com/Renan-Parise/finances/internal/api/tags"
 	"github.com/Renan-Parise/finances/internal/api/transactions"
+	"github.com/Renan-Parise/finances/internal/api/users"
+	"github.com/Renan-Parise/finances/internal/api/views"
 	"github.com/Renan-Parise/finances/internal/container"
 	"github.com/Renan-Parise/finances/internal/db"
 	"github.com/Renan-Parise/finances/internal/redis"
+	"github.com/Renan-Parise/finances/internal/scheduler"
 
 	"github.com/gin-gonic/gin"
 )
@@ -24,6 +42,26 @@ func main() {
 	transactions.NewTransactionHandler(api, container.TransactionUseCase)
 	statistics.NewStatisticsHandler(api, container.StatisticsUseCase)
 	categories.NewCategoryHandler(api, container.CategoryUseCase)
+	users.NewUserHandler(api, container.UserUseCase)
+	accounts.NewAccountHandler(api, container.AccountUseCase)
+	currencies.NewCurrencyHandler(api, container.CurrencyUseCase)
+	recurring.NewRecurringHandler(api, container.RecurringUseCase)
+	installments.NewInstallmentHandler(api, container.InstallmentUseCase)
+	imports.NewImportHandler(api, container.ImportUseCase)
+	exports.NewExportHandler(api, container.ExportUseCase)
+	journals.NewJournalHandler(api, container.JournalUseCase)
+	rules.NewRuleHandler(api, container.RuleUseCase)
+	suggestions.NewSuggestionHandler(api, container.SuggestionUseCase)
+	duplicates.NewDuplicateHandler(api, container.DuplicateUseCase)
+	reconciliations.NewReconciliationHandler(api, container.ReconciliationUseCase)
+	views.NewViewHandler(api, container.ViewUseCase)
+	tags.NewTagHandler(api, container.TagUseCase)
+
+	jobs := scheduler.NewScheduler()
+	jobs.Every("recurring-transactions", time.Hour, container.RecurringUseCase.MaterializeDue)
+	jobs.Every("installments", time.Hour, container.InstallmentUseCase.PostDue)
+	jobs.Every("transaction-search", 24*time.Hour, container.TransactionUseCase.IndexSearch)
+	jobs.Start(context.Background())
 
 	router.Run("0.0.0.0:8180")
 }
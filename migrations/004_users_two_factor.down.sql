@@ -0,0 +1 @@
+ALTER TABLE users DROP COLUMN `twoFALastUsedStep`, MODIFY COLUMN `twoFACode` VARCHAR(6) DEFAULT '';
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE users
+    MODIFY COLUMN `twoFACode` VARCHAR(64) DEFAULT '',
+    ADD COLUMN `twoFALastUsedStep` BIGINT DEFAULT NULL AFTER `twoFACodeExpiration`;
\ No newline at end of file
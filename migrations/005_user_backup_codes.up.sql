@@ -0,0 +1,13 @@
+CREATE TABLE user_backup_codes (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `userId` BIGINT UNSIGNED NOT NULL,
+    `codeHash` CHAR(64) NOT NULL,
+    `usedAt` DATETIME DEFAULT NULL,
+    `createdAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_user_backup_code` (`userId`, `codeHash`),
+    CONSTRAINT `fk_user_backup_code`
+        FOREIGN KEY (`userId`) REFERENCES users(`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
\ No newline at end of file
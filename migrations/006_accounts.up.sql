@@ -0,0 +1,14 @@
+CREATE TABLE accounts (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `userId` BIGINT UNSIGNED NOT NULL,
+    `name` VARCHAR(100) NOT NULL,
+    `type` VARCHAR(20) NOT NULL,
+    `openingBalance` DECIMAL(12,2) NOT NULL DEFAULT 0,
+    `createdAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updatedAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    CONSTRAINT `fk_user_account`
+        FOREIGN KEY (`userId`) REFERENCES users(`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
\ No newline at end of file
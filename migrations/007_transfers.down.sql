@@ -0,0 +1 @@
+DROP TABLE IF EXISTS transfers;
\ No newline at end of file
@@ -0,0 +1,18 @@
+CREATE TABLE transfers (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `userId` BIGINT UNSIGNED NOT NULL,
+    `fromAccountId` BIGINT UNSIGNED NOT NULL,
+    `toAccountId` BIGINT UNSIGNED NOT NULL,
+    `amount` DECIMAL(10,2) NOT NULL,
+    `description` VARCHAR(255) NOT NULL,
+    `createdAt` DATETIME NOT NULL,
+    PRIMARY KEY (`id`),
+    CONSTRAINT `fk_user_transfer`
+        FOREIGN KEY (`userId`) REFERENCES users(`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT `fk_from_account_transfer`
+        FOREIGN KEY (`fromAccountId`) REFERENCES accounts(`id`),
+    CONSTRAINT `fk_to_account_transfer`
+        FOREIGN KEY (`toAccountId`) REFERENCES accounts(`id`)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE transactions DROP FOREIGN KEY `fk_transfer_transaction`, DROP FOREIGN KEY `fk_account_transaction`, DROP COLUMN `transferId`, DROP COLUMN `accountId`, MODIFY COLUMN `category` INT UNSIGNED NOT NULL;
\ No newline at end of file
@@ -0,0 +1,9 @@
+ALTER TABLE transactions
+    MODIFY COLUMN `category` INT UNSIGNED NULL,
+    ADD COLUMN `accountId` BIGINT UNSIGNED NULL AFTER `userId`,
+    ADD COLUMN `transferId` BIGINT UNSIGNED NULL AFTER `accountId`,
+    ADD CONSTRAINT `fk_account_transaction`
+        FOREIGN KEY (`accountId`) REFERENCES accounts(`id`),
+    ADD CONSTRAINT `fk_transfer_transaction`
+        FOREIGN KEY (`transferId`) REFERENCES transfers(`id`)
+        ON DELETE CASCADE;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE transactions DROP COLUMN `currency`;
\ No newline at end of file
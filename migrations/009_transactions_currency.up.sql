@@ -0,0 +1,2 @@
+ALTER TABLE transactions
+    ADD COLUMN `currency` CHAR(3) NOT NULL DEFAULT 'BRL' AFTER `amount`;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE accounts DROP COLUMN `currency`;
\ No newline at end of file
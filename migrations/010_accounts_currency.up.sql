@@ -0,0 +1,2 @@
+ALTER TABLE accounts
+    ADD COLUMN `currency` CHAR(3) NOT NULL DEFAULT 'BRL' AFTER `type`;
\ No newline at end of file
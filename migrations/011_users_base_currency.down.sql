@@ -0,0 +1 @@
+ALTER TABLE users DROP COLUMN `baseCurrency`;
\ No newline at end of file
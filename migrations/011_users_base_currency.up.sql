@@ -0,0 +1,2 @@
+ALTER TABLE users
+    ADD COLUMN `baseCurrency` CHAR(3) NOT NULL DEFAULT 'BRL' AFTER `email`;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE exchange_rates (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `base` CHAR(3) NOT NULL,
+    `quote` CHAR(3) NOT NULL,
+    `date` DATE NOT NULL,
+    `rate` DECIMAL(18,8) NOT NULL,
+    `createdAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_exchange_rate` (`base`, `quote`, `date`)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS recurring_transactions;
\ No newline at end of file
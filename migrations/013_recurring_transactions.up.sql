@@ -0,0 +1,26 @@
+CREATE TABLE recurring_transactions (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `userId` BIGINT UNSIGNED NOT NULL,
+    `accountId` BIGINT UNSIGNED NULL,
+    `description` VARCHAR(255) NOT NULL,
+    `category` INT UNSIGNED NOT NULL,
+    `amount` DECIMAL(10,2) NOT NULL,
+    `currency` CHAR(3) NOT NULL DEFAULT 'BRL',
+    `rule` VARCHAR(255) NOT NULL,
+    `startDate` DATE NOT NULL,
+    `postedThrough` DATE NULL,
+    `active` TINYINT(1) NOT NULL DEFAULT '1',
+    `createdAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updatedAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    CONSTRAINT `fk_user_recurring`
+        FOREIGN KEY (`userId`) REFERENCES users(`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT `fk_account_recurring`
+        FOREIGN KEY (`accountId`) REFERENCES accounts(`id`),
+    CONSTRAINT `fk_category_recurring`
+        FOREIGN KEY (`category`) REFERENCES categories(`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS recurring_occurrences;
\ No newline at end of file
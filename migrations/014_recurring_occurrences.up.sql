@@ -0,0 +1,16 @@
+CREATE TABLE recurring_occurrences (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `recurringId` BIGINT UNSIGNED NOT NULL,
+    `date` DATE NOT NULL,
+    `status` VARCHAR(10) NOT NULL,
+    `transactionId` BIGINT UNSIGNED NULL,
+    `createdAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_recurring_occurrence` (`recurringId`, `date`),
+    CONSTRAINT `fk_recurring_occurrence`
+        FOREIGN KEY (`recurringId`) REFERENCES recurring_transactions(`id`)
+        ON DELETE CASCADE,
+    CONSTRAINT `fk_transaction_occurrence`
+        FOREIGN KEY (`transactionId`) REFERENCES transactions(`id`)
+        ON DELETE SET NULL
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS installment_plans;
\ No newline at end of file
@@ -0,0 +1,26 @@
+CREATE TABLE installment_plans (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `userId` BIGINT UNSIGNED NOT NULL,
+    `accountId` BIGINT UNSIGNED NULL,
+    `description` VARCHAR(255) NOT NULL,
+    `category` INT UNSIGNED NOT NULL,
+    `totalAmount` DECIMAL(10,2) NOT NULL,
+    `currency` CHAR(3) NOT NULL DEFAULT 'BRL',
+    `installments` INT UNSIGNED NOT NULL,
+    `firstDueDate` DATE NOT NULL,
+    `interestRate` DECIMAL(7,4) NOT NULL DEFAULT 0,
+    `status` VARCHAR(12) NOT NULL,
+    `createdAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updatedAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    CONSTRAINT `fk_user_installment_plan`
+        FOREIGN KEY (`userId`) REFERENCES users(`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT `fk_account_installment_plan`
+        FOREIGN KEY (`accountId`) REFERENCES accounts(`id`),
+    CONSTRAINT `fk_category_installment_plan`
+        FOREIGN KEY (`category`) REFERENCES categories(`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS installments;
\ No newline at end of file
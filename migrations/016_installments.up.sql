@@ -0,0 +1,17 @@
+CREATE TABLE installments (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `planId` BIGINT UNSIGNED NOT NULL,
+    `number` INT UNSIGNED NOT NULL,
+    `dueDate` DATE NOT NULL,
+    `amount` DECIMAL(10,2) NOT NULL,
+    `status` VARCHAR(10) NOT NULL,
+    `transactionId` BIGINT UNSIGNED NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_plan_installment` (`planId`, `number`),
+    CONSTRAINT `fk_plan_installment`
+        FOREIGN KEY (`planId`) REFERENCES installment_plans(`id`)
+        ON DELETE CASCADE,
+    CONSTRAINT `fk_transaction_installment`
+        FOREIGN KEY (`transactionId`) REFERENCES transactions(`id`)
+        ON DELETE SET NULL
+);
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE transaction_splits (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `transactionId` BIGINT UNSIGNED NOT NULL,
+    `category` INT UNSIGNED NOT NULL,
+    `amount` DECIMAL(10,2) NOT NULL,
+    `note` VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (`id`),
+    KEY `idx_split_category` (`category`),
+    CONSTRAINT `fk_transaction_split`
+        FOREIGN KEY (`transactionId`) REFERENCES transactions(`id`)
+        ON DELETE CASCADE,
+    CONSTRAINT `fk_category_split`
+        FOREIGN KEY (`category`) REFERENCES categories(`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,30 @@
+CREATE TABLE import_profiles (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `userId` BIGINT UNSIGNED NOT NULL,
+    `name` VARCHAR(100) NOT NULL,
+    `accountId` BIGINT UNSIGNED NULL,
+    `category` INT UNSIGNED NOT NULL,
+    `currency` CHAR(3) NULL,
+    `delimiter` CHAR(1) NOT NULL DEFAULT ',',
+    `encoding` VARCHAR(20) NOT NULL DEFAULT 'utf-8',
+    `hasHeader` BOOLEAN NOT NULL DEFAULT TRUE,
+    `skipRows` INT NOT NULL DEFAULT 0,
+    `dateColumn` VARCHAR(100) NOT NULL,
+    `dateFormat` VARCHAR(30) NOT NULL,
+    `descriptionColumn` VARCHAR(100) NOT NULL,
+    `amountColumn` VARCHAR(100) NULL,
+    `debitColumn` VARCHAR(100) NULL,
+    `creditColumn` VARCHAR(100) NULL,
+    `decimalSeparator` CHAR(1) NOT NULL DEFAULT '.',
+    `createdAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updatedAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_import_profile_name` (`userId`, `name`),
+    CONSTRAINT `fk_user_import_profile`
+        FOREIGN KEY (`userId`) REFERENCES users(`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT `fk_account_import_profile`
+        FOREIGN KEY (`accountId`) REFERENCES accounts(`id`)
+        ON DELETE SET NULL
+);
\ No newline at end of file
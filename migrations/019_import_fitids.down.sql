@@ -0,0 +1 @@
+DROP TABLE IF EXISTS import_fitids;
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE import_fitids (
+    `accountId` BIGINT UNSIGNED NOT NULL,
+    `fitid` VARCHAR(255) NOT NULL,
+    `transactionId` BIGINT UNSIGNED NULL,
+    `createdAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`accountId`, `fitid`),
+    CONSTRAINT `fk_account_fitid`
+        FOREIGN KEY (`accountId`) REFERENCES accounts(`id`)
+        ON DELETE CASCADE,
+    CONSTRAINT `fk_transaction_fitid`
+        FOREIGN KEY (`transactionId`) REFERENCES transactions(`id`)
+        ON DELETE SET NULL
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS journal_sync;
\ No newline at end of file
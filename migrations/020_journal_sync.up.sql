@@ -0,0 +1,13 @@
+CREATE TABLE journal_sync (
+    `userId` BIGINT UNSIGNED NOT NULL,
+    `syncId` VARCHAR(100) NOT NULL,
+    `transactionId` BIGINT UNSIGNED NULL,
+    `createdAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`userId`, `syncId`),
+    CONSTRAINT `fk_user_journal_sync`
+        FOREIGN KEY (`userId`) REFERENCES users(`id`)
+        ON DELETE CASCADE,
+    CONSTRAINT `fk_transaction_journal_sync`
+        FOREIGN KEY (`transactionId`) REFERENCES transactions(`id`)
+        ON DELETE SET NULL
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS rules;
\ No newline at end of file
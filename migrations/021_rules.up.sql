@@ -0,0 +1,30 @@
+CREATE TABLE rules (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `userId` BIGINT UNSIGNED NOT NULL,
+    `name` VARCHAR(100) NOT NULL,
+    `priority` INT NOT NULL DEFAULT 0,
+    `enabled` BOOLEAN NOT NULL DEFAULT TRUE,
+    `stopProcessing` BOOLEAN NOT NULL DEFAULT FALSE,
+    `descriptionContains` VARCHAR(255) NULL,
+    `descriptionRegex` VARCHAR(255) NULL,
+    `amountMin` DECIMAL(10,2) NULL,
+    `amountMax` DECIMAL(10,2) NULL,
+    `accountId` BIGINT UNSIGNED NULL,
+    `weekdays` TINYINT UNSIGNED NOT NULL DEFAULT 0,
+    `setCategory` INT UNSIGNED NULL,
+    `setDescription` VARCHAR(255) NULL,
+    `createdAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updatedAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `idx_rule_priority` (`userId`, `priority`),
+    CONSTRAINT `fk_user_rule`
+        FOREIGN KEY (`userId`) REFERENCES users(`id`)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT `fk_account_rule`
+        FOREIGN KEY (`accountId`) REFERENCES accounts(`id`)
+        ON DELETE CASCADE,
+    CONSTRAINT `fk_category_rule`
+        FOREIGN KEY (`setCategory`) REFERENCES categories(`id`)
+        ON DELETE SET NULL
+);
\ No newline at end of file
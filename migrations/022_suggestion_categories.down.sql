@@ -0,0 +1 @@
+DROP TABLE IF EXISTS suggestion_categories;
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE suggestion_categories (
+    `userId` BIGINT UNSIGNED NOT NULL,
+    `category` INT UNSIGNED NOT NULL,
+    `documents` INT NOT NULL DEFAULT 0,
+    `tokens` INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (`userId`, `category`),
+    CONSTRAINT `fk_user_suggestion_category`
+        FOREIGN KEY (`userId`) REFERENCES users(`id`)
+        ON DELETE CASCADE,
+    CONSTRAINT `fk_category_suggestion_category`
+        FOREIGN KEY (`category`) REFERENCES categories(`id`)
+        ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS suggestion_tokens;
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE suggestion_tokens (
+    `userId` BIGINT UNSIGNED NOT NULL,
+    `category` INT UNSIGNED NOT NULL,
+    `token` VARCHAR(64) NOT NULL,
+    `count` INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (`userId`, `token`, `category`),
+    CONSTRAINT `fk_user_suggestion_token`
+        FOREIGN KEY (`userId`) REFERENCES users(`id`)
+        ON DELETE CASCADE,
+    CONSTRAINT `fk_category_suggestion_token`
+        FOREIGN KEY (`category`) REFERENCES categories(`id`)
+        ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS duplicate_resolutions;
\ No newline at end of file
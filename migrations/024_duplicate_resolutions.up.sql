@@ -0,0 +1,14 @@
+CREATE TABLE duplicate_resolutions (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `userId` BIGINT UNSIGNED NOT NULL,
+    `action` VARCHAR(10) NOT NULL,
+    `transactionId` BIGINT UNSIGNED NOT NULL,
+    `otherId` BIGINT UNSIGNED NOT NULL,
+    `snapshot` TEXT NULL,
+    `createdAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `idx_duplicate_resolution_action` (`userId`, `action`),
+    CONSTRAINT `fk_user_duplicate_resolution`
+        FOREIGN KEY (`userId`) REFERENCES users(`id`)
+        ON DELETE CASCADE
+);
\ No newline at end of file
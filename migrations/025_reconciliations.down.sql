@@ -0,0 +1 @@
+DROP TABLE IF EXISTS reconciliations;
\ No newline at end of file
@@ -0,0 +1,18 @@
+CREATE TABLE reconciliations (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    `userId` BIGINT UNSIGNED NOT NULL,
+    `accountId` BIGINT UNSIGNED NOT NULL,
+    `statementDate` DATE NOT NULL,
+    `statementBalance` DECIMAL(10,2) NOT NULL,
+    `status` VARCHAR(10) NOT NULL DEFAULT 'open',
+    `createdAt` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `finishedAt` DATETIME NULL,
+    PRIMARY KEY (`id`),
+    KEY `idx_reconciliation_account` (`userId`, `accountId`, `status`),
+    CONSTRAINT `fk_user_reconciliation`
+        FOREIGN KEY (`userId`) REFERENCES users(`id`)
+        ON DELETE CASCADE,
+    CONSTRAINT `fk_account_reconciliation`
+        FOREIGN KEY (`accountId`) REFERENCES accounts(`id`)
+        ON DELETE CASCADE
+);
\ No newline at end of file
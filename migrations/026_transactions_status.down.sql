@@ -0,0 +1 @@
+ALTER TABLE transactions DROP FOREIGN KEY `fk_reconciliation_transaction`, DROP COLUMN `reconciliationId`, DROP COLUMN `locked`, DROP COLUMN `status`;
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE transactions
+    ADD COLUMN `status` VARCHAR(10) NOT NULL DEFAULT 'pending' AFTER `currency`,
+    ADD COLUMN `locked` BOOLEAN NOT NULL DEFAULT FALSE AFTER `status`,
+    ADD COLUMN `reconciliationId` BIGINT UNSIGNED NULL AFTER `locked`,
+    ADD CONSTRAINT `fk_reconciliation_transaction`
+        FOREIGN KEY (`reconciliationId`) REFERENCES reconciliations(`id`)
+        ON DELETE SET NULL;
\ No newline at end of file
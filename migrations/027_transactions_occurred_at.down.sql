@@ -0,0 +1 @@
+ALTER TABLE transactions DROP KEY `idx_transaction_occurred`, DROP COLUMN `occurredOffset`, DROP COLUMN `occurredAt`;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE transactions
+    ADD COLUMN `occurredAt` DATETIME NULL AFTER `transferId`,
+    ADD COLUMN `occurredOffset` SMALLINT NOT NULL DEFAULT 0 AFTER `occurredAt`,
+    ADD KEY `idx_transaction_occurred` (`userId`, `occurredAt`);
\ No newline at end of file
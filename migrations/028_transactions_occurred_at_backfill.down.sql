@@ -0,0 +1 @@
+UPDATE transactions SET `occurredAt` = NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+UPDATE transactions SET `occurredAt` = `createdAt` WHERE `occurredAt` IS NULL;
\ No newline at end of file
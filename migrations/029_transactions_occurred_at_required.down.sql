@@ -0,0 +1 @@
+ALTER TABLE transactions MODIFY COLUMN `occurredAt` DATETIME NULL;
\ No newline at end of file
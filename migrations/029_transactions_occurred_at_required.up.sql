@@ -0,0 +1 @@
+ALTER TABLE transactions MODIFY COLUMN `occurredAt` DATETIME NOT NULL;
\ No newline at end of file
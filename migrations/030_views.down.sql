@@ -0,0 +1 @@
+DROP TABLE IF EXISTS views;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE transactions DROP COLUMN `searchText`;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE transactions
+    ADD COLUMN `searchText` TEXT NULL AFTER `description`;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE transactions DROP KEY `ft_transaction_search`;
\ No newline at end of file
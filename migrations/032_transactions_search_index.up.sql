@@ -0,0 +1 @@
+ALTER TABLE transactions ADD FULLTEXT KEY `ft_transaction_search` (`searchText`);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS transaction_tags;
\ No newline at end of file
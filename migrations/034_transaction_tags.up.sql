@@ -0,0 +1,12 @@
+CREATE TABLE transaction_tags (
+    `transactionId` BIGINT UNSIGNED NOT NULL,
+    `tagId` BIGINT UNSIGNED NOT NULL,
+    PRIMARY KEY (`transactionId`, `tagId`),
+    KEY `idx_transaction_tag_tag` (`tagId`),
+    CONSTRAINT `fk_transaction_transaction_tag`
+        FOREIGN KEY (`transactionId`) REFERENCES transactions(`id`)
+        ON DELETE CASCADE,
+    CONSTRAINT `fk_tag_transaction_tag`
+        FOREIGN KEY (`tagId`) REFERENCES tags(`id`)
+        ON DELETE CASCADE
+);
\ No newline at end of file
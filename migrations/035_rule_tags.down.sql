@@ -0,0 +1 @@
+DROP TABLE IF EXISTS rule_tags;
\ No newline at end of file
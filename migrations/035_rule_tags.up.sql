@@ -0,0 +1,12 @@
+CREATE TABLE rule_tags (
+    `ruleId` BIGINT UNSIGNED NOT NULL,
+    `tagId` BIGINT UNSIGNED NOT NULL,
+    PRIMARY KEY (`ruleId`, `tagId`),
+    KEY `idx_rule_tag_tag` (`tagId`),
+    CONSTRAINT `fk_rule_rule_tag`
+        FOREIGN KEY (`ruleId`) REFERENCES rules(`id`)
+        ON DELETE CASCADE,
+    CONSTRAINT `fk_tag_rule_tag`
+        FOREIGN KEY (`tagId`) REFERENCES tags(`id`)
+        ON DELETE CASCADE
+);
\ No newline at end of file
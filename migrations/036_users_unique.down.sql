@@ -0,0 +1 @@
+ALTER TABLE users DROP INDEX `uq_user_email`, DROP INDEX `uq_user_username`;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE users ADD UNIQUE KEY `uq_user_email` (`email`), ADD UNIQUE KEY `uq_user_username` (`username`);
\ No newline at end of file
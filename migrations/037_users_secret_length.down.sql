@@ -0,0 +1 @@
+ALTER TABLE users MODIFY COLUMN `twoFACode` VARCHAR(64) DEFAULT '';
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE users MODIFY COLUMN `twoFACode` VARCHAR(255) DEFAULT '';
\ No newline at end of file
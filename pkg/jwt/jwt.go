@@ -1,50 +1,116 @@
 package jwt
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"os"
+	"sync"
+	"time"
 
 	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/joho/godotenv"
 )
 
-var secretKey []byte
+var (
+	secretKey []byte
+	once      sync.Once
+)
 
-func init() {
-	err := godotenv.Load(".env")
-	if err != nil {
-		panic("Error loading .env file")
-	}
-	secret := os.Getenv("SECRET_KEY")
-	if secret == "" {
-		panic("SECRET_KEY not set in .env file")
-	}
-	secretKey = []byte(secret)
+func getSecretKey() []byte {
+	once.Do(func() {
+		if os.Getenv("SECRET_KEY") == "" {
+			if err := godotenv.Load(".env"); err != nil {
+				panic("Error loading .env file")
+			}
+		}
+		secret := os.Getenv("SECRET_KEY")
+		if secret == "" {
+			panic("SECRET_KEY not set in .env file")
+		}
+		secretKey = []byte(secret)
+	})
+	return secretKey
 }
 
+const ScopeTwoFactorPending = "2fa_pending"
+
 type Claims struct {
-	UserID int64 `json:"userID"`
+	UserID        int64  `json:"userID"`
+	Scope         string `json:"scope,omitempty"`
+	SessionID     string `json:"sid,omitempty"`
+	IssuedAtMicro int64  `json:"iat_us,omitempty"`
 	jwt.StandardClaims
 }
 
+func GenerateToken(userID int64, sessionID string, duration time.Duration) (string, error) {
+	return generate(&Claims{UserID: userID, SessionID: sessionID}, duration)
+}
+
+// GenerateScopedToken issues a token limited to the given scope.
+func GenerateScopedToken(userID int64, scope string, duration time.Duration) (string, error) {
+	return generate(&Claims{UserID: userID, Scope: scope}, duration)
+}
+
+func generate(claims *Claims, duration time.Duration) (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims.IssuedAtMicro = now.UnixMicro()
+	claims.StandardClaims = jwt.StandardClaims{
+		Id:        hex.EncodeToString(id),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(duration).Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(getSecretKey())
+}
+
 func ParseToken(tokenString string) (int64, error) {
+	claims, err := parseClaims(tokenString)
+	if err != nil {
+		return 0, err
+	}
+	return claims.UserID, nil
+}
+
+func ParseScopedToken(tokenString, scope string) (int64, error) {
+	claims, err := parseClaims(tokenString)
+	if err != nil {
+		return 0, err
+	}
+	if claims.Scope != scope {
+		return 0, errors.New("invalid token scope")
+	}
+	return claims.UserID, nil
+}
+
+func parseClaims(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return secretKey, nil
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return getSecretKey(), nil
 	})
 	if err != nil {
 		if ve, ok := err.(*jwt.ValidationError); ok {
 			if ve.Errors&jwt.ValidationErrorMalformed != 0 {
-				return 0, errors.New("invalid token format")
+				return nil, errors.New("invalid token format")
 			} else if ve.Errors&(jwt.ValidationErrorExpired|jwt.ValidationErrorNotValidYet) != 0 {
-				return 0, errors.New("token expired or not active yet")
+				return nil, errors.New("token expired or not active yet")
 			} else {
-				return 0, errors.New("could not handle token. did you tried to hack us?")
+				return nil, errors.New("could not handle token. did you tried to hack us?")
 			}
 		}
+		return nil, err
 	}
 
 	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		return claims.UserID, nil
+		return claims, nil
 	}
-	return 0, errors.New("invalid token")
+	return nil, errors.New("invalid token")
 }
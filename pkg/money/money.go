@@ -0,0 +1,292 @@
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const DefaultCurrency = "BRL"
+
+// MaxAmount is the largest amount, in cents, the amount columns hold.
+const MaxAmount = 9999999999
+
+var (
+	plainDecimal  = regexp.MustCompile(`^-?\d+([.,]\d{1,2})?$`)
+	storedDecimal = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrAmountOutOfRange = errors.New("amount must be between -99999999.99 and 99999999.99")
+)
+
+// Money stores amounts as an integer number of minor units (cents) so sums
+// never drift the way float64 amounts do.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+func Zero(currency string) Money {
+	return Money{Currency: currency}
+}
+
+// Parse reads a plain decimal such as "-12.34" or "12,34", with at most two
+// decimal places and within the range of the amount columns.
+func Parse(value string, currency string) (Money, error) {
+	value = strings.TrimSpace(value)
+	if !plainDecimal.MatchString(value) {
+		return Money{}, fmt.Errorf("%w %q", ErrInvalidAmount, value)
+	}
+
+	rat, _ := new(big.Rat).SetString(strings.Replace(value, ",", ".", 1))
+	cents, ok := roundCents(rat)
+	if !ok || cents > MaxAmount || cents < -MaxAmount {
+		return Money{}, ErrAmountOutOfRange
+	}
+	return Money{Amount: cents, Currency: currency}, nil
+}
+
+func MustParse(value string, currency string) Money {
+	m, err := Parse(value, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// FromRat converts an exact decimal amount in major units, rounding half to
+// even to the nearest minor unit.
+func FromRat(value *big.Rat, currency string) Money {
+	cents, ok := roundCents(value)
+	if !ok {
+		panic(fmt.Sprintf("money: %s overflows", value.FloatString(2)))
+	}
+	return Money{Amount: cents, Currency: currency}
+}
+
+func (m Money) Rat() *big.Rat {
+	return big.NewRat(m.Amount, 100)
+}
+
+func (m Money) String() string {
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/100, amount%100)
+}
+
+// Format renders the amount with the given decimal and thousands separators.
+func (m Money) Format(decimalSeparator, thousandsSeparator string) string {
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(amount/100, 10)
+	if thousandsSeparator != "" {
+		var grouped strings.Builder
+		for i, digit := range digits {
+			if i > 0 && (len(digits)-i)%3 == 0 {
+				grouped.WriteString(thousandsSeparator)
+			}
+			grouped.WriteRune(digit)
+		}
+		digits = grouped.String()
+	}
+	return fmt.Sprintf("%s%s%s%02d", sign, digits, decimalSeparator, amount%100)
+}
+
+// Add and Sub panic when both amounts have a currency and they differ.
+func (m Money) Add(other Money) Money {
+	return Money{Amount: m.Amount + other.Amount, Currency: m.currencyWith(other)}
+}
+
+func (m Money) Sub(other Money) Money {
+	return Money{Amount: m.Amount - other.Amount, Currency: m.currencyWith(other)}
+}
+
+func (m Money) Neg() Money {
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+func (m Money) Abs() Money {
+	if m.Amount < 0 {
+		return m.Neg()
+	}
+	return m
+}
+
+func (m Money) Mul(factor int64) Money {
+	return Money{Amount: m.Amount * factor, Currency: m.Currency}
+}
+
+// Convert multiplies the amount by rate and rounds the result half to even
+// into the target currency.
+func (m Money) Convert(rate *big.Rat, currency string) (Money, error) {
+	cents, ok := roundCents(new(big.Rat).Mul(m.Rat(), rate))
+	if !ok {
+		return Money{}, fmt.Errorf("converting %s %s to %s overflows", m, m.Currency, currency)
+	}
+	return Money{Amount: cents, Currency: currency}, nil
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+func (m Money) Cmp(other Money) int {
+	switch {
+	case m.Amount < other.Amount:
+		return -1
+	case m.Amount > other.Amount:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Percentage returns part/total*100 rounded half to even to two decimals.
+func Percentage(part, total Money) float64 {
+	if total.Amount == 0 {
+		return 0
+	}
+	ratio := new(big.Rat).SetFrac64(part.Amount*100, total.Amount)
+	return RoundRat(ratio, 2)
+}
+
+// RoundRat rounds half to even to the given number of decimal places.
+func RoundRat(value *big.Rat, places int) float64 {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(places)), nil)
+	scaled := new(big.Rat).Mul(value, new(big.Rat).SetInt(scale))
+	rounded := new(big.Rat).SetFrac(big.NewInt(RoundHalfEven(scaled)), scale)
+	f, _ := rounded.Float64()
+	return f
+}
+
+func RoundHalfEven(value *big.Rat) int64 {
+	return roundHalfEven(value).Int64()
+}
+
+func roundCents(value *big.Rat) (int64, bool) {
+	cents := roundHalfEven(new(big.Rat).Mul(value, big.NewRat(100, 1)))
+	return cents.Int64(), cents.IsInt64()
+}
+
+func roundHalfEven(value *big.Rat) *big.Int {
+	num := value.Num()
+	den := value.Denom()
+
+	quotient, remainder := new(big.Int).QuoRem(num, den, new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+
+	doubled := new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2))
+	switch doubled.Cmp(den) {
+	case 1:
+		quotient.Add(quotient, big.NewInt(int64(num.Sign())))
+	case 0:
+		if quotient.Bit(0) == 1 {
+			quotient.Add(quotient, big.NewInt(int64(num.Sign())))
+		}
+	}
+	return quotient
+}
+
+func (m Money) currencyWith(other Money) string {
+	switch {
+	case m.Currency == "":
+		return other.Currency
+	case other.Currency != "" && other.Currency != m.Currency:
+		panic(fmt.Sprintf("money: cannot combine %s and %s amounts", m.Currency, other.Currency))
+	}
+	return m.Currency
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON accepts both "12.34" and 12.34; numbers are parsed from their
+// literal text so they never pass through float64.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	raw := strings.TrimSpace(string(data))
+	if raw == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(raw); err == nil {
+		raw = unquoted
+	}
+
+	parsed, err := Parse(raw, m.Currency)
+	if err != nil {
+		return err
+	}
+	if parsed.Currency == "" {
+		parsed.Currency = DefaultCurrency
+	}
+	*m = parsed
+	return nil
+}
+
+func (m *Money) Scan(src interface{}) error {
+	currency := m.Currency
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+
+	var raw string
+	switch value := src.(type) {
+	case nil:
+		*m = Zero(currency)
+		return nil
+	case []byte:
+		raw = string(value)
+	case string:
+		raw = value
+	case int64:
+		*m = New(value*100, currency)
+		return nil
+	case float64:
+		raw = strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return fmt.Errorf("cannot scan %T into money.Money", src)
+	}
+
+	if !storedDecimal.MatchString(raw) {
+		return fmt.Errorf("cannot scan %q into money.Money", raw)
+	}
+	rat, _ := new(big.Rat).SetString(raw)
+	cents, ok := roundCents(rat)
+	if !ok {
+		return fmt.Errorf("cannot scan %q into money.Money: out of range", raw)
+	}
+	*m = New(cents, currency)
+	return nil
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Renan-Parise/finances/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddDoesNotDrift(t *testing.T) {
+	sum := money.MustParse("0.1", "BRL").Add(money.MustParse("0.2", "BRL"))
+	assert.Equal(t, "0.30", sum.String())
+	assert.Equal(t, int64(30), sum.Amount)
+}
+
+func TestParse(t *testing.T) {
+	cases := map[string]string{
+		"2,50":         "2.50",
+		"-0.07":        "-0.07",
+		"10":           "10.00",
+		"1.5":          "1.50",
+		"99999999.99":  "99999999.99",
+		"-99999999.99": "-99999999.99",
+	}
+
+	for input, expected := range cases {
+		m, err := money.Parse(input, "BRL")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, m.String(), "parsing %s", input)
+	}
+
+	for _, input := range []string{"", "abc", "1/3", "1e30", "1.005", "+1.00", ".50", "1,000.00", "100000000.00"} {
+		_, err := money.Parse(input, "BRL")
+		assert.True(t, errors.Is(err, money.ErrInvalidAmount) || errors.Is(err, money.ErrAmountOutOfRange), "parsing %q", input)
+	}
+}
+
+func TestCombiningCurrenciesPanics(t *testing.T) {
+	assert.Equal(t, "3.00", money.Zero("").Add(money.New(300, "USD")).String())
+	assert.Panics(t, func() { money.New(100, "BRL").Add(money.New(100, "USD")) })
+	assert.Panics(t, func() { money.New(100, "BRL").Sub(money.New(100, "USD")) })
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	var payload struct {
+		Amount money.Money `json:"amount"`
+	}
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount": 19.99}`), &payload))
+	assert.Equal(t, int64(1999), payload.Amount.Amount)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount": "-5.10"}`), &payload))
+	assert.Equal(t, int64(-510), payload.Amount.Amount)
+
+	encoded, err := json.Marshal(payload)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount": "-5.10"}`, string(encoded))
+}
+
+func TestScanAndValue(t *testing.T) {
+	var m money.Money
+	assert.NoError(t, m.Scan([]byte("-1234.56")))
+	assert.Equal(t, int64(-123456), m.Amount)
+	assert.Equal(t, money.DefaultCurrency, m.Currency)
+
+	value, err := m.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, driver.Value("-1234.56"), value)
+
+	assert.NoError(t, m.Scan([]byte("1.0050")))
+	assert.Equal(t, int64(100), m.Amount)
+	assert.NoError(t, m.Scan([]byte("-1.015")))
+	assert.Equal(t, int64(-102), m.Amount)
+
+	assert.Error(t, m.Scan([]byte("1e30")))
+
+	assert.NoError(t, m.Scan(nil))
+	assert.True(t, m.IsZero())
+}
+
+func TestPercentageUsesBankersRounding(t *testing.T) {
+	total := money.New(300, "BRL")
+	assert.Equal(t, 33.33, money.Percentage(money.New(100, "BRL"), total))
+
+	assert.Equal(t, 0.12, money.Percentage(money.New(1, "BRL"), money.New(800, "BRL")))
+	assert.Equal(t, 0.38, money.Percentage(money.New(3, "BRL"), money.New(800, "BRL")))
+
+	assert.Equal(t, 0.0, money.Percentage(money.New(1, "BRL"), money.Zero("BRL")))
+}
+
+func TestConvertRoundsIntoTargetCurrency(t *testing.T) {
+	converted, err := money.MustParse("10.00", "USD").Convert(big.NewRat(54321, 10000), "BRL")
+	assert.NoError(t, err)
+	assert.Equal(t, "54.32", converted.String())
+	assert.Equal(t, "BRL", converted.Currency)
+
+	tie, err := money.MustParse("0.25", "USD").Convert(big.NewRat(1, 2), "BRL")
+	assert.NoError(t, err)
+	assert.Equal(t, "0.12", tie.String())
+
+	_, err = money.MustParse("99999999.99", "USD").Convert(big.NewRat(1e18, 1), "BRL")
+	assert.Error(t, err)
+}
+
+func TestFormatWithSeparators(t *testing.T) {
+	assert.Equal(t, "-1.234.567,89", money.MustParse("-1234567.89", "BRL").Format(",", "."))
+	assert.Equal(t, "1,234.50", money.MustParse("1234.5", "USD").Format(".", ","))
+	assert.Equal(t, "999.00", money.MustParse("999", "USD").Format(".", ","))
+	assert.Equal(t, "0,05", money.MustParse("0.05", "BRL").Format(",", ""))
+}
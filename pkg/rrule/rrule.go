@@ -0,0 +1,417 @@
+package rrule
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Daily   = "DAILY"
+	Weekly  = "WEEKLY"
+	Monthly = "MONTHLY"
+	Yearly  = "YEARLY"
+)
+
+const maxPeriods = 50000
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// WeekdayNum is a BYDAY entry.
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// Rule is the subset of RFC 5545 recurrence rules used for scheduled
+// transactions.
+type Rule struct {
+	Freq       string
+	Interval   int
+	ByMonthDay []int
+	ByDay      []WeekdayNum
+	BySetPos   []int
+	Count      int
+	Until      time.Time
+	Start      time.Time
+}
+
+// Parse reads a rule such as "FREQ=MONTHLY;BYMONTHDAY=5;COUNT=12" anchored at
+// start.
+func Parse(value string, start time.Time) (*Rule, error) {
+	rule := &Rule{Interval: 1, Start: Date(start)}
+
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, fmt.Errorf("empty rule")
+	}
+
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return nil, fmt.Errorf("invalid rule part %q", part)
+		}
+
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = strings.ToUpper(val)
+			switch rule.Freq {
+			case Daily, Weekly, Monthly, Yearly:
+			default:
+				err = fmt.Errorf("unsupported FREQ %q", val)
+			}
+		case "INTERVAL":
+			rule.Interval, err = parsePositive(val)
+		case "COUNT":
+			rule.Count, err = parsePositive(val)
+		case "UNTIL":
+			rule.Until, err = parseUntil(val)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseInts(val, 31)
+		case "BYSETPOS":
+			rule.BySetPos, err = parseInts(val, 366)
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(val)
+		default:
+			err = fmt.Errorf("unsupported rule part %q", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("FREQ is required")
+	}
+
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return nil, fmt.Errorf("COUNT and UNTIL cannot be combined")
+	}
+
+	if !rule.Until.IsZero() && rule.Until.Before(rule.Start) {
+		return nil, fmt.Errorf("UNTIL is before the start date")
+	}
+
+	return rule, nil
+}
+
+// String renders the rule back into its RRULE form.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = day.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(r.BySetPos))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+	return strings.Join(parts, ";")
+}
+
+func (d WeekdayNum) String() string {
+	for code, weekday := range weekdays {
+		if weekday == d.Weekday {
+			if d.N != 0 {
+				return strconv.Itoa(d.N) + code
+			}
+			return code
+		}
+	}
+	return ""
+}
+
+// Between returns the occurrences falling on dates from through to, inclusive.
+func (r *Rule) Between(from, to time.Time) []time.Time {
+	from, to = Date(from), Date(to)
+
+	var dates []time.Time
+	r.iterate(func(date time.Time) bool {
+		if date.After(to) {
+			return false
+		}
+		if !date.Before(from) {
+			dates = append(dates, date)
+		}
+		return true
+	})
+	return dates
+}
+
+// Next returns up to limit occurrences on or after from.
+func (r *Rule) Next(from time.Time, limit int) []time.Time {
+	from = Date(from)
+
+	var dates []time.Time
+	r.iterate(func(date time.Time) bool {
+		if len(dates) >= limit {
+			return false
+		}
+		if !date.Before(from) {
+			dates = append(dates, date)
+		}
+		return len(dates) < limit
+	})
+	return dates
+}
+
+// Includes reports whether date is one of the rule's occurrences.
+func (r *Rule) Includes(date time.Time) bool {
+	date = Date(date)
+	dates := r.Between(date, date)
+	return len(dates) == 1
+}
+
+// Date truncates t to its calendar date at midnight UTC.
+func Date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (r *Rule) iterate(yield func(time.Time) bool) {
+	emitted := 0
+	for period := 0; period < maxPeriods; period++ {
+		for _, date := range r.expand(period) {
+			if date.Before(r.Start) {
+				continue
+			}
+			if !r.Until.IsZero() && date.After(r.Until) {
+				return
+			}
+			if !yield(date) {
+				return
+			}
+			emitted++
+			if r.Count > 0 && emitted >= r.Count {
+				return
+			}
+		}
+	}
+}
+
+func (r *Rule) expand(period int) []time.Time {
+	step := period * r.Interval
+	var candidates []time.Time
+
+	switch r.Freq {
+	case Daily:
+		date := r.Start.AddDate(0, 0, step)
+		if r.matchesWeekday(date) && r.matchesMonthDay(date) {
+			candidates = append(candidates, date)
+		}
+	case Weekly:
+		offset := (int(r.Start.Weekday()) + 6) % 7
+		monday := r.Start.AddDate(0, 0, -offset+7*step)
+		for i := 0; i < 7; i++ {
+			date := monday.AddDate(0, 0, i)
+			if len(r.ByDay) == 0 && date.Weekday() != r.Start.Weekday() {
+				continue
+			}
+			if r.matchesWeekday(date) {
+				candidates = append(candidates, date)
+			}
+		}
+	case Monthly:
+		first := time.Date(r.Start.Year(), r.Start.Month()+time.Month(step), 1, 0, 0, 0, 0, time.UTC)
+		candidates = r.expandMonth(first)
+	case Yearly:
+		date := time.Date(r.Start.Year()+step, r.Start.Month(), r.Start.Day(), 0, 0, 0, 0, time.UTC)
+		if date.Day() == r.Start.Day() {
+			candidates = append(candidates, date)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+	return r.applySetPos(candidates)
+}
+
+func (r *Rule) expandMonth(first time.Time) []time.Time {
+	days := daysIn(first)
+	var candidates []time.Time
+
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, day := range r.ByMonthDay {
+			if day < 0 {
+				day = days + day + 1
+			}
+			if day < 1 || day > days {
+				continue
+			}
+			date := first.AddDate(0, 0, day-1)
+			if r.matchesWeekday(date) {
+				candidates = append(candidates, date)
+			}
+		}
+	case len(r.ByDay) > 0:
+		seen := make(map[int]bool)
+		for _, byDay := range r.ByDay {
+			var matches []time.Time
+			for day := 0; day < days; day++ {
+				date := first.AddDate(0, 0, day)
+				if date.Weekday() == byDay.Weekday {
+					matches = append(matches, date)
+				}
+			}
+			if byDay.N != 0 {
+				index := byDay.N - 1
+				if byDay.N < 0 {
+					index = len(matches) + byDay.N
+				}
+				if index < 0 || index >= len(matches) {
+					continue
+				}
+				matches = matches[index : index+1]
+			}
+			for _, date := range matches {
+				if !seen[date.Day()] {
+					seen[date.Day()] = true
+					candidates = append(candidates, date)
+				}
+			}
+		}
+	default:
+		if r.Start.Day() <= days {
+			candidates = append(candidates, first.AddDate(0, 0, r.Start.Day()-1))
+		}
+	}
+	return candidates
+}
+
+func (r *Rule) applySetPos(candidates []time.Time) []time.Time {
+	if len(r.BySetPos) == 0 {
+		return candidates
+	}
+
+	var selected []time.Time
+	for _, pos := range r.BySetPos {
+		index := pos - 1
+		if pos < 0 {
+			index = len(candidates) + pos
+		}
+		if index >= 0 && index < len(candidates) {
+			selected = append(selected, candidates[index])
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].Before(selected[j]) })
+	return selected
+}
+
+func (r *Rule) matchesWeekday(date time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, day := range r.ByDay {
+		if day.Weekday == date.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) matchesMonthDay(date time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	days := daysIn(date)
+	for _, day := range r.ByMonthDay {
+		if day < 0 {
+			day = days + day + 1
+		}
+		if day == date.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func parsePositive(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("expected a positive integer, got %q", value)
+	}
+	return n, nil
+}
+
+func parseInts(value string, limit int) ([]int, error) {
+	var values []int
+	for _, part := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n == 0 || n < -limit || n > limit {
+			return nil, fmt.Errorf("invalid value %q", part)
+		}
+		values = append(values, n)
+	}
+	return values, nil
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	var days []WeekdayNum
+	for _, part := range strings.Split(strings.ToUpper(value), ",") {
+		part = strings.TrimSpace(part)
+		if len(part) < 2 {
+			return nil, fmt.Errorf("invalid BYDAY %q", part)
+		}
+
+		weekday, ok := weekdays[part[len(part)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid BYDAY %q", part)
+		}
+
+		day := WeekdayNum{Weekday: weekday}
+		if prefix := part[:len(part)-2]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, fmt.Errorf("invalid BYDAY %q", part)
+			}
+			day.N = n
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102", "20060102T150405Z", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return Date(t), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid UNTIL %q", value)
+}
+
+func joinInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/pkg/rrule"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestMonthlyOnDayWithCount(t *testing.T) {
+	rule, err := rrule.Parse("FREQ=MONTHLY;BYMONTHDAY=5;COUNT=3", date(2026, 1, 10))
+	assert.NoError(t, err)
+
+	dates := rule.Between(date(2026, 1, 1), date(2026, 12, 31))
+	assert.Equal(t, []time.Time{date(2026, 2, 5), date(2026, 3, 5), date(2026, 4, 5)}, dates)
+}
+
+func TestEveryTwoWeeksUntil(t *testing.T) {
+	rule, err := rrule.Parse("FREQ=WEEKLY;INTERVAL=2;UNTIL=20260215", date(2026, 1, 2))
+	assert.NoError(t, err)
+
+	dates := rule.Between(date(2026, 1, 1), date(2026, 12, 31))
+	assert.Equal(t, []time.Time{date(2026, 1, 2), date(2026, 1, 16), date(2026, 1, 30), date(2026, 2, 13)}, dates)
+}
+
+func TestLastBusinessDayOfMonth(t *testing.T) {
+	rule, err := rrule.Parse("FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", date(2026, 1, 1))
+	assert.NoError(t, err)
+
+	dates := rule.Next(date(2026, 1, 1), 5)
+	assert.Equal(t, []time.Time{
+		date(2026, 1, 30), date(2026, 2, 27), date(2026, 3, 31), date(2026, 4, 30), date(2026, 5, 29),
+	}, dates)
+}
+
+func TestLastDayOfMonthAndOrdinalWeekday(t *testing.T) {
+	rule, err := rrule.Parse("FREQ=MONTHLY;BYMONTHDAY=-1", date(2026, 1, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Time{date(2026, 1, 31), date(2026, 2, 28)}, rule.Next(date(2026, 1, 1), 2))
+
+	rule, err = rrule.Parse("FREQ=MONTHLY;BYDAY=2MO", date(2026, 1, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Time{date(2026, 1, 12), date(2026, 2, 9)}, rule.Next(date(2026, 1, 1), 2))
+}
+
+func TestIncludesAndString(t *testing.T) {
+	rule, err := rrule.Parse("RRULE:FREQ=MONTHLY;BYMONTHDAY=10;COUNT=2", date(2026, 3, 1))
+	assert.NoError(t, err)
+
+	assert.True(t, rule.Includes(date(2026, 4, 10)))
+	assert.False(t, rule.Includes(date(2026, 5, 10)))
+	assert.Equal(t, "FREQ=MONTHLY;BYMONTHDAY=10;COUNT=2", rule.String())
+}
+
+func TestParseRejectsInvalidRules(t *testing.T) {
+	invalid := []string{
+		"",
+		"BYMONTHDAY=5",
+		"FREQ=HOURLY",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=MONTHLY;COUNT=2;UNTIL=20270101",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=DAILY;INTERVAL=0",
+	}
+
+	for _, value := range invalid {
+		_, err := rrule.Parse(value, date(2026, 1, 1))
+		assert.Error(t, err, "parsing %q", value)
+	}
+}
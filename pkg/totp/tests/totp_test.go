@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Renan-Parise/finances/pkg/totp"
+	"github.com/stretchr/testify/assert"
+)
+
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateCodeMatchesRFC6238Vectors(t *testing.T) {
+	vectors := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, vector := range vectors {
+		code, err := totp.GenerateCode(rfcSecret, time.Unix(vector.unix, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, vector.code, code, "unexpected code at %d", vector.unix)
+	}
+}
+
+func TestVerifyAcceptsAdjacentStepsOnly(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	code, err := totp.GenerateCode(rfcSecret, now)
+	assert.NoError(t, err)
+
+	step, ok := totp.Verify(rfcSecret, code, now.Add(totp.Period*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, totp.Step(now), step)
+
+	_, ok = totp.Verify(rfcSecret, code, now.Add(3*totp.Period*time.Second))
+	assert.False(t, ok)
+
+	_, ok = totp.Verify(rfcSecret, "12345", now)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecretAndURI(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	uri := totp.URI("Finances", "jane@example.com", secret)
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Finances:jane@example.com?"))
+	assert.Contains(t, uri, "secret="+secret)
+	assert.Contains(t, uri, "issuer=Finances")
+}